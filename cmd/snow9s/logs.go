@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/marcelinojackson-org/snow9s/internal/snowflake"
+	"github.com/spf13/cobra"
+)
+
+func runLogs(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if logLines < 1 {
+		return fmt.Errorf("--lines must be at least 1, got %d", logLines)
+	}
+	if followLogs && followInterval <= 0 {
+		return fmt.Errorf("--interval must be positive, got %s", followInterval)
+	}
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	cfg, logger, err := loadConfigAndLogger()
+	if err != nil {
+		return err
+	}
+	client, err := connect(ctx, cfg, logger)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	spcs := snowflake.NewSPCS(client, cfg)
+	fetch := func() ([]string, error) {
+		fetchCtx, cancel := context.WithTimeout(ctx, cfg.Timeout())
+		defer cancel()
+		logs, err := spcs.GetServiceLogs(fetchCtx, name, logContainer, logLines)
+		if err != nil {
+			return nil, err
+		}
+		return splitLogLines(logs), nil
+	}
+
+	out := cmd.OutOrStdout()
+	lines, err := fetch()
+	if err != nil {
+		return err
+	}
+	writeLines(out, lines)
+	if !followLogs {
+		return nil
+	}
+
+	ticker := time.NewTicker(followInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		tail, err := fetch()
+		if errors.Is(err, context.Canceled) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		writeLines(out, newLogLines(lines, tail))
+		lines = tail
+	}
+}
+
+// splitLogLines breaks SYSTEM$GET_SERVICE_LOGS output into lines, dropping
+// the trailing newline.
+func splitLogLines(logs string) []string {
+	logs = strings.TrimRight(logs, "\n")
+	if logs == "" {
+		return nil
+	}
+	return strings.Split(logs, "\n")
+}
+
+// newLogLines returns the lines of a fresh tail that follow the previous
+// one. The tail window slides, so the longest run of lines that ends prev and
+// starts lines is the part already printed; matching a run rather than one
+// line keeps repeated lines from being mistaken for the point to resume at.
+// When the windows no longer overlap every line is new.
+func newLogLines(prev, lines []string) []string {
+	for n := min(len(prev), len(lines)); n > 0; n-- {
+		if slices.Equal(prev[len(prev)-n:], lines[:n]) {
+			return lines[n:]
+		}
+	}
+	return lines
+}
+
+func writeLines(w io.Writer, lines []string) {
+	for _, line := range lines {
+		fmt.Fprintln(w, line)
+	}
+}
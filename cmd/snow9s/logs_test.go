@@ -0,0 +1,30 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNewLogLines(t *testing.T) {
+	cases := []struct {
+		name  string
+		prev  []string
+		lines []string
+		want  []string
+	}{
+		{name: "first fetch", prev: nil, lines: []string{"a", "b"}, want: []string{"a", "b"}},
+		{name: "nothing new", prev: []string{"a", "b", "c"}, lines: []string{"a", "b", "c"}, want: []string{}},
+		{name: "window slid", prev: []string{"a", "b", "c"}, lines: []string{"c", "d", "e"}, want: []string{"d", "e"}},
+		{name: "repeated last line", prev: []string{"x", "ok"}, lines: []string{"ok", "retry", "ok", "done"}, want: []string{"retry", "ok", "done"}},
+		{name: "repeated run", prev: []string{"a", "ok", "ok"}, lines: []string{"ok", "ok", "ok"}, want: []string{"ok"}},
+		{name: "rotated window", prev: []string{"a", "b", "c"}, lines: []string{"d", "e", "f"}, want: []string{"d", "e", "f"}},
+		{name: "empty tail", prev: []string{"a"}, lines: nil, want: nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := newLogLines(tc.prev, tc.lines); !slices.Equal(got, tc.want) {
+				t.Fatalf("newLogLines(%q, %q) = %q, want %q", tc.prev, tc.lines, got, tc.want)
+			}
+		})
+	}
+}
@@ -1,27 +1,62 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log"
 	"os"
+	"os/signal"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/marcelinojackson-org/snow9s/internal/config"
+	"github.com/marcelinojackson-org/snow9s/internal/metrics"
 	"github.com/marcelinojackson-org/snow9s/internal/snowflake"
 	"github.com/marcelinojackson-org/snow9s/internal/ui"
+	"github.com/marcelinojackson-org/snow9s/internal/version"
+	"go.yaml.in/yaml/v3"
 )
 
-var cfgOverrides config.Config
+var (
+	cfgOverrides   config.Config
+	skipProdPrompt bool
+	explain        bool
+	noOnboarding   bool
+	skipConfigDir  bool
+	demo           bool
+	outputFormat   string
+	tuiOutput      string
+	probeEndpoints bool
+	describeOutput string
+	assumeYes      bool
+	fullTimestamps bool
+	logContainer   string
+	logLines       int
+	followLogs     bool
+	followInterval time.Duration
+	watchList      bool
+	watchInterval  time.Duration
+	listStatus     string
+	serveAddr      string
+	serveInterval  time.Duration
+	serveResources []string
+)
 
 func main() {
 	rootCmd := buildRootCmd()
 	ctx := context.Background()
 	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		fmt.Println("Error:", err)
+		if hint := snowflake.Hint(err); hint != "" {
+			fmt.Println("Hint:", hint)
+		}
 		os.Exit(1)
 	}
 }
@@ -39,34 +74,158 @@ func buildRootCmd() *cobra.Command {
 	flags.StringVar(&cfgOverrides.Account, "account", "", "Snowflake account (or SNOWFLAKE_ACCOUNT)")
 	flags.StringVar(&cfgOverrides.User, "user", "", "Snowflake user")
 	flags.StringVar(&cfgOverrides.Password, "password", "", "Snowflake password")
+	flags.StringVar(&cfgOverrides.Authenticator, "authenticator", "", "Authenticator: snowflake, snowflake_jwt, externalbrowser (SSO), or oauth")
 	flags.StringVar(&cfgOverrides.Database, "database", "", "Database name")
 	flags.StringVar(&cfgOverrides.Schema, "schema", "", "Schema (namespace)")
 	flags.StringVar(&cfgOverrides.Warehouse, "warehouse", "", "Warehouse name")
+	flags.StringVar(&cfgOverrides.Role, "role", "", "Role for the session (default: the user's default role)")
+	flags.StringVar(&cfgOverrides.MonitorWarehouse, "monitor-warehouse", "", "Warehouse for read-only SHOW/DESCRIBE queries")
 	flags.StringVar(&cfgOverrides.Context, "context", "", "Config context name")
+	flags.StringVar(&cfgOverrides.Connection, "connection", "", "Import a named connection from ~/.snowflake/connections.toml")
 	flags.BoolVar(&cfgOverrides.Debug, "debug", false, "Enable debug Snowflake logging")
+	flags.StringVar(&cfgOverrides.LogFormat, "log-format", "", "Debug log format: text or json (one object per line with timestamp, level, msg, query, duration_ms)")
+	flags.StringVar(&cfgOverrides.Theme.Preset, "theme", "", "Color preset: default, light, or solarized (overrides theme.preset)")
+	flags.IntVar(&cfgOverrides.QueryTimeout, "timeout", 0, "Seconds the connection ping and each query may take (default 10, or query_timeout)")
+	flags.StringVar(&cfgOverrides.ProdPattern, "prod-pattern", "", "Regex marking production accounts/contexts (default \"prod\")")
+	flags.BoolVar(&noOnboarding, "no-onboarding", false, "Never prompt for first-run setup when no configuration exists")
+	flags.BoolVar(&demo, "demo", false, "Run offline against canned demo data instead of Snowflake (or "+config.DemoEnv+"=1)")
+	flags.BoolVar(&skipConfigDir, "insecure-skip-config-dir", false, "Do not create or read ~/.snow9s; load settings only from env and flags (or "+config.NoConfigDirEnv+"=1)")
+	_ = rootCmd.RegisterFlagCompletionFunc("context", completeContexts)
+	rootCmd.Flags().StringVarP(&tuiOutput, "output", "o", "", "Table layout: wide adds owner, DNS name, instances, and spec digest to Services")
+	rootCmd.Flags().BoolVar(&skipProdPrompt, "skip-prod-prompt", false, "Do not ask for confirmation when connecting to production")
+	rootCmd.Flags().BoolVar(&probeEndpoints, "probe-endpoints", false, "Probe public endpoint ingress URLs over HTTP(S) and show whether they answer (makes outbound requests; honors HTTPS_PROXY/NO_PROXY)")
 
 	listCmd := &cobra.Command{Use: "list", Short: "List resources"}
+	listCmd.PersistentFlags().BoolVar(&explain, "explain", false, "Print the queries that would run and exit without connecting")
+	listCmd.PersistentFlags().BoolVar(&fullTimestamps, "full-timestamps", false, "Show exact RFC3339 creation times instead of humanized age")
+	listCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "Output format: table, wide, json, yaml, or csv")
+	listCmd.PersistentFlags().BoolVarP(&watchList, "watch", "w", false, "Clear the screen and reprint the listing every --interval until Ctrl+C")
+	listCmd.PersistentFlags().DurationVar(&watchInterval, "interval", 5*time.Second, "Refresh interval with --watch")
 	servicesCmd := &cobra.Command{Use: "services", Short: "List Snowpark services", RunE: runListServices}
-	listCmd.AddCommand(servicesCmd)
+	servicesCmd.Flags().StringVar(&listStatus, "status", "", "Only list services with this status, e.g. running or failed")
+	poolsCmd := &cobra.Command{Use: "pools", Short: "List compute pools", RunE: runListPools}
+	reposCmd := &cobra.Command{Use: "repos", Short: "List image repositories in the resolved database and schema", RunE: runListRepos}
+	listCmd.AddCommand(servicesCmd, poolsCmd, reposCmd)
+
+	getCmd := &cobra.Command{Use: "get", Short: "Show a single resource"}
+	getServiceCmd := &cobra.Command{
+		Use:   "service <name>",
+		Short: "Show one service's attributes in the resolved database and schema",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runGetService,
+	}
+	getServiceCmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format: table, json, or yaml")
+	getCmd.AddCommand(getServiceCmd)
+
+	describeCmd := &cobra.Command{Use: "describe", Short: "Describe a single resource"}
+	describeServiceCmd := &cobra.Command{
+		Use:   "service <name>",
+		Short: "Print one service's attributes as KEY: value rows",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runDescribeService,
+	}
+	describeServiceCmd.Flags().StringVarP(&describeOutput, "output", "o", "text", "Output format: text, json, or yaml (the service spec)")
+	describeCmd.AddCommand(describeServiceCmd)
+
+	dropCmd := &cobra.Command{Use: "drop", Short: "Drop a resource"}
+	dropServiceCmd := &cobra.Command{
+		Use:   "service <name>",
+		Short: "Drop a service in the resolved database and schema",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runDropService,
+	}
+	dropServiceCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Do not ask to type the service name to confirm")
+	dropCmd.AddCommand(dropServiceCmd)
+
+	logsCmd := &cobra.Command{
+		Use:   "logs <service>",
+		Short: "Print a service's container logs",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runLogs,
+	}
+	logsCmd.Flags().StringVarP(&logContainer, "container", "c", "", "Container to read (default: the service's first container)")
+	logsCmd.Flags().IntVarP(&logLines, "lines", "n", 200, "Number of trailing lines to print")
+	logsCmd.Flags().BoolVarP(&followLogs, "follow", "f", false, "Keep polling and print new lines until interrupted")
+	logsCmd.Flags().DurationVar(&followInterval, "interval", 2*time.Second, "Polling interval with --follow")
+
+	serveCmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Poll SPCS and expose Prometheus metrics and a health check over HTTP",
+		Args:  cobra.NoArgs,
+		RunE:  runServe,
+	}
+	serveCmd.Flags().StringVar(&serveAddr, "addr", ":9090", "Address to listen on")
+	serveCmd.Flags().DurationVar(&serveInterval, "interval", 30*time.Second, "Polling interval")
+	serveCmd.Flags().StringSliceVar(&serveResources, "resources", []string{metrics.ResourceServices, metrics.ResourcePools}, "Resources to poll: services, pools")
+
+	configCmd := &cobra.Command{Use: "config", Short: "Inspect the resolved configuration"}
+	dsnCmd := &cobra.Command{Use: "dsn", Short: "Print the connection DSN with secrets redacted", RunE: runConfigDSN}
+	configCmd.AddCommand(dsnCmd)
 
 	rootCmd.AddCommand(listCmd)
+	rootCmd.AddCommand(getCmd)
+	rootCmd.AddCommand(describeCmd)
+	rootCmd.AddCommand(dropCmd)
+	rootCmd.AddCommand(logsCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(&cobra.Command{
+		Use:   "contexts",
+		Short: "List the contexts in the config file, marking the active one",
+		Args:  cobra.NoArgs,
+		RunE:  runContexts,
+	})
+	rootCmd.AddCommand(&cobra.Command{
+		Use:       "completion [bash|zsh|fish|powershell]",
+		Short:     "Generate a shell completion script",
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
+		RunE:      runCompletion,
+	})
+	rootCmd.AddCommand(&cobra.Command{
+		Use:   "version",
+		Short: "Print version, build, and platform information",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprint(cmd.OutOrStdout(), version.Info())
+		},
+	})
 	return rootCmd
 }
 
 func runTUI(ctx context.Context) error {
+	if tuiOutput != "" && tuiOutput != "wide" {
+		return fmt.Errorf("unknown output %q (want wide)", tuiOutput)
+	}
 	cfg, logger, err := loadConfigAndLogger()
 	if err != nil {
 		return err
 	}
+	if cfg.IsProduction() && !skipProdPrompt && !confirmProduction(os.Stdin, os.Stdout, cfg) {
+		return errors.New("aborted: production connection not confirmed")
+	}
 
-	client, err := snowflake.NewClient(ctx, cfg, logger)
+	client, err := connect(ctx, cfg, logger)
 	if err != nil {
 		return err
 	}
-	defer client.Close()
 
 	spcs := snowflake.NewSPCS(client, cfg)
 	uiApp := ui.NewApp(cfg, spcs, cfg.Debug)
+	uiApp.SetWide(tuiOutput == "wide")
+	uiApp.SetProbeEndpoints(probeEndpoints)
+	uiApp.EnableContextSwitch(ui.ContextSwitch{
+		Load: loadContext,
+		Connect: func(ctx context.Context, cfg config.Config) (*snowflake.SPCS, func() error, error) {
+			client, err := connect(ctx, cfg, logger)
+			if err != nil {
+				return nil, nil, err
+			}
+			return snowflake.NewSPCS(client, cfg), client.Close, nil
+		},
+		ConfirmProduction: !skipProdPrompt,
+	}, client.Close)
+	defer uiApp.Close()
 	if cfg.Debug {
 		if w := uiApp.DebugWriter(); w != nil {
 			logger.SetOutput(io.MultiWriter(os.Stdout, w))
@@ -76,43 +235,478 @@ func runTUI(ctx context.Context) error {
 	return uiApp.Run(ctx)
 }
 
+// confirmProduction asks the user to acknowledge a production connection.
+func confirmProduction(in io.Reader, out io.Writer, cfg config.Config) bool {
+	name := cfg.Account
+	if cfg.Context != "" {
+		name = fmt.Sprintf("%s (context %s)", cfg.Account, cfg.Context)
+	}
+	fmt.Fprintf(out, "You're connecting to PROD: %s. Continue? y/N ", name)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
+
 func runListServices(cmd *cobra.Command, args []string) error {
-	ctx, cancel := context.WithTimeout(cmd.Context(), 15*time.Second)
+	return runList(cmd, snowflake.ExplainListServices, func(ctx context.Context, spcs *snowflake.SPCS, w io.Writer) error {
+		services, err := spcs.ListServicesByStatus(ctx, listStatus)
+		if err != nil {
+			return err
+		}
+		return ui.PrintServices(w, services, outputFormat, fullTimestamps)
+	})
+}
+
+func runListPools(cmd *cobra.Command, args []string) error {
+	return runList(cmd, snowflake.ExplainListComputePools, func(ctx context.Context, spcs *snowflake.SPCS, w io.Writer) error {
+		pools, err := spcs.ListComputePools(ctx)
+		if err != nil {
+			return err
+		}
+		return ui.PrintComputePools(w, pools, outputFormat, fullTimestamps)
+	})
+}
+
+func runListRepos(cmd *cobra.Command, args []string) error {
+	return runList(cmd, snowflake.ExplainListImageRepositories, func(ctx context.Context, spcs *snowflake.SPCS, w io.Writer) error {
+		repos, err := spcs.ListImageRepositories(ctx)
+		if err != nil {
+			return err
+		}
+		return ui.PrintImageRepositories(w, repos, outputFormat, fullTimestamps)
+	})
+}
+
+// listFunc loads one resource type and prints it in the chosen format.
+type listFunc func(ctx context.Context, spcs *snowflake.SPCS, w io.Writer) error
+
+// runList validates the list flags, then prints the statements (--explain),
+// the listing once, or keeps reprinting it (--watch) until interrupted.
+func runList(cmd *cobra.Command, statements func(config.Config) []string, list listFunc) error {
+	if err := ui.ValidateOutputFormat(outputFormat); err != nil {
+		return err
+	}
+	if watchList && watchInterval <= 0 {
+		return fmt.Errorf("--interval must be positive, got %s", watchInterval)
+	}
+	if explain {
+		cfg, err := resolveConfig()
+		if err != nil {
+			return err
+		}
+		printStatements(cmd.OutOrStdout(), statements(cfg))
+		return nil
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	cfg, logger, err := loadConfigAndLogger()
+	if err != nil {
+		return err
+	}
+	client, err := connect(ctx, cfg, logger)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	spcs := snowflake.NewSPCS(client, cfg)
+	render := func(w io.Writer) error {
+		ctx, cancel := context.WithTimeout(ctx, cfg.Timeout())
+		defer cancel()
+		return list(ctx, spcs, w)
+	}
+	if !watchList {
+		return render(cmd.OutOrStdout())
+	}
+	return watch(ctx, cmd.OutOrStdout(), cmd.CommandPath(), render)
+}
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+// watch reprints render every watchInterval like watch(1), until ctx is
+// cancelled. Each frame is rendered before the screen is cleared so it does
+// not flicker, and a failed refresh is shown in place of the listing.
+func watch(ctx context.Context, out io.Writer, title string, render func(io.Writer) error) error {
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	for {
+		var frame bytes.Buffer
+		fmt.Fprintf(&frame, "Every %s: %s\t%s\n\n", watchInterval, title, time.Now().Format(time.TimeOnly))
+		if err := render(&frame); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			fmt.Fprintf(&frame, "Error: %v\n", err)
+		}
+		fmt.Fprint(out, clearScreen)
+		if _, err := frame.WriteTo(out); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func runGetService(cmd *cobra.Command, args []string) error {
+	switch outputFormat {
+	case "table", "json", "yaml":
+	default:
+		return fmt.Errorf("unknown output format %q (want table, json, or yaml)", outputFormat)
+	}
+
+	attrs, err := fetchServiceAttributes(cmd.Context(), args[0])
+	if err != nil {
+		return err
+	}
+	return printAttributes(cmd.OutOrStdout(), snowflake.AttributeMap(attrs), outputFormat)
+}
+
+func runDescribeService(cmd *cobra.Command, args []string) error {
+	switch describeOutput {
+	case "text", "json":
+	case "yaml":
+		return runDescribeServiceSpec(cmd, args[0])
+	default:
+		return fmt.Errorf("unknown output format %q (want text, json, or yaml)", describeOutput)
+	}
+
+	attrs, err := fetchServiceAttributes(cmd.Context(), args[0])
+	if err != nil {
+		return err
+	}
+	if describeOutput == "json" {
+		return printAttributes(cmd.OutOrStdout(), snowflake.AttributeMap(attrs), "json")
+	}
+	printDescription(cmd.OutOrStdout(), snowflake.AttributeMap(attrs))
+	return nil
+}
+
+// runDescribeServiceSpec prints a service's YAML spec from DESCRIBE SERVICE.
+func runDescribeServiceSpec(cmd *cobra.Command, name string) error {
+	cfg, logger, err := loadConfigAndLogger()
+	if err != nil {
+		return err
+	}
+	client, err := connect(cmd.Context(), cfg, logger)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), cfg.Timeout())
+	defer cancel()
+
+	spec, err := snowflake.NewSPCS(client, cfg).GetServiceSpec(ctx, name)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(spec) == "" {
+		return fmt.Errorf("service %q in %s.%s has no spec", name, cfg.Database, cfg.Schema)
+	}
+	fmt.Fprint(cmd.OutOrStdout(), strings.TrimRight(spec, "\n")+"\n")
+	return nil
+}
+
+// fetchServiceAttributes connects and returns a service's SHOW SERVICES row,
+// turning a missing service into a user-facing error.
+func fetchServiceAttributes(ctx context.Context, name string) ([]snowflake.Attribute, error) {
+	cfg, logger, err := loadConfigAndLogger()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := connect(ctx, cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout())
 	defer cancel()
 
+	attrs, err := snowflake.NewSPCS(client, cfg).DescribeServiceAttributes(ctx, name)
+	if errors.Is(err, snowflake.ErrNotFound) || (err == nil && len(attrs) == 0) {
+		return nil, fmt.Errorf("service %q not found in %s.%s", name, cfg.Database, cfg.Schema)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return attrs, nil
+}
+
+// printDescription writes attributes as KEY: value rows sorted by key, with
+// the values aligned.
+func printDescription(w io.Writer, attrs map[string]string) {
+	keys := make([]string, 0, len(attrs))
+	width := 0
+	for key := range attrs {
+		keys = append(keys, key)
+		width = max(width, len(key)+1)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(w, "%-*s %s\n", width, strings.ToUpper(key)+":", attrs[key])
+	}
+}
+
+// printAttributes writes a resource's attributes as sorted key/value lines,
+// JSON, or YAML.
+func printAttributes(w io.Writer, attrs map[string]string, format string) error {
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(attrs, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encode json: %w", err)
+		}
+		fmt.Fprintln(w, string(data))
+	case "yaml":
+		data, err := yaml.Marshal(attrs)
+		if err != nil {
+			return fmt.Errorf("encode yaml: %w", err)
+		}
+		fmt.Fprint(w, string(data))
+	default:
+		keys := make([]string, 0, len(attrs))
+		width := 0
+		for k := range attrs {
+			keys = append(keys, k)
+			width = max(width, len(k))
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(w, "%-*s  %s\n", width, strings.ToUpper(k), attrs[k])
+		}
+	}
+	return nil
+}
+
+func runDropService(cmd *cobra.Command, args []string) error {
+	name := args[0]
 	cfg, logger, err := loadConfigAndLogger()
 	if err != nil {
 		return err
 	}
+	if !assumeYes && !confirmDrop(cmd.InOrStdin(), cmd.OutOrStdout(), "service", name, cfg) {
+		return errors.New("aborted: service name not confirmed")
+	}
 
-	client, err := snowflake.NewClient(ctx, cfg, logger)
+	client, err := connect(cmd.Context(), cfg, logger)
 	if err != nil {
 		return err
 	}
 	defer client.Close()
 
-	spcs := snowflake.NewSPCS(client, cfg)
-	services, err := spcs.ListServices(ctx)
+	ctx, cancel := context.WithTimeout(cmd.Context(), cfg.Timeout())
+	defer cancel()
+	dropped, err := dropService(ctx, snowflake.NewSPCS(client, cfg), name)
 	if err != nil {
 		return err
 	}
-	ui.PrintTable(services)
+	fmt.Fprintf(cmd.OutOrStdout(), "Dropped service %s in %s.%s\n", dropped, cfg.Database, cfg.Schema)
 	return nil
 }
 
-func loadConfigAndLogger() (config.Config, *log.Logger, error) {
-	cfgFile, err := config.LoadConfig(cfgOverrides.Context)
+// dropService looks name up the way SHOW SERVICES LIKE matches it, ignoring
+// case, and drops the service under its real name, which it returns. DROP
+// SERVICE IF EXISTS on a mistyped quoted name would otherwise do nothing and
+// still succeed, so a missing service is reported as ErrNotFound.
+func dropService(ctx context.Context, spcs *snowflake.SPCS, name string) (string, error) {
+	attrs, err := spcs.DescribeServiceAttributes(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if actual := snowflake.AttributeMap(attrs)["name"]; actual != "" {
+		name = actual
+	}
+	if err := spcs.DropService(ctx, name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// confirmDrop asks the user to type name before a resource is dropped.
+func confirmDrop(in io.Reader, out io.Writer, kind, name string, cfg config.Config) bool {
+	fmt.Fprintf(out, "Drop %s %s in %s.%s? This cannot be undone. Type the %s name to confirm: ", kind, name, cfg.Database, cfg.Schema, kind)
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	return strings.TrimSpace(answer) == name
+}
+
+func runContexts(cmd *cobra.Command, args []string) error {
+	names, err := config.ListContexts(loadOptions(""))
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return errors.New("no contexts defined in the config file")
+	}
+	active, err := config.ActiveContext(loadOptions(cfgOverrides.Context))
+	if err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	for _, name := range names {
+		marker := " "
+		if name == active {
+			marker = "*"
+		}
+		fmt.Fprintf(out, "%s %s\n", marker, name)
+	}
+	return nil
+}
+
+func runCompletion(cmd *cobra.Command, args []string) error {
+	root, out := cmd.Root(), cmd.OutOrStdout()
+	switch args[0] {
+	case "bash":
+		return root.GenBashCompletionV2(out, true)
+	case "zsh":
+		return root.GenZshCompletion(out)
+	case "fish":
+		return root.GenFishCompletion(out, true)
+	default:
+		return root.GenPowerShellCompletionWithDesc(out)
+	}
+}
+
+// completeContexts offers the context names from the config file for --context.
+func completeContexts(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	names, err := config.ListContexts(loadOptions(""))
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	matches := make([]string, 0, len(names))
+	for _, name := range names {
+		if strings.HasPrefix(name, strings.ToLower(toComplete)) {
+			matches = append(matches, name)
+		}
+	}
+	return matches, cobra.ShellCompDirectiveNoFileComp
+}
+
+func runConfigDSN(cmd *cobra.Command, args []string) error {
+	cfg, _, err := loadConfigAndLogger()
+	if err != nil {
+		return err
+	}
+	dsn, err := snowflake.RedactedDSN(cfg)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), dsn)
+	return nil
+}
+
+func printStatements(w io.Writer, statements []string) {
+	for _, stmt := range statements {
+		fmt.Fprintf(w, "%s;\n", stmt)
+	}
+}
+
+// loadOptions tells the config loader which context to read and whether
+// --insecure-skip-config-dir was passed.
+func loadOptions(context string) config.Options {
+	return config.Options{Context: context, NoConfigDir: skipConfigDir}
+}
+
+// resolveConfig merges the config file, env, and flags without validating
+// credentials, for commands that never connect.
+func resolveConfig() (config.Config, error) {
+	// The connection is applied while loading so config and env values
+	// still take precedence over the ones it supplies.
+	opts := loadOptions(cfgOverrides.Context)
+	opts.Connection = cfgOverrides.Connection
+	cfgFile, err := config.Load(opts)
 	if err != nil && !errors.Is(err, os.ErrNotExist) {
-		return config.Config{}, nil, err
+		return config.Config{}, err
 	}
 	cfg := config.MergeOverrides(cfgFile, cfgOverrides)
+	if cfgFile.Context != "" {
+		// Keep the full name when --context matched by prefix or substring.
+		cfg.Context = cfgFile.Context
+	}
+	return cfg, nil
+}
+
+// loadContext resolves the named context for switching inside the TUI. The
+// context defines the connection, so only flags unrelated to it carry over.
+func loadContext(name string) (config.Config, error) {
+	overrides := config.Config{
+		Debug:          cfgOverrides.Debug,
+		LogFormat:      cfgOverrides.LogFormat,
+		Theme:          cfgOverrides.Theme,
+		ProdPattern:    cfgOverrides.ProdPattern,
+		StrictKeyPerms: cfgOverrides.StrictKeyPerms,
+	}
+	cfgFile, err := config.Load(loadOptions(name))
+	if err != nil {
+		return config.Config{}, err
+	}
+	cfg := config.MergeOverrides(cfgFile, overrides)
+	cfg.Context = cfgFile.Context
+	if err := cfg.Validate(); err != nil {
+		return config.Config{}, err
+	}
+	return cfg, nil
+}
+
+func loadConfigAndLogger() (config.Config, *log.Logger, error) {
+	cfg, err := resolveConfig()
+	if err != nil {
+		return config.Config{}, nil, err
+	}
+	if demoMode() {
+		return snowflake.DemoConfig(cfg), newLogger(cfg), nil
+	}
+	if cfg.IsEmpty() && !noOnboarding && !cfg.NoConfigDir && isInteractive() {
+		if err := runOnboarding(os.Stdin, os.Stdout); err != nil {
+			return config.Config{}, nil, fmt.Errorf("onboarding: %w", err)
+		}
+		if cfg, err = resolveConfig(); err != nil {
+			return config.Config{}, nil, err
+		}
+	}
 	if err := cfg.Validate(); err != nil {
 		return config.Config{}, nil, err
 	}
+	for _, warning := range cfg.Warnings() {
+		fmt.Fprintln(os.Stderr, "Warning:", warning)
+	}
+
+	return cfg, newLogger(cfg), nil
+}
 
+// newLogger returns the debug logger, discarding output unless debug is on.
+func newLogger(cfg config.Config) *log.Logger {
 	logger := log.New(os.Stdout, "snow9s ", log.LstdFlags)
+	if cfg.JSONLog() {
+		// Each entry carries its own timestamp.
+		logger = log.New(os.Stdout, "", 0)
+	}
 	if !cfg.Debug {
 		logger.SetOutput(io.Discard)
 	}
-	return cfg, logger, nil
+	return logger
+}
+
+// demoMode reports whether --demo or SNOW9S_DEMO asks for canned data.
+func demoMode() bool {
+	return demo || config.DemoEnabled()
+}
+
+// connect opens a Snowflake client, or the offline demo client in demo mode.
+func connect(ctx context.Context, cfg config.Config, logger *log.Logger) (*snowflake.Client, error) {
+	if demoMode() {
+		return snowflake.NewDemoClient(cfg, logger), nil
+	}
+	return snowflake.NewClient(ctx, cfg, logger)
 }
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/marcelinojackson-org/snow9s/internal/config"
+	"github.com/marcelinojackson-org/snow9s/internal/snowflake"
+)
+
+func TestPrintDescription(t *testing.T) {
+	cases := []struct {
+		name  string
+		attrs map[string]string
+		want  string
+	}{
+		{name: "empty", attrs: nil, want: ""},
+		{
+			name:  "sorted alphabetically and aligned",
+			attrs: map[string]string{"status": "RUNNING", "name": "API", "compute_pool": "POOL1"},
+			want:  "COMPUTE_POOL: POOL1\nNAME:         API\nSTATUS:       RUNNING\n",
+		},
+		{
+			name:  "padding follows the longest key",
+			attrs: map[string]string{"a": "1", "dns_name": "api.svc", "zz": "2"},
+			want:  "A:        1\nDNS_NAME: api.svc\nZZ:       2\n",
+		},
+		{
+			name:  "empty values keep the key",
+			attrs: map[string]string{"comment": "", "owner": "SYSADMIN"},
+			want:  "COMMENT: \nOWNER:   SYSADMIN\n",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out strings.Builder
+			printDescription(&out, tc.attrs)
+			if out.String() != tc.want {
+				t.Fatalf("got\n%q\nwant\n%q", out.String(), tc.want)
+			}
+		})
+	}
+}
+
+func TestConfirmDrop(t *testing.T) {
+	cfg := config.Config{Database: "DB", Schema: "PUBLIC"}
+	cases := []struct {
+		answer string
+		want   bool
+	}{
+		{answer: "api\n", want: true},
+		{answer: "  api  \n", want: true},
+		{answer: "API\n", want: false},
+		{answer: "web\n", want: false},
+		{answer: "", want: false},
+	}
+	for _, tc := range cases {
+		var out strings.Builder
+		if got := confirmDrop(strings.NewReader(tc.answer), &out, "service", "api", cfg); got != tc.want {
+			t.Fatalf("answer %q: expected %v got %v", tc.answer, tc.want, got)
+		}
+		if !strings.Contains(out.String(), "Drop service api in DB.PUBLIC?") {
+			t.Fatalf("unexpected prompt %q", out.String())
+		}
+	}
+}
+
+func TestDropServiceUsesTheListedName(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	cfg := config.Config{Database: "DB", Schema: "PUBLIC"}
+	spcs := snowflake.NewSPCS(db, cfg)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SHOW SERVICES LIKE 'api' IN SCHEMA "DB"."PUBLIC"`)).WillReturnRows(
+		sqlmock.NewRows([]string{"name", "status"}).AddRow("API", "RUNNING"))
+	mock.ExpectQuery(regexp.QuoteMeta(`DROP SERVICE IF EXISTS "DB"."PUBLIC"."API"`)).WillReturnRows(
+		sqlmock.NewRows([]string{"status"}).AddRow("API successfully dropped."))
+	dropped, err := dropService(context.Background(), spcs, "api")
+	if err != nil || dropped != "API" {
+		t.Fatalf("expected API to be dropped, got %q, %v", dropped, err)
+	}
+
+	// A service that does not exist is an error, and nothing is dropped.
+	mock.ExpectQuery(regexp.QuoteMeta(`SHOW SERVICES LIKE 'gone'`)).WillReturnRows(sqlmock.NewRows([]string{"name", "status"}))
+	if _, err := dropService(context.Background(), spcs, "gone"); !errors.Is(err, snowflake.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
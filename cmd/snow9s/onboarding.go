@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/marcelinojackson-org/snow9s/internal/config"
+)
+
+// onboardingContext is the context name written by first-run onboarding.
+const onboardingContext = "default"
+
+// isInteractive reports whether stdin is a terminal that can answer prompts.
+func isInteractive() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// runOnboarding asks for the minimum connection settings, validates them, and
+// writes them as the default context.
+func runOnboarding(in io.Reader, out io.Writer) error {
+	reader := bufio.NewReader(in)
+	ask := func(label, def string) string {
+		if def != "" {
+			fmt.Fprintf(out, "%s [%s]: ", label, def)
+		} else {
+			fmt.Fprintf(out, "%s: ", label)
+		}
+		answer, _ := reader.ReadString('\n')
+		answer = strings.TrimSpace(answer)
+		if answer == "" {
+			return def
+		}
+		return answer
+	}
+
+	fmt.Fprintln(out, "No snow9s configuration found. Let's set up a connection (Ctrl+C to abort).")
+	cfg := config.Config{}
+	cfg.Account = ask("Account (orgname-accountname or locator)", "")
+	if _, err := config.NormalizeAccount(cfg.Account, ""); err != nil {
+		return err
+	}
+	cfg.User = ask("User", "")
+	if cfg.User == "" {
+		return errors.New("user is required")
+	}
+
+	auth := strings.ToLower(ask("Auth method (password/keypair)", "password"))
+	switch auth {
+	case "keypair", "key":
+		cfg.PrivateKeyPath = ask("Private key path (.p8)", "")
+		if _, err := os.Stat(cfg.PrivateKeyPath); err != nil {
+			return fmt.Errorf("private key path: %w", err)
+		}
+	case "password":
+		cfg.Password = os.Getenv("SNOWFLAKE_PASSWORD")
+		if cfg.Password == "" {
+			cfg.Password = ask("Password (saved to ~/.snow9s/env)", "")
+		}
+	default:
+		return fmt.Errorf("unknown auth method %q", auth)
+	}
+
+	cfg.Database = ask("Database", "")
+	cfg.Schema = ask("Schema", "PUBLIC")
+	cfg.Warehouse = ask("Warehouse", "")
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	path, err := config.WriteContext(onboardingContext, cfg)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Wrote %s\n", path)
+	if cfg.Password != "" && os.Getenv("SNOWFLAKE_PASSWORD") == "" {
+		envPath, err := config.WriteEnvVar("SNOWFLAKE_PASSWORD", cfg.Password)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Wrote SNOWFLAKE_PASSWORD to %s\n", envPath)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/marcelinojackson-org/snow9s/internal/config"
+)
+
+func TestRunOnboardingPassword(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("SNOW9S_CONFIG", filepath.Join(dir, "config.yaml"))
+	t.Setenv("SNOWFLAKE_PASSWORD", "")
+	answers := strings.Join([]string{"myorg-myacct", "alice", "", "s3cret", "ANALYTICS", "", "COMPUTE_WH"}, "\n") + "\n"
+
+	if err := runOnboarding(strings.NewReader(answers), io.Discard); err != nil {
+		t.Fatalf("onboarding: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "config.yaml"))
+	if err != nil {
+		t.Fatalf("read config: %v", err)
+	}
+	if strings.Contains(string(data), "s3cret") {
+		t.Fatalf("password must not be written to config.yaml")
+	}
+
+	// Unset so LoadConfig picks the password up from the env file.
+	os.Unsetenv("SNOWFLAKE_PASSWORD")
+	cfg, err := config.LoadConfig("")
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("onboarded config does not validate: %v", err)
+	}
+	if cfg.Context != onboardingContext || cfg.Account != "myorg-myacct" || cfg.Password != "s3cret" || cfg.Schema != "PUBLIC" || cfg.Warehouse != "COMPUTE_WH" {
+		t.Fatalf("unexpected config %+v", cfg)
+	}
+}
+
+func TestRunOnboardingRejectsInvalidAnswers(t *testing.T) {
+	cases := map[string][]string{
+		"missing password": {"myorg-myacct", "alice", "password", ""},
+		"unknown auth":     {"myorg-myacct", "alice", "sso"},
+		"missing key":      {"myorg-myacct", "alice", "keypair", "/nonexistent/key.p8"},
+		"bad account":      {"not an account!", "alice"},
+		"missing user":     {"myorg-myacct", ""},
+	}
+	for name, answers := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv("SNOW9S_CONFIG", filepath.Join(dir, "config.yaml"))
+			t.Setenv("SNOWFLAKE_PASSWORD", "")
+			input := strings.Join(answers, "\n") + "\n"
+			if err := runOnboarding(strings.NewReader(input), io.Discard); err == nil {
+				t.Fatalf("expected an error")
+			}
+			if _, err := os.Stat(filepath.Join(dir, "config.yaml")); err == nil {
+				t.Fatalf("no config may be written for invalid answers")
+			}
+		})
+	}
+}
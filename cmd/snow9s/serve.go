@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/marcelinojackson-org/snow9s/internal/metrics"
+	"github.com/marcelinojackson-org/snow9s/internal/snowflake"
+	"github.com/spf13/cobra"
+)
+
+// runServe polls SPCS and serves the results at /metrics and /healthz until
+// interrupted.
+func runServe(cmd *cobra.Command, _ []string) error {
+	if serveInterval <= 0 {
+		return fmt.Errorf("--interval must be positive, got %s", serveInterval)
+	}
+	resources, err := metrics.ParseResources(serveResources)
+	if err != nil {
+		return fmt.Errorf("--resources: %w", err)
+	}
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg, logger, err := loadConfigAndLogger()
+	if err != nil {
+		return err
+	}
+	// Every poll should reach Snowflake rather than the query cache.
+	cfg.CacheTTL = 0
+	client, err := connect(ctx, cfg, logger)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	poller := metrics.NewPoller(snowflake.NewSPCS(client, cfg), serveInterval, resources)
+	go poller.Run(ctx)
+
+	server := &http.Server{
+		Addr:              serveAddr,
+		Handler:           poller.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+	fmt.Fprintf(cmd.ErrOrStderr(), "Serving metrics on %s every %s (/metrics, /healthz)\n", serveAddr, serveInterval)
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("serve metrics: %w", err)
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("shut down metrics server: %w", err)
+	}
+	return nil
+}
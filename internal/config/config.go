@@ -5,45 +5,209 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 // Config holds the Snowflake connection and app settings.
 type Config struct {
-	Account        string `mapstructure:"account"`
-	User           string `mapstructure:"user"`
-	Password       string `mapstructure:"password"`
-	PrivateKeyPath string `mapstructure:"private_key_path"`
-	Database       string `mapstructure:"database"`
-	Schema         string `mapstructure:"schema"`
-	Warehouse      string `mapstructure:"warehouse"`
-	Context        string `mapstructure:"context"`
-	Debug          bool   `mapstructure:"debug"`
+	Account              string            `mapstructure:"account"`
+	User                 string            `mapstructure:"user"`
+	Password             string            `mapstructure:"password"`
+	PrivateKeyPath       string            `mapstructure:"private_key_path"`
+	PrivateKey           string            `mapstructure:"private_key"`
+	PrivateKeyPassphrase string            `mapstructure:"private_key_passphrase"`
+	Authenticator        string            `mapstructure:"authenticator"`
+	Token                string            `mapstructure:"token"`
+	Database             string            `mapstructure:"database"`
+	Schema               string            `mapstructure:"schema"`
+	Warehouse            string            `mapstructure:"warehouse"`
+	Role                 string            `mapstructure:"role"`
+	Context              string            `mapstructure:"context"`
+	Connection           string            `mapstructure:"connection"`
+	Debug                bool              `mapstructure:"debug"`
+	MonitorWarehouse     string            `mapstructure:"monitor_warehouse"`
+	StrictKeyPerms       bool              `mapstructure:"strict_key_perms"`
+	TableBorders         bool              `mapstructure:"table_borders"`
+	CellPadding          int               `mapstructure:"cell_padding"`
+	ProdPattern          string            `mapstructure:"prod_pattern"`
+	AccountFormat        string            `mapstructure:"account_format"`
+	SessionParams        map[string]string `mapstructure:"session_params"`
+	Timezone             string            `mapstructure:"timezone"`
+	CostWarnings         CostWarnings      `mapstructure:"cost_warnings"`
+	RetryMaxBackoff      int               `mapstructure:"retry_max_backoff"`
+	MaxRetries           int               `mapstructure:"max_retries"`
+	CacheTTL             int               `mapstructure:"cache_ttl"`
+	ErrorTimeout         int               `mapstructure:"error_timeout"`
+	QueryTimeout         int               `mapstructure:"query_timeout"`
+	AutoResumeWarehouse  bool              `mapstructure:"auto_resume_warehouse"`
+	LogFormat            string            `mapstructure:"log_format"`
+	SlowQueryThreshold   int               `mapstructure:"slow_query_threshold"`
+	SkipConfirmations    bool              `mapstructure:"skip_confirmations"`
+	Theme                Theme             `mapstructure:"theme"`
+	// NoConfigDir records that the config directory was skipped while
+	// loading, so state and context files are left alone as well.
+	NoConfigDir bool `mapstructure:"-"`
+}
+
+// CostWarnings holds the resource counts above which the header shows a
+// cost-awareness warning. Zero disables a check.
+type CostWarnings struct {
+	RunningServices int `mapstructure:"running_services"`
+	ActiveNodes     int `mapstructure:"active_nodes"`
+}
+
+// Theme customizes the UI palette. Preset names a built-in scheme (default,
+// light, solarized); the remaining fields override single colors with hex
+// strings such as "#1e1e1e".
+type Theme struct {
+	Preset          string `mapstructure:"preset"`
+	Background      string `mapstructure:"background"`
+	PrimaryText     string `mapstructure:"primary_text"`
+	SecondaryText   string `mapstructure:"secondary_text"`
+	HeaderBg        string `mapstructure:"header_bg"`
+	HeaderText      string `mapstructure:"header_text"`
+	SelectionBg     string `mapstructure:"selection_bg"`
+	SelectionText   string `mapstructure:"selection_text"`
+	Border          string `mapstructure:"border"`
+	RowAltBg        string `mapstructure:"row_alt_bg"`
+	StatusRunning   string `mapstructure:"status_running"`
+	StatusStarting  string `mapstructure:"status_starting"`
+	StatusStopped   string `mapstructure:"status_stopped"`
+	StatusSuspended string `mapstructure:"status_suspended"`
+	Highlight       string `mapstructure:"highlight"`
+}
+
+// DefaultSchema is used when neither the config nor the connection names one.
+const DefaultSchema = "PUBLIC"
+
+// DefaultCellPadding is the horizontal padding of the borderless k9s look.
+const DefaultCellPadding = 1
+
+// DefaultProdPattern flags accounts and contexts that look like production.
+const DefaultProdPattern = "prod"
+
+// DefaultRetryMaxBackoff caps, in seconds, the delay between automatic
+// retries of a failed refresh.
+const DefaultRetryMaxBackoff = 60
+
+// DefaultErrorTimeout is how many seconds the TUI shows an error before
+// clearing it.
+const DefaultErrorTimeout = 10
+
+// DefaultQueryTimeout is how many seconds the connection ping and each
+// query may take before they are cancelled.
+const DefaultQueryTimeout = 10
+
+// DefaultSlowQueryThreshold is how many seconds a query may take before the
+// TUI warns that it was slow.
+const DefaultSlowQueryThreshold = 3
+
+// DefaultMaxRetries is how many times a query that failed transiently is
+// retried before the error is returned.
+const DefaultMaxRetries = 3
+
+// Default cost warning thresholds.
+const (
+	DefaultRunningServicesWarn = 20
+	DefaultActiveNodesWarn     = 10
+)
+
+// Supported authenticator values. An empty authenticator picks key pair auth
+// when private_key or private_key_path is set and password auth otherwise.
+const (
+	AuthenticatorSnowflake       = "snowflake"
+	AuthenticatorJWT             = "snowflake_jwt"
+	AuthenticatorExternalBrowser = "externalbrowser"
+	AuthenticatorOAuth           = "oauth"
+)
+
+// Debug log formats accepted by log_format. Text is the default.
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// NoConfigDirEnv, when true, stops snow9s from creating, reading, or writing
+// its config directory; configuration then comes only from env and flags.
+const NoConfigDirEnv = "SNOW9S_NO_CONFIG_DIR"
+
+// ConfigDirDisabled reports whether NoConfigDirEnv is set.
+func ConfigDirDisabled() bool {
+	disabled, err := strconv.ParseBool(os.Getenv(NoConfigDirEnv))
+	return err == nil && disabled
+}
+
+// Options selects what Load reads. The zero value loads the default context
+// from the config directory unless NoConfigDirEnv is set.
+type Options struct {
+	// Context names the context to load, matched like --context.
+	Context string
+	// NoConfigDir skips the config directory, as NoConfigDirEnv does.
+	NoConfigDir bool
+	// Connection names the Snowflake connection to fill gaps from, taking
+	// precedence over the connection key in the config file and env.
+	Connection string
+}
+
+func (o Options) configDirDisabled() bool {
+	return o.NoConfigDir || ConfigDirDisabled()
+}
+
+// DemoEnv, when true, runs snow9s against canned demo data instead of
+// Snowflake, like --demo.
+const DemoEnv = "SNOW9S_DEMO"
+
+// DemoEnabled reports whether DemoEnv is set.
+func DemoEnabled() bool {
+	enabled, err := strconv.ParseBool(os.Getenv(DemoEnv))
+	return err == nil && enabled
 }
 
 // LoadConfig reads configuration from env vars and the optional config file.
 // Context names align with the kubeconfig style: contexts.<name>.
 func LoadConfig(contextName string) (Config, error) {
+	return Load(Options{Context: contextName})
+}
+
+// Load is LoadConfig with explicit options.
+func Load(opts Options) (Config, error) {
 	cfgPath := configFilePath()
+	useDir := !opts.configDirDisabled()
+	contextName := opts.Context
 
-	if err := ensureConfigDir(cfgPath); err != nil {
-		return Config{}, err
+	if useDir {
+		if err := ensureConfigDir(cfgPath); err != nil {
+			return Config{}, err
+		}
+		loadEnvOverrides(cfgPath)
 	}
-	loadEnvOverrides(cfgPath)
 
 	v := viper.New()
 	v.SetConfigType("yaml")
 	v.SetEnvPrefix("SNOWFLAKE")
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	v.AutomaticEnv()
-	v.SetDefault("schema", "PUBLIC")
 	v.SetDefault("debug", false)
+	v.SetDefault("cell_padding", DefaultCellPadding)
+	v.SetDefault("prod_pattern", DefaultProdPattern)
+	v.SetDefault("cost_warnings.running_services", DefaultRunningServicesWarn)
+	v.SetDefault("cost_warnings.active_nodes", DefaultActiveNodesWarn)
+	v.SetDefault("retry_max_backoff", DefaultRetryMaxBackoff)
+	v.SetDefault("max_retries", DefaultMaxRetries)
+	v.SetDefault("error_timeout", DefaultErrorTimeout)
+	v.SetDefault("query_timeout", DefaultQueryTimeout)
+	v.SetDefault("slow_query_threshold", DefaultSlowQueryThreshold)
 	bindEnvKeys(v)
 
 	v.SetConfigFile(cfgPath)
-	if _, err := os.Stat(cfgPath); err == nil {
+	if _, err := os.Stat(cfgPath); err == nil && useDir {
 		if err := v.ReadInConfig(); err != nil {
 			return Config{}, fmt.Errorf("read config: %w", err)
 		}
@@ -54,18 +218,136 @@ func LoadConfig(contextName string) (Config, error) {
 		contextName = v.GetString("context")
 	}
 	if contextName != "" {
-		sub := v.Sub(fmt.Sprintf("contexts.%s", contextName))
+		resolved, err := matchContext(contextName, v.GetStringMap("contexts"))
+		if err != nil {
+			return Config{}, err
+		}
+		sub := v.Sub(fmt.Sprintf("contexts.%s", resolved))
 		if sub == nil {
 			return Config{}, fmt.Errorf("context %q not found in config", contextName)
 		}
 		sub.SetEnvPrefix("SNOWFLAKE")
 		sub.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 		sub.AutomaticEnv()
+		sub.SetDefault("cell_padding", DefaultCellPadding)
+		sub.SetDefault("prod_pattern", v.GetString("prod_pattern"))
+		sub.SetDefault("cost_warnings.running_services", v.GetInt("cost_warnings.running_services"))
+		sub.SetDefault("cost_warnings.active_nodes", v.GetInt("cost_warnings.active_nodes"))
+		sub.SetDefault("retry_max_backoff", v.GetInt("retry_max_backoff"))
+		sub.SetDefault("max_retries", v.GetInt("max_retries"))
+		sub.SetDefault("cache_ttl", v.GetInt("cache_ttl"))
+		sub.SetDefault("error_timeout", v.GetInt("error_timeout"))
+		sub.SetDefault("query_timeout", v.GetInt("query_timeout"))
+		sub.SetDefault("slow_query_threshold", v.GetInt("slow_query_threshold"))
+		sub.SetDefault("skip_confirmations", v.GetBool("skip_confirmations"))
 		bindEnvKeys(sub)
-		return decodeConfig(sub)
+		cfg, err := decodeConfig(sub, opts.Connection)
+		if err != nil {
+			return Config{}, err
+		}
+		if cfg.Theme == (Theme{}) {
+			// The theme usually lives at the top level, shared by all contexts.
+			if err := v.UnmarshalKey("theme", &cfg.Theme); err != nil {
+				return Config{}, fmt.Errorf("unmarshal theme: %w", err)
+			}
+		}
+		cfg.Context = resolved
+		cfg.NoConfigDir = !useDir
+		return cfg, nil
+	}
+
+	cfg, err := decodeConfig(v, opts.Connection)
+	if err != nil {
+		return Config{}, err
 	}
+	cfg.NoConfigDir = !useDir
+	return cfg, nil
+}
+
+// matchContext resolves name against the configured contexts: an exact match
+// wins, then a unique prefix, then a unique substring. Ambiguous names report
+// the candidates.
+func matchContext(name string, contexts map[string]any) (string, error) {
+	needle := strings.ToLower(name)
+	if _, ok := contexts[needle]; ok {
+		return needle, nil
+	}
+	names := make([]string, 0, len(contexts))
+	for ctx := range contexts {
+		names = append(names, ctx)
+	}
+	sort.Strings(names)
 
-	return decodeConfig(v)
+	for _, match := range []func(string, string) bool{strings.HasPrefix, strings.Contains} {
+		candidates := []string{}
+		for _, ctx := range names {
+			if match(ctx, needle) {
+				candidates = append(candidates, ctx)
+			}
+		}
+		switch len(candidates) {
+		case 0:
+			continue
+		case 1:
+			return candidates[0], nil
+		default:
+			return "", fmt.Errorf("context %q is ambiguous: %s", name, strings.Join(candidates, ", "))
+		}
+	}
+	return "", fmt.Errorf("context %q not found in config", name)
+}
+
+// ListContexts returns the contexts defined in the config file, sorted. A
+// missing file or disabled config directory yields no names.
+func ListContexts(opts Options) ([]string, error) {
+	v, err := readConfigFile(opts)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	contexts := v.GetStringMap("contexts")
+	names := make([]string, 0, len(contexts))
+	for name := range contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ActiveContext resolves the context Load would use: opts.Context when given
+// (matched like --context), otherwise the file's context key. It returns ""
+// when no context is selected.
+func ActiveContext(opts Options) (string, error) {
+	name := opts.Context
+	v, err := readConfigFile(opts)
+	if err != nil || v == nil {
+		return name, err
+	}
+	if name == "" {
+		name = v.GetString("context")
+	}
+	if name == "" {
+		return "", nil
+	}
+	return matchContext(name, v.GetStringMap("contexts"))
+}
+
+// readConfigFile loads the config file alone, without env or defaults. It
+// returns nil when the file is missing or the config directory is disabled.
+func readConfigFile(opts Options) (*viper.Viper, error) {
+	cfgPath := configFilePath()
+	if opts.configDirDisabled() {
+		return nil, nil
+	}
+	if _, err := os.Stat(cfgPath); err != nil {
+		return nil, nil
+	}
+	v := viper.New()
+	v.SetConfigType("yaml")
+	v.SetConfigFile(cfgPath)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	return v, nil
 }
 
 // MergeOverrides applies non-empty values from overrides to the base config.
@@ -83,6 +365,9 @@ func MergeOverrides(base, overrides Config) Config {
 	if overrides.PrivateKeyPath != "" {
 		result.PrivateKeyPath = overrides.PrivateKeyPath
 	}
+	if overrides.Authenticator != "" {
+		result.Authenticator = overrides.Authenticator
+	}
 	if overrides.Database != "" {
 		result.Database = overrides.Database
 	}
@@ -92,12 +377,36 @@ func MergeOverrides(base, overrides Config) Config {
 	if overrides.Warehouse != "" {
 		result.Warehouse = overrides.Warehouse
 	}
+	if overrides.Role != "" {
+		result.Role = overrides.Role
+	}
 	if overrides.Context != "" {
 		result.Context = overrides.Context
 	}
+	if overrides.MonitorWarehouse != "" {
+		result.MonitorWarehouse = overrides.MonitorWarehouse
+	}
 	if overrides.Debug {
 		result.Debug = true
 	}
+	if overrides.StrictKeyPerms {
+		result.StrictKeyPerms = true
+	}
+	if overrides.ProdPattern != "" {
+		result.ProdPattern = overrides.ProdPattern
+	}
+	if overrides.Theme.Preset != "" {
+		result.Theme.Preset = overrides.Theme.Preset
+	}
+	if overrides.QueryTimeout != 0 {
+		result.QueryTimeout = overrides.QueryTimeout
+	}
+	if overrides.LogFormat != "" {
+		result.LogFormat = overrides.LogFormat
+	}
+	if overrides.NoConfigDir {
+		result.NoConfigDir = true
+	}
 	return result
 }
 
@@ -106,25 +415,288 @@ func (c Config) Validate() error {
 	if c.Account == "" {
 		return errors.New("account is required")
 	}
+	if _, err := NormalizeAccount(c.Account, c.AccountFormat); err != nil {
+		return err
+	}
 	if c.User == "" {
 		return errors.New("user is required")
 	}
-	if c.Password == "" && c.PrivateKeyPath == "" {
-		return errors.New("password or private key path is required")
+	if c.CellPadding < 0 {
+		return errors.New("cell_padding must be non-negative")
+	}
+	if c.CostWarnings.RunningServices < 0 || c.CostWarnings.ActiveNodes < 0 {
+		return errors.New("cost_warnings thresholds must be non-negative")
+	}
+	if c.RetryMaxBackoff < 0 {
+		return errors.New("retry_max_backoff must be non-negative")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("max_retries must be non-negative")
+	}
+	if c.CacheTTL < 0 {
+		return errors.New("cache_ttl must be non-negative")
+	}
+	if c.ErrorTimeout < 0 {
+		return errors.New("error_timeout must be non-negative")
+	}
+	if c.QueryTimeout < 0 {
+		return errors.New("query_timeout must be non-negative")
+	}
+	if c.SlowQueryThreshold < 0 {
+		return errors.New("slow_query_threshold must be non-negative")
+	}
+	switch strings.ToLower(c.LogFormat) {
+	case "", LogFormatText, LogFormatJSON:
+	default:
+		return fmt.Errorf("unknown log_format %q (want text or json)", c.LogFormat)
+	}
+	if _, err := regexp.Compile(c.ProdPattern); err != nil {
+		return fmt.Errorf("prod_pattern: %w", err)
+	}
+	if c.Timezone != "" {
+		if _, err := time.LoadLocation(c.Timezone); err != nil {
+			return fmt.Errorf("timezone: %w", err)
+		}
+	}
+	switch strings.ToLower(c.Authenticator) {
+	case "":
+		if c.Password == "" && c.PrivateKeyPath == "" && c.PrivateKey == "" {
+			return errors.New("password, private key, or private key path is required")
+		}
+	case AuthenticatorSnowflake:
+		if c.Password == "" {
+			return errors.New("password is required for snowflake authentication")
+		}
+	case AuthenticatorJWT:
+		if c.PrivateKeyPath == "" && c.PrivateKey == "" {
+			return errors.New("private key or private key path is required for snowflake_jwt authentication")
+		}
+	case AuthenticatorExternalBrowser:
+	case AuthenticatorOAuth:
+		if c.Token == "" {
+			return errors.New("token is required for oauth authentication")
+		}
+	default:
+		return fmt.Errorf("unknown authenticator %q (want snowflake, snowflake_jwt, externalbrowser, or oauth)", c.Authenticator)
 	}
 	if c.PrivateKeyPath != "" {
-		if _, err := os.Stat(c.PrivateKeyPath); err != nil {
+		info, err := os.Stat(c.PrivateKeyPath)
+		if err != nil {
 			return fmt.Errorf("private key path: %w", err)
 		}
+		if c.StrictKeyPerms && keyTooOpen(info) {
+			return fmt.Errorf("private key %s is accessible by others (mode %04o); run chmod 600", c.PrivateKeyPath, info.Mode().Perm())
+		}
 	}
 	return nil
 }
 
-func decodeConfig(v *viper.Viper) (Config, error) {
+// Account formats accepted by account_format.
+const (
+	AccountFormatAuto    = "auto"
+	AccountFormatOrg     = "org"
+	AccountFormatLocator = "locator"
+)
+
+var (
+	orgAccountPattern     = regexp.MustCompile(`^[A-Za-z0-9_]+-[A-Za-z0-9_-]+$`)
+	accountLocatorPattern = regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_-]+){0,2}$`)
+)
+
+// NormalizeAccount trims URL decoration from an account identifier and checks
+// it against the expected form: orgname-accountname or a legacy locator such
+// as xy12345.us-east-1.aws. An empty format accepts either.
+func NormalizeAccount(account, format string) (string, error) {
+	acct := strings.TrimSpace(account)
+	acct = strings.TrimPrefix(acct, "https://")
+	acct = strings.TrimSuffix(acct, "/")
+	if i := strings.Index(strings.ToLower(acct), ".snowflakecomputing.com"); i >= 0 {
+		acct = acct[:i]
+	}
+
+	switch strings.ToLower(format) {
+	case "", AccountFormatAuto:
+		if orgAccountPattern.MatchString(acct) || accountLocatorPattern.MatchString(acct) {
+			return acct, nil
+		}
+		return "", fmt.Errorf("account %q is neither orgname-accountname nor an account locator", account)
+	case AccountFormatOrg:
+		if orgAccountPattern.MatchString(acct) {
+			return acct, nil
+		}
+		if org, name, found := strings.Cut(acct, "."); found {
+			return "", fmt.Errorf("account %q looks wrong: organization account names use a dash, e.g. %s-%s", account, org, name)
+		}
+		return "", fmt.Errorf("account %q is not in orgname-accountname form", account)
+	case AccountFormatLocator:
+		if accountLocatorPattern.MatchString(acct) {
+			return acct, nil
+		}
+		return "", fmt.Errorf("account %q is not an account locator (e.g. xy12345.us-east-1); set account_format: org for orgname-accountname", account)
+	default:
+		return "", fmt.Errorf("account_format must be %q, %q or %q", AccountFormatAuto, AccountFormatOrg, AccountFormatLocator)
+	}
+}
+
+// IsProduction reports whether the account or context name matches the
+// case-insensitive prod_pattern. An empty pattern disables the guard.
+func (c Config) IsProduction() bool {
+	if c.ProdPattern == "" {
+		return false
+	}
+	re, err := regexp.Compile("(?i)" + c.ProdPattern)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(c.Account) || re.MatchString(c.Context)
+}
+
+// InteractiveAuth reports whether connecting waits on the user, as the
+// external browser SSO flow does.
+func (c Config) InteractiveAuth() bool {
+	return strings.EqualFold(c.Authenticator, AuthenticatorExternalBrowser)
+}
+
+// Timeout bounds the connection ping and each query, falling back to
+// DefaultQueryTimeout when query_timeout is unset.
+func (c Config) Timeout() time.Duration {
+	if c.QueryTimeout <= 0 {
+		return DefaultQueryTimeout * time.Second
+	}
+	return time.Duration(c.QueryTimeout) * time.Second
+}
+
+// JSONLog reports whether debug lines should be written as JSON objects.
+func (c Config) JSONLog() bool {
+	return strings.EqualFold(c.LogFormat, LogFormatJSON)
+}
+
+// IsEmpty reports whether no connection settings were provided at all,
+// which is the first-run case.
+func (c Config) IsEmpty() bool {
+	return c.Account == "" && c.User == "" && c.Password == "" && c.PrivateKeyPath == "" && c.PrivateKey == ""
+}
+
+// WriteContext saves cfg as a named context in the config file, making it the
+// default context, and returns the file path. Passwords are never written;
+// they belong in the env file or SNOWFLAKE_PASSWORD.
+func WriteContext(name string, cfg Config) (string, error) {
+	if cfg.NoConfigDir || ConfigDirDisabled() {
+		return "", fmt.Errorf("config directory disabled (--insecure-skip-config-dir or %s)", NoConfigDirEnv)
+	}
+	cfgPath := configFilePath()
+	if err := ensureConfigDir(cfgPath); err != nil {
+		return "", err
+	}
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if _, err := os.Stat(cfgPath); err == nil {
+		v.SetConfigFile(cfgPath)
+		if err := v.ReadInConfig(); err != nil {
+			return "", fmt.Errorf("read config: %w", err)
+		}
+	}
+	prefix := fmt.Sprintf("contexts.%s.", name)
+	values := map[string]string{
+		"account":          cfg.Account,
+		"account_format":   cfg.AccountFormat,
+		"user":             cfg.User,
+		"private_key_path": cfg.PrivateKeyPath,
+		"database":         cfg.Database,
+		"schema":           cfg.Schema,
+		"warehouse":        cfg.Warehouse,
+	}
+	for key, value := range values {
+		if value != "" {
+			v.Set(prefix+key, value)
+		}
+	}
+	v.Set("context", name)
+	v.SetConfigPermissions(0o600)
+	if err := v.WriteConfigAs(cfgPath); err != nil {
+		return "", fmt.Errorf("write config: %w", err)
+	}
+	return cfgPath, nil
+}
+
+// WriteEnvVar sets key in the env file next to the config file (~/.snow9s/env),
+// replacing an existing assignment, and returns the file path. It is where
+// secrets such as SNOWFLAKE_PASSWORD are kept.
+func WriteEnvVar(key, value string) (string, error) {
+	if ConfigDirDisabled() {
+		return "", fmt.Errorf("config directory disabled (--insecure-skip-config-dir or %s)", NoConfigDirEnv)
+	}
+	cfgPath := configFilePath()
+	if err := ensureConfigDir(cfgPath); err != nil {
+		return "", err
+	}
+	envPath := filepath.Join(filepath.Dir(cfgPath), "env")
+	data, err := os.ReadFile(envPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("read env file: %w", err)
+	}
+	line := fmt.Sprintf("%s=%s", key, value)
+	var lines []string
+	if trimmed := strings.TrimRight(string(data), "\n"); trimmed != "" {
+		lines = strings.Split(trimmed, "\n")
+	}
+	replaced := false
+	for i, l := range lines {
+		if name, _, ok := strings.Cut(strings.TrimSpace(l), "="); ok && strings.TrimSpace(name) == key {
+			lines[i] = line
+			replaced = true
+		}
+	}
+	if !replaced {
+		lines = append(lines, line)
+	}
+	if err := os.WriteFile(envPath, []byte(strings.Join(lines, "\n")+"\n"), 0o600); err != nil {
+		return "", fmt.Errorf("write env file: %w", err)
+	}
+	return envPath, nil
+}
+
+// Warnings reports non-fatal configuration problems, such as a private key
+// readable by group or others when strict_key_perms is off.
+func (c Config) Warnings() []string {
+	var warnings []string
+	if c.PrivateKeyPath != "" && !c.StrictKeyPerms {
+		if info, err := os.Stat(c.PrivateKeyPath); err == nil && keyTooOpen(info) {
+			warnings = append(warnings, fmt.Sprintf("private key %s is accessible by others (mode %04o); consider chmod 600", c.PrivateKeyPath, info.Mode().Perm()))
+		}
+	}
+	return warnings
+}
+
+// keyTooOpen mirrors ssh: keys must not grant any group or other permissions.
+func keyTooOpen(info os.FileInfo) bool {
+	if runtime.GOOS == "windows" {
+		return false
+	}
+	return info.Mode().Perm()&0o077 != 0
+}
+
+// decodeConfig unmarshals v and fills gaps from the named Snowflake
+// connection, if any; connection, when set, replaces the one v names.
+func decodeConfig(v *viper.Viper, connection string) (Config, error) {
 	var cfg Config
 	if err := v.Unmarshal(&cfg); err != nil {
 		return Config{}, fmt.Errorf("unmarshal config: %w", err)
 	}
+	if connection != "" {
+		cfg.Connection = connection
+	}
+	if cfg.Connection != "" {
+		conn, err := loadConnection(cfg.Connection)
+		if err != nil {
+			return Config{}, err
+		}
+		cfg = applyConnection(cfg, conn)
+	}
+	if cfg.Schema == "" {
+		cfg.Schema = DefaultSchema
+	}
 	return cfg, nil
 }
 
@@ -140,7 +712,7 @@ func configFilePath() string {
 }
 
 func bindEnvKeys(v *viper.Viper) {
-	for _, key := range []string{"account", "user", "password", "private_key_path", "database", "schema", "warehouse", "context", "debug"} {
+	for _, key := range []string{"account", "user", "password", "private_key_path", "private_key", "private_key_passphrase", "authenticator", "token", "database", "schema", "warehouse", "role", "context", "connection", "debug", "monitor_warehouse", "strict_key_perms", "prod_pattern", "account_format", "timezone", "auto_resume_warehouse", "log_format"} {
 		_ = v.BindEnv(key)
 	}
 }
@@ -199,6 +771,7 @@ func createEnvTemplate(dir string) {
 # SNOWFLAKE_DATABASE=MYDB
 # SNOWFLAKE_SCHEMA=PUBLIC
 # SNOWFLAKE_WAREHOUSE=COMPUTE_WH
+# SNOWFLAKE_MONITOR_WAREHOUSE=MONITOR_WH
 `
 	_ = os.WriteFile(envPath, []byte(template), 0o600)
 }
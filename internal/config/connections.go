@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// snowflakeConnection is one [name] table of Snowflake's connections.toml,
+// as read by the Snowflake CLI and connectors.
+type snowflakeConnection struct {
+	Account              string `mapstructure:"account"`
+	User                 string `mapstructure:"user"`
+	Password             string `mapstructure:"password"`
+	Authenticator        string `mapstructure:"authenticator"`
+	Token                string `mapstructure:"token"`
+	PrivateKeyFile       string `mapstructure:"private_key_file"`
+	PrivateKeyPath       string `mapstructure:"private_key_path"`
+	PrivateKeyPassphrase string `mapstructure:"private_key_file_pwd"`
+	Warehouse            string `mapstructure:"warehouse"`
+	Database             string `mapstructure:"database"`
+	Schema               string `mapstructure:"schema"`
+	Role                 string `mapstructure:"role"`
+}
+
+// connectionsFilePath returns connections.toml under $SNOWFLAKE_HOME, or
+// ~/.snowflake by default.
+func connectionsFilePath() string {
+	if home := os.Getenv("SNOWFLAKE_HOME"); home != "" {
+		return filepath.Join(home, "connections.toml")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "connections.toml"
+	}
+	return filepath.Join(home, ".snowflake", "connections.toml")
+}
+
+// loadConnection reads the named connection from connections.toml.
+func loadConnection(name string) (snowflakeConnection, error) {
+	path := connectionsFilePath()
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("toml")
+	if err := v.ReadInConfig(); err != nil {
+		return snowflakeConnection{}, fmt.Errorf("read %s: %w", path, err)
+	}
+	sub := v.Sub(strings.ToLower(name))
+	if sub == nil {
+		return snowflakeConnection{}, fmt.Errorf("connection %q not found in %s", name, path)
+	}
+	var conn snowflakeConnection
+	if err := sub.Unmarshal(&conn); err != nil {
+		return snowflakeConnection{}, fmt.Errorf("parse connection %q: %w", name, err)
+	}
+	return conn, nil
+}
+
+// applyConnection fills settings missing from cfg with the connection's, so
+// values from the snow9s config, env, and flags still win.
+func applyConnection(cfg Config, conn snowflakeConnection) Config {
+	fill := func(dst *string, values ...string) {
+		for _, v := range values {
+			if *dst == "" && v != "" {
+				*dst = v
+			}
+		}
+	}
+	fill(&cfg.Account, conn.Account)
+	fill(&cfg.User, conn.User)
+	fill(&cfg.Password, conn.Password)
+	fill(&cfg.Authenticator, conn.Authenticator)
+	fill(&cfg.Token, conn.Token)
+	fill(&cfg.PrivateKeyPath, conn.PrivateKeyFile, conn.PrivateKeyPath)
+	fill(&cfg.PrivateKeyPassphrase, conn.PrivateKeyPassphrase)
+	fill(&cfg.Warehouse, conn.Warehouse)
+	fill(&cfg.Database, conn.Database)
+	fill(&cfg.Schema, conn.Schema)
+	fill(&cfg.Role, conn.Role)
+	return cfg
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// State holds UI preferences persisted between runs in ~/.snow9s/state.json.
+type State struct {
+	// Favorites maps a context key to fully-qualified service names.
+	Favorites map[string][]string `json:"favorites,omitempty"`
+	// UTC renders clocks and timestamps in UTC instead of local time.
+	UTC bool `json:"utc,omitempty"`
+	// NoWrap lists text view kinds (detail, debug) with line wrapping off.
+	NoWrap map[string]bool `json:"noWrap,omitempty"`
+	// Views maps a context key to the view and filter it was last left on.
+	Views map[string]ViewState `json:"views,omitempty"`
+
+	// noConfigDir makes SaveState a no-op; see Config.NoConfigDir.
+	noConfigDir bool
+}
+
+// ViewState is the view and applied filter restored when the TUI starts.
+type ViewState struct {
+	View   string `json:"view,omitempty"`
+	Filter string `json:"filter,omitempty"`
+}
+
+// StateKey identifies the context that per-context state is stored under.
+func StateKey(cfg Config) string {
+	if cfg.Context != "" {
+		return cfg.Context
+	}
+	return cfg.Account
+}
+
+// LoadState reads the state file, returning an empty state when it is absent
+// or the config directory is disabled for cfg.
+func LoadState(cfg Config) (State, error) {
+	st := State{noConfigDir: cfg.NoConfigDir || ConfigDirDisabled()}
+	if st.noConfigDir {
+		return st, nil
+	}
+	data, err := os.ReadFile(stateFilePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return st, nil
+	}
+	if err != nil {
+		return st, fmt.Errorf("read state: %w", err)
+	}
+	if err := json.Unmarshal(data, &st); err != nil {
+		return State{}, fmt.Errorf("parse state: %w", err)
+	}
+	return st, nil
+}
+
+// SaveState writes the state file next to the config file. It is a no-op
+// when the config directory is disabled.
+func SaveState(st State) error {
+	if st.noConfigDir || ConfigDirDisabled() {
+		return nil
+	}
+	path := stateFilePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode state: %w", err)
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// IsFavorite reports whether name is pinned for the given context key.
+func (s State) IsFavorite(key, name string) bool {
+	for _, fav := range s.Favorites[key] {
+		if fav == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ToggleFavorite pins or unpins name and reports whether it is now pinned.
+func (s *State) ToggleFavorite(key, name string) bool {
+	if s.Favorites == nil {
+		s.Favorites = map[string][]string{}
+	}
+	favs := s.Favorites[key]
+	for i, fav := range favs {
+		if fav == name {
+			s.Favorites[key] = append(favs[:i], favs[i+1:]...)
+			return false
+		}
+	}
+	s.Favorites[key] = append(favs, name)
+	return true
+}
+
+func stateFilePath() string {
+	return filepath.Join(filepath.Dir(configFilePath()), "state.json")
+}
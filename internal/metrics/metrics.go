@@ -0,0 +1,273 @@
+// Package metrics polls SPCS resources and serves their counts in the
+// Prometheus text exposition format, for running snow9s as a monitor.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/marcelinojackson-org/snow9s/pkg/models"
+)
+
+// Resources the poller can watch.
+const (
+	ResourceServices = "services"
+	ResourcePools    = "pools"
+)
+
+// Lister is the part of the SPCS layer the poller reads.
+type Lister interface {
+	ListServices(ctx context.Context) ([]models.Service, error)
+	ListComputePools(ctx context.Context) ([]models.ComputePool, error)
+}
+
+// ParseResources validates resource names, dropping duplicates.
+func ParseResources(names []string) ([]string, error) {
+	var out []string
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "":
+			continue
+		case ResourceServices, ResourcePools:
+		default:
+			return nil, fmt.Errorf("unknown resource %q (want %s or %s)", name, ResourceServices, ResourcePools)
+		}
+		if !slices.Contains(out, name) {
+			out = append(out, name)
+		}
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no resources to poll")
+	}
+	return out, nil
+}
+
+// pollState is the outcome of the polls of one resource.
+type pollState struct {
+	lastErr     error
+	lastSuccess time.Time
+	failures    int
+}
+
+// Poller lists the configured resources every interval and keeps the latest
+// results for the metrics and health handlers.
+type Poller struct {
+	lister    Lister
+	interval  time.Duration
+	resources []string
+	now       func() time.Time
+
+	mu       sync.Mutex
+	services []models.Service
+	pools    []models.ComputePool
+	state    map[string]*pollState
+}
+
+// NewPoller polls resources through lister every interval.
+func NewPoller(lister Lister, interval time.Duration, resources []string) *Poller {
+	state := map[string]*pollState{}
+	for _, r := range resources {
+		state[r] = &pollState{}
+	}
+	return &Poller{
+		lister:    lister,
+		interval:  interval,
+		resources: resources,
+		now:       time.Now,
+		state:     state,
+	}
+}
+
+// Run polls once straight away and then every interval until ctx is done.
+func (p *Poller) Run(ctx context.Context) {
+	p.Poll(ctx)
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.Poll(ctx)
+		}
+	}
+}
+
+// Poll lists each resource once. A failed list keeps the previous values so
+// a blip does not zero the gauges; the failure shows in the poll metrics.
+func (p *Poller) Poll(ctx context.Context) {
+	for _, r := range p.resources {
+		pollCtx, cancel := context.WithTimeout(ctx, p.interval)
+		var (
+			services []models.Service
+			pools    []models.ComputePool
+			err      error
+		)
+		switch r {
+		case ResourceServices:
+			services, err = p.lister.ListServices(pollCtx)
+		case ResourcePools:
+			pools, err = p.lister.ListComputePools(pollCtx)
+		}
+		cancel()
+
+		p.mu.Lock()
+		st := p.state[r]
+		st.lastErr = err
+		if err != nil {
+			st.failures++
+		} else {
+			st.lastSuccess = p.now()
+			switch r {
+			case ResourceServices:
+				p.services = services
+			case ResourcePools:
+				p.pools = pools
+			}
+		}
+		p.mu.Unlock()
+	}
+}
+
+// Healthy reports an error unless every resource has been listed within the
+// last three intervals.
+func (p *Poller) Healthy() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, r := range p.resources {
+		st := p.state[r]
+		if st.lastSuccess.IsZero() {
+			if st.lastErr != nil {
+				return fmt.Errorf("%s: %w", r, st.lastErr)
+			}
+			return fmt.Errorf("%s: not polled yet", r)
+		}
+		if age := p.now().Sub(st.lastSuccess); age > 3*p.interval {
+			msg := fmt.Sprintf("%s: last successful poll %s ago", r, age.Round(time.Second))
+			if st.lastErr != nil {
+				return fmt.Errorf("%s: %w", msg, st.lastErr)
+			}
+			return fmt.Errorf("%s", msg)
+		}
+	}
+	return nil
+}
+
+// WriteMetrics writes the current values in the Prometheus text format.
+func (p *Poller) WriteMetrics(w io.Writer) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var b strings.Builder
+	if _, ok := p.state[ResourceServices]; ok {
+		byStatus := map[string]int{}
+		for _, svc := range p.services {
+			byStatus[labelValue(svc.Status)]++
+		}
+		writeFamily(&b, "snow9s_services", "Services by status.")
+		for _, status := range sortedKeys(byStatus) {
+			fmt.Fprintf(&b, "snow9s_services{status=\"%s\"} %d\n", escapeLabel(status), byStatus[status])
+		}
+		writeFamily(&b, "snow9s_services_count", "Services in the schema.")
+		fmt.Fprintf(&b, "snow9s_services_count %d\n", len(p.services))
+	}
+	if _, ok := p.state[ResourcePools]; ok {
+		byState := map[string]int{}
+		for _, pool := range p.pools {
+			byState[labelValue(pool.State)]++
+		}
+		writeFamily(&b, "snow9s_compute_pools", "Compute pools by state.")
+		for _, state := range sortedKeys(byState) {
+			fmt.Fprintf(&b, "snow9s_compute_pools{state=\"%s\"} %d\n", escapeLabel(state), byState[state])
+		}
+		writeFamily(&b, "snow9s_compute_pools_count", "Compute pools in the account.")
+		fmt.Fprintf(&b, "snow9s_compute_pools_count %d\n", len(p.pools))
+
+		pools := slices.Clone(p.pools)
+		slices.SortFunc(pools, func(a, b models.ComputePool) int { return strings.Compare(a.Name, b.Name) })
+		writeFamily(&b, "snow9s_compute_pool_active_nodes", "Active nodes per compute pool.")
+		for _, pool := range pools {
+			fmt.Fprintf(&b, "snow9s_compute_pool_active_nodes{pool=\"%s\"} %d\n", escapeLabel(pool.Name), pool.ActiveNodeCount)
+		}
+		writeFamily(&b, "snow9s_compute_pool_max_nodes", "Maximum nodes per compute pool.")
+		for _, pool := range pools {
+			fmt.Fprintf(&b, "snow9s_compute_pool_max_nodes{pool=\"%s\"} %d\n", escapeLabel(pool.Name), pool.MaxNodeCount)
+		}
+	}
+
+	writeFamily(&b, "snow9s_poll_success", "Whether the last poll of a resource succeeded.")
+	for _, r := range p.resources {
+		ok := 0
+		if p.state[r].lastErr == nil && !p.state[r].lastSuccess.IsZero() {
+			ok = 1
+		}
+		fmt.Fprintf(&b, "snow9s_poll_success{resource=\"%s\"} %d\n", r, ok)
+	}
+	fmt.Fprintf(&b, "# HELP snow9s_poll_failures_total Failed polls per resource.\n# TYPE snow9s_poll_failures_total counter\n")
+	for _, r := range p.resources {
+		fmt.Fprintf(&b, "snow9s_poll_failures_total{resource=\"%s\"} %d\n", r, p.state[r].failures)
+	}
+	writeFamily(&b, "snow9s_last_success_timestamp_seconds", "Unix time of the last successful poll per resource.")
+	for _, r := range p.resources {
+		var ts int64
+		if last := p.state[r].lastSuccess; !last.IsZero() {
+			ts = last.Unix()
+		}
+		fmt.Fprintf(&b, "snow9s_last_success_timestamp_seconds{resource=\"%s\"} %d\n", r, ts)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Handler serves /metrics and /healthz.
+func (p *Poller) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = p.WriteMetrics(w)
+	})
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if err := p.Healthy(); err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintf(w, "unhealthy: %s\n", err)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	})
+	return mux
+}
+
+func writeFamily(b *strings.Builder, name, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+}
+
+// labelValue normalizes a status for use as a label, naming blanks.
+func labelValue(s string) string {
+	s = models.NormalizeStatus(s)
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,141 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marcelinojackson-org/snow9s/pkg/models"
+)
+
+type fakeLister struct {
+	services []models.Service
+	pools    []models.ComputePool
+	err      error
+}
+
+func (f *fakeLister) ListServices(context.Context) ([]models.Service, error) {
+	return f.services, f.err
+}
+
+func (f *fakeLister) ListComputePools(context.Context) ([]models.ComputePool, error) {
+	return f.pools, f.err
+}
+
+func TestParseResources(t *testing.T) {
+	got, err := ParseResources([]string{" Services", "pools", "services"})
+	if err != nil {
+		t.Fatalf("ParseResources: %v", err)
+	}
+	if strings.Join(got, ",") != "services,pools" {
+		t.Fatalf("got %v", got)
+	}
+	if _, err := ParseResources([]string{"repos"}); err == nil {
+		t.Fatal("expected error for unknown resource")
+	}
+	if _, err := ParseResources(nil); err == nil {
+		t.Fatal("expected error for no resources")
+	}
+}
+
+func TestWriteMetrics(t *testing.T) {
+	lister := &fakeLister{
+		services: []models.Service{
+			{Name: "A", Status: "running"},
+			{Name: "B", Status: "running"},
+			{Name: "C", Status: "failed"},
+			{Name: "D"},
+		},
+		pools: []models.ComputePool{
+			{Name: "P2", State: "idle", MaxNodeCount: 2},
+			{Name: "P1", State: "active", ActiveNodeCount: 1, MaxNodeCount: 3},
+		},
+	}
+	p := NewPoller(lister, time.Minute, []string{ResourceServices, ResourcePools})
+	p.now = func() time.Time { return time.Unix(1700000000, 0) }
+	p.Poll(context.Background())
+
+	var b strings.Builder
+	if err := p.WriteMetrics(&b); err != nil {
+		t.Fatalf("WriteMetrics: %v", err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"# TYPE snow9s_services gauge\n",
+		`snow9s_services{status="failed"} 1`,
+		`snow9s_services{status="running"} 2`,
+		`snow9s_services{status="unknown"} 1`,
+		"snow9s_services_count 4\n",
+		`snow9s_compute_pools{state="active"} 1`,
+		"snow9s_compute_pools_count 2\n",
+		"snow9s_compute_pool_active_nodes{pool=\"P1\"} 1\nsnow9s_compute_pool_active_nodes{pool=\"P2\"} 0\n",
+		`snow9s_compute_pool_max_nodes{pool="P1"} 3`,
+		`snow9s_poll_success{resource="services"} 1`,
+		`snow9s_poll_failures_total{resource="pools"} 0`,
+		`snow9s_last_success_timestamp_seconds{resource="pools"} 1700000000`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("metrics missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestPollFailureKeepsValues(t *testing.T) {
+	lister := &fakeLister{services: []models.Service{{Name: "A", Status: "running"}}}
+	p := NewPoller(lister, time.Minute, []string{ResourceServices})
+	p.Poll(context.Background())
+
+	lister.err = errors.New("network down")
+	p.Poll(context.Background())
+
+	var b strings.Builder
+	_ = p.WriteMetrics(&b)
+	out := b.String()
+	for _, want := range []string{
+		"snow9s_services_count 1\n",
+		`snow9s_poll_success{resource="services"} 0`,
+		`snow9s_poll_failures_total{resource="services"} 1`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("metrics missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "snow9s_compute_pools") {
+		t.Errorf("pools were not requested:\n%s", out)
+	}
+}
+
+func TestHealthz(t *testing.T) {
+	lister := &fakeLister{}
+	p := NewPoller(lister, time.Minute, []string{ResourceServices})
+	now := time.Unix(1700000000, 0)
+	p.now = func() time.Time { return now }
+	handler := p.Handler()
+
+	status := func() int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		return rec.Code
+	}
+
+	if got := status(); got != http.StatusServiceUnavailable {
+		t.Fatalf("before first poll: got %d", got)
+	}
+	p.Poll(context.Background())
+	if got := status(); got != http.StatusOK {
+		t.Fatalf("after poll: got %d", got)
+	}
+	lister.err = errors.New("boom")
+	p.Poll(context.Background())
+	if got := status(); got != http.StatusOK {
+		t.Fatalf("one failed poll should stay healthy: got %d", got)
+	}
+	now = now.Add(4 * time.Minute)
+	if got := status(); got != http.StatusServiceUnavailable {
+		t.Fatalf("stale poll: got %d", got)
+	}
+}
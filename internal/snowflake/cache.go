@@ -0,0 +1,69 @@
+package snowflake
+
+import (
+	"sync"
+	"time"
+)
+
+// queryCache keeps recent list results keyed by query text so refreshes
+// within ttl reuse them instead of querying Snowflake again. A zero ttl
+// disables it. It is safe for concurrent use.
+type queryCache struct {
+	ttl     time.Duration
+	now     func() time.Time
+	mu      sync.Mutex
+	gen     uint64
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	value   any
+	expires time.Time
+}
+
+func newQueryCache(ttl time.Duration) *queryCache {
+	return &queryCache{ttl: ttl, now: time.Now, entries: map[string]cacheEntry{}}
+}
+
+// cacheGet returns the unexpired result for query. On a miss it returns the
+// cache generation to hand back to put, so a result loaded across a clear is
+// not stored.
+func cacheGet[T any](c *queryCache, query string) (T, uint64, bool) {
+	var zero T
+	if c == nil || c.ttl <= 0 {
+		return zero, 0, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[query]
+	if !ok || !c.now().Before(entry.expires) {
+		delete(c.entries, query)
+		return zero, c.gen, false
+	}
+	value, ok := entry.value.(T)
+	return value, c.gen, ok
+}
+
+// put stores value for query unless the cache was cleared since gen.
+func (c *queryCache) put(query string, gen uint64, value any) {
+	if c == nil || c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if gen != c.gen {
+		return
+	}
+	c.entries[query] = cacheEntry{value: value, expires: c.now().Add(c.ttl)}
+}
+
+// clear drops every cached result.
+func (c *queryCache) clear() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	clear(c.entries)
+}
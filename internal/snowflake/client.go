@@ -1,12 +1,16 @@
 package snowflake
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/base64"
 	"encoding/pem"
 	"fmt"
 	"log"
 	"os"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"crypto/rsa"
@@ -17,7 +21,18 @@ import (
 	"github.com/marcelinojackson-org/snow9s/internal/config"
 )
 
-const defaultTimeout = 10 * time.Second
+// interactiveAuthTimeout bounds the first ping when login waits on the user,
+// e.g. to finish SSO in the browser.
+const interactiveAuthTimeout = 3 * time.Minute
+
+// redacted replaces secrets in DSN previews.
+const redacted = "REDACTED"
+
+// maxIdleConns is how many sessions the pool keeps open between queries.
+const maxIdleConns = 2
+
+// defaultQueryTag lets account admins attribute queries issued by snow9s.
+const defaultQueryTag = "snow9s"
 
 // Queryable abstracts sql.DB for easier testing.
 type Queryable interface {
@@ -26,9 +41,21 @@ type Queryable interface {
 
 // Client wraps the Snowflake connection.
 type Client struct {
-	db     *sql.DB
-	debug  bool
-	logger *log.Logger
+	db         *sql.DB
+	debug      bool
+	jsonLog    bool
+	logger     *log.Logger
+	location   *time.Location
+	maxRetries int
+	retryBase  time.Duration
+	// slowQuery is how long a query may take before it is flagged as slow;
+	// zero disables the check.
+	slowQuery time.Duration
+	lastQuery atomic.Pointer[QueryTiming]
+	// autoResume resumes a suspended warehouse once and reruns the query,
+	// as auto_resume_warehouse asks. warehouse is the one pooled sessions use.
+	autoResume bool
+	warehouse  string
 }
 
 // NewClient establishes a Snowflake connection and validates it with Ping.
@@ -37,52 +64,85 @@ func NewClient(ctx context.Context, cfg config.Config, logger *log.Logger) (*Cli
 		return nil, err
 	}
 
-	sfCfg := gosnowflake.Config{
-		Account:   cfg.Account,
-		User:      cfg.User,
-		Warehouse: cfg.Warehouse,
-		Database:  cfg.Database,
-		Schema:    cfg.Schema,
-	}
-	if cfg.PrivateKeyPath != "" {
-		keyBytes, err := os.ReadFile(cfg.PrivateKeyPath)
-		if err != nil {
-			return nil, fmt.Errorf("read private key: %w", err)
-		}
-		privateKey, err := parseRSAPrivateKey(keyBytes)
-		if err != nil {
-			return nil, fmt.Errorf("parse private key: %w", err)
-		}
-		sfCfg.PrivateKey = privateKey
-		sfCfg.Authenticator = gosnowflake.AuthTypeJwt
-	} else {
-		sfCfg.Password = cfg.Password
-	}
-
-	dsn, err := gosnowflake.DSN(&sfCfg)
+	sfCfg, err := buildSnowflakeConfig(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("create DSN: %w", err)
+		return nil, err
 	}
 
-	db, err := sql.Open("snowflake", dsn)
-	if err != nil {
-		return nil, fmt.Errorf("open connection: %w", err)
+	if logger == nil {
+		logger = log.New(log.Writer(), "snow9s", log.LstdFlags)
+	}
+	connector := sessionConnector{
+		Connector:  gosnowflake.NewConnector(gosnowflake.SnowflakeDriver{}, *sfCfg),
+		statements: sessionStatements(cfg),
 	}
+	if cfg.Debug {
+		connector.logger = logger
+		connector.jsonLog = cfg.JSONLog()
+	}
+
+	db := sql.OpenDB(connector)
 	db.SetMaxOpenConns(5)
-	db.SetMaxIdleConns(2)
+	db.SetMaxIdleConns(maxIdleConns)
 	db.SetConnMaxLifetime(30 * time.Minute)
 
-	pingCtx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	// Only the USE statements, which run once login has finished, are logged
+	// before the ping succeeds so the browser SSO flow can use the terminal
+	// undisturbed.
+	pingTimeout := cfg.Timeout()
+	if cfg.InteractiveAuth() {
+		pingTimeout = interactiveAuthTimeout
+	}
+	pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
 	defer cancel()
 	if err := db.PingContext(pingCtx); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("ping Snowflake: %w", err)
 	}
 
-	if logger == nil {
-		logger = log.New(log.Writer(), "snow9s", log.LstdFlags)
+	location, err := sessionLocation(pingCtx, db, cfg.Timezone)
+	if err != nil {
+		writeLog(logger, cfg.JSONLog(), LogEntry{Level: LevelWarn, Msg: fmt.Sprintf("resolve session time zone: %v; assuming UTC", err)})
+		location = time.UTC
 	}
 
-	return &Client{db: db, debug: cfg.Debug, logger: logger}, nil
+	client := &Client{
+		db:         db,
+		debug:      cfg.Debug,
+		jsonLog:    cfg.JSONLog(),
+		logger:     logger,
+		location:   location,
+		maxRetries: cfg.MaxRetries,
+		retryBase:  queryRetryBase,
+		slowQuery:  time.Duration(cfg.SlowQueryThreshold) * time.Second,
+		autoResume: cfg.AutoResumeWarehouse,
+		warehouse:  cfg.Warehouse,
+	}
+	return client, nil
+}
+
+// Location returns the session time zone used for timestamps that carry no
+// offset.
+func (c *Client) Location() *time.Location {
+	return c.location
+}
+
+// sessionLocation resolves the session time zone: the configured timezone
+// when set, otherwise the current UTC offset reported by Snowflake.
+func sessionLocation(ctx context.Context, db *sql.DB, timezone string) (*time.Location, error) {
+	if timezone != "" {
+		return time.LoadLocation(timezone)
+	}
+	var offset string
+	if err := db.QueryRowContext(ctx, "SELECT TO_VARCHAR(CURRENT_TIMESTAMP(), 'TZH:TZM')").Scan(&offset); err != nil {
+		return nil, fmt.Errorf("query session offset: %w", err)
+	}
+	ts, err := time.Parse("-07:00", strings.TrimSpace(offset))
+	if err != nil {
+		return nil, fmt.Errorf("parse session offset %q: %w", offset, err)
+	}
+	_, seconds := ts.Zone()
+	return time.FixedZone("UTC"+strings.TrimSpace(offset), seconds), nil
 }
 
 // QueryContext satisfies the Queryable interface while honoring debug logging.
@@ -90,12 +150,188 @@ func (c *Client) QueryContext(ctx context.Context, query string, args ...any) (*
 	return c.Query(ctx, query, args...)
 }
 
-// Query issues a SQL query with optional debug logging.
+// Query issues a SQL query, logging it with its duration when debug logging
+// is on. Transient failures are retried up to the configured MaxRetries with
+// exponential backoff, as long as the context deadline leaves time for the
+// wait. A suspended warehouse is resumed and the query rerun once when
+// auto-resume is enabled.
 func (c *Client) Query(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
-	if c.debug {
-		c.logger.Printf("SQL: %s", query)
+	for attempt := 0; ; attempt++ {
+		rows, err := c.resumeOnSuspend(ctx, c.db, c.warehouse, func() (*sql.Rows, error) {
+			return c.timedQuery(ctx, query, args...)
+		})
+		if err == nil || attempt >= c.maxRetries || !isRetryable(err) {
+			return rows, err
+		}
+		delay := retryDelay(c.retryBase, attempt)
+		c.debugf(LevelWarn, "retry %d/%d in %s: %v", attempt+1, c.maxRetries, delay.Round(time.Millisecond), err)
+		if !sleepCtx(ctx, delay) {
+			return nil, err
+		}
+	}
+}
+
+// timedQuery runs one attempt of a query, logs how long it took, and
+// records the timing for LastQuery.
+func (c *Client) timedQuery(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	elapsed := time.Since(start)
+	c.logQuery(query, elapsed, err)
+	timing := &QueryTiming{Query: query, Elapsed: elapsed, Slow: c.slowQuery > 0 && elapsed > c.slowQuery}
+	c.lastQuery.Store(timing)
+	if timing.Slow {
+		c.debugf(LevelWarn, "%s", timing)
+	}
+	return rows, err
+}
+
+// QueryTiming records how long a query took.
+type QueryTiming struct {
+	Query   string
+	Elapsed time.Duration
+	// Slow is set when Elapsed exceeded slow_query_threshold.
+	Slow bool
+}
+
+// String describes the timing as the slow-query warning shows it.
+func (t QueryTiming) String() string {
+	verdict := "query"
+	if t.Slow {
+		verdict = "slow query"
+	}
+	return fmt.Sprintf("%s: %s took %.1fs", verdict, t.Query, t.Elapsed.Seconds())
+}
+
+// LastQuery returns the timing of the most recent query, or false before
+// any ran.
+func (c *Client) LastQuery() (QueryTiming, bool) {
+	timing := c.lastQuery.Load()
+	if timing == nil {
+		return QueryTiming{}, false
+	}
+	return *timing, true
+}
+
+// sessionQuerier is what resuming a warehouse needs from a *sql.DB or a
+// pinned *sql.Conn.
+type sessionQuerier interface {
+	Queryable
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// resumeOnSuspend runs a query and, when auto-resume is on and the query
+// failed because warehouse is suspended, resumes warehouse through q and
+// runs the query once more.
+func (c *Client) resumeOnSuspend(ctx context.Context, q sessionQuerier, warehouse string, run func() (*sql.Rows, error)) (*sql.Rows, error) {
+	rows, err := run()
+	if err == nil || !c.autoResume || warehouse == "" || !c.warehouseSuspended(ctx, q, warehouse, err) {
+		return rows, err
+	}
+	if rerr := c.resume(ctx, q, warehouse); rerr != nil {
+		return nil, fmt.Errorf("%w (resume warehouse %s: %v)", err, warehouse, rerr)
+	}
+	return run()
+}
+
+// warehouseSuspended reports whether err came from warehouse being
+// suspended. "No active warehouse" is also what a missing or ungranted
+// warehouse reports, so that error is only trusted once SHOW WAREHOUSES
+// confirms the warehouse is suspended.
+func (c *Client) warehouseSuspended(ctx context.Context, q Queryable, warehouse string, err error) bool {
+	if IsWarehouseSuspended(err) {
+		return true
+	}
+	if !IsNoActiveWarehouse(err) {
+		return false
+	}
+	state, serr := warehouseState(ctx, q, warehouse)
+	if serr != nil {
+		c.debugf(LevelWarn, "check warehouse %s: %v", warehouse, serr)
+		return false
+	}
+	return state == "SUSPENDED"
+}
+
+// warehouseState returns the state SHOW WAREHOUSES reports for warehouse,
+// such as STARTED or SUSPENDED, or "" when the role cannot see it.
+func warehouseState(ctx context.Context, q Queryable, warehouse string) (string, error) {
+	name := warehouse
+	if len(name) > 1 && strings.HasPrefix(name, `"`) && strings.HasSuffix(name, `"`) {
+		name = strings.ReplaceAll(name[1:len(name)-1], `""`, `"`)
+	}
+	rows, err := queryRows(ctx, q, "SHOW WAREHOUSES LIKE "+quoteLiteral(name))
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+	state := ""
+	for rows.Next() {
+		rec, err := scanRowToMap(rows, cols)
+		if err != nil {
+			return "", err
+		}
+		// LIKE treats _ as a wildcard and ignores case, so pick the exact name.
+		if got := rec.get("name"); got == name || (plainIdent.MatchString(warehouse) && strings.EqualFold(got, name)) {
+			state = strings.ToUpper(rec.get("state"))
+		}
 	}
-	return c.db.QueryContext(ctx, query, args...)
+	return state, rows.Err()
+}
+
+// resume starts warehouse through q if it is suspended.
+func (c *Client) resume(ctx context.Context, q sessionQuerier, warehouse string) error {
+	stmt := "ALTER WAREHOUSE " + configIdent(warehouse) + " RESUME IF SUSPENDED"
+	start := time.Now()
+	_, err := q.ExecContext(ctx, stmt)
+	c.logQuery(stmt, time.Since(start), err)
+	return err
+}
+
+// withResume wraps a session pinned to warehouse so its queries resume the
+// warehouse like Query does.
+func (c *Client) withResume(conn *sql.Conn, warehouse string) Queryable {
+	if !c.autoResume {
+		return conn
+	}
+	return resumingConn{Conn: conn, client: c, warehouse: warehouse}
+}
+
+// resumingConn is a pinned session whose queries resume its warehouse once
+// when they find it suspended.
+type resumingConn struct {
+	*sql.Conn
+	client    *Client
+	warehouse string
+}
+
+func (r resumingConn) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return r.client.resumeOnSuspend(ctx, r.Conn, r.warehouse, func() (*sql.Rows, error) {
+		return r.Conn.QueryContext(ctx, query, args...)
+	})
+}
+
+// Reconnect discards the pooled idle sessions, whose tokens may have expired
+// or whose sockets may be dead, and pings Snowflake so the next query runs on
+// a freshly authenticated session.
+func (c *Client) Reconnect(ctx context.Context) error {
+	c.db.SetMaxIdleConns(0)
+	c.db.SetMaxIdleConns(maxIdleConns)
+	if err := c.db.PingContext(ctx); err != nil {
+		c.debugf(LevelWarn, "reconnect: %v", err)
+		return err
+	}
+	c.debugf(LevelDebug, "reconnected to Snowflake")
+	return nil
+}
+
+// Conn pins a single session from the pool, e.g. to scope USE WAREHOUSE.
+func (c *Client) Conn(ctx context.Context) (*sql.Conn, error) {
+	return c.db.Conn(ctx)
 }
 
 // Close releases the database connection.
@@ -111,18 +347,173 @@ func (c *Client) DB() *sql.DB {
 	return c.db
 }
 
-func parseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+// RedactedDSN builds the gosnowflake DSN for cfg with the password and
+// private key replaced, so it is safe to print or log.
+func RedactedDSN(cfg config.Config) (string, error) {
+	if err := cfg.Validate(); err != nil {
+		return "", err
+	}
+	sfCfg, err := buildSnowflakeConfig(cfg)
+	if err != nil {
+		return "", err
+	}
+	if sfCfg.Password != "" {
+		sfCfg.Password = redacted
+	}
+	if sfCfg.Token != "" {
+		sfCfg.Token = redacted
+	}
+	usesKey := sfCfg.PrivateKey != nil
+	sfCfg.PrivateKey = nil
+
+	dsn, err := gosnowflake.DSN(sfCfg)
+	if err != nil {
+		return "", fmt.Errorf("create DSN: %w", err)
+	}
+	if usesKey {
+		sep := "?"
+		if strings.Contains(dsn, "?") {
+			sep = "&"
+		}
+		dsn += sep + "privateKey=" + redacted
+	}
+	return dsn, nil
+}
+
+func buildSnowflakeConfig(cfg config.Config) (*gosnowflake.Config, error) {
+	account, err := config.NormalizeAccount(cfg.Account, cfg.AccountFormat)
+	if err != nil {
+		return nil, err
+	}
+	sfCfg := &gosnowflake.Config{
+		Account:   account,
+		User:      cfg.User,
+		Warehouse: cfg.Warehouse,
+		Database:  cfg.Database,
+		Schema:    cfg.Schema,
+		Role:      cfg.Role,
+	}
+	sfCfg.Params = sessionParams(cfg.SessionParams, cfg.Timezone)
+	switch strings.ToLower(cfg.Authenticator) {
+	case config.AuthenticatorExternalBrowser:
+		sfCfg.Authenticator = gosnowflake.AuthTypeExternalBrowser
+		sfCfg.ExternalBrowserTimeout = interactiveAuthTimeout
+		return sfCfg, nil
+	case config.AuthenticatorOAuth:
+		sfCfg.Authenticator = gosnowflake.AuthTypeOAuth
+		sfCfg.Token = cfg.Token
+		return sfCfg, nil
+	case config.AuthenticatorSnowflake:
+		sfCfg.Authenticator = gosnowflake.AuthTypeSnowflake
+		sfCfg.Password = cfg.Password
+		return sfCfg, nil
+	}
+	if cfg.PrivateKey != "" || cfg.PrivateKeyPath != "" {
+		keyBytes, err := privateKeyPEM(cfg)
+		if err != nil {
+			return nil, err
+		}
+		privateKey, err := parseRSAPrivateKey(keyBytes, cfg.PrivateKeyPassphrase)
+		if err != nil {
+			return nil, fmt.Errorf("parse private key: %w", err)
+		}
+		sfCfg.PrivateKey = privateKey
+		sfCfg.Authenticator = gosnowflake.AuthTypeJwt
+	} else {
+		sfCfg.Password = cfg.Password
+	}
+	return sfCfg, nil
+}
+
+// sessionParams maps configured session parameters onto gosnowflake params,
+// upper-casing names and tagging queries with snow9s unless QUERY_TAG is set.
+// A configured timezone becomes the session TIMEZONE unless set explicitly.
+func sessionParams(values map[string]string, timezone string) map[string]*string {
+	params := make(map[string]*string, len(values)+2)
+	for name, value := range values {
+		v := value
+		params[strings.ToUpper(name)] = &v
+	}
+	if _, ok := params["QUERY_TAG"]; !ok {
+		tag := defaultQueryTag
+		params["QUERY_TAG"] = &tag
+	}
+	if _, ok := params["TIMEZONE"]; !ok && timezone != "" {
+		tz := timezone
+		params["TIMEZONE"] = &tz
+	}
+	return params
+}
+
+// privateKeyPEM returns the configured key, preferring the inline
+// private_key over private_key_path.
+func privateKeyPEM(cfg config.Config) ([]byte, error) {
+	if cfg.PrivateKey != "" {
+		return decodeInlineKey(cfg.PrivateKey)
+	}
+	keyBytes, err := os.ReadFile(cfg.PrivateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("read private key: %w", err)
+	}
+	return keyBytes, nil
+}
+
+// decodeInlineKey accepts a PEM key, with real or escaped (\n) newlines as env
+// vars often carry, or the base64 encoding of one.
+func decodeInlineKey(value string) ([]byte, error) {
+	value = strings.TrimSpace(value)
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(strings.ReplaceAll(value, `\n`, "\n")), nil
+	}
+	compact := strings.Join(strings.Fields(value), "")
+	decoded, err := base64.StdEncoding.DecodeString(compact)
+	if err != nil {
+		return nil, fmt.Errorf("private_key is neither PEM nor base64: %w", err)
+	}
+	if !bytes.Contains(decoded, []byte("-----BEGIN")) {
+		return nil, fmt.Errorf("private_key is base64 but does not decode to a PEM key")
+	}
+	return decoded, nil
+}
+
+// parseRSAPrivateKey reads a PKCS#8 or PKCS#1 PEM key, decrypting encrypted
+// PKCS#8 keys with passphrase. Decryption failures wrap ErrWrongPassphrase or
+// ErrPassphraseRequired so they are distinguishable from unparseable keys.
+func parseRSAPrivateKey(data []byte, passphrase string) (*rsa.PrivateKey, error) {
 	block, _ := pem.Decode(data)
 	if block == nil {
 		return nil, fmt.Errorf("no PEM data found")
 	}
-	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+	der := block.Bytes
+	if block.Type == "ENCRYPTED PRIVATE KEY" {
+		if passphrase == "" {
+			return nil, ErrPassphraseRequired
+		}
+		plain, err := decryptPKCS8(block.Bytes, passphrase)
+		if err != nil {
+			return nil, err
+		}
+		key, err := x509.ParsePKCS8PrivateKey(plain)
+		if err != nil {
+			// Valid-looking padding from a wrong passphrase still yields garbage.
+			return nil, ErrWrongPassphrase
+		}
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("unexpected private key type %T", key)
+		}
+		return rsaKey, nil
+	}
+	if _, encrypted := block.Headers["DEK-Info"]; encrypted {
+		return nil, fmt.Errorf("legacy encrypted PEM keys are not supported; convert with openssl pkcs8 -topk8 -v2 aes-256-cbc")
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
 		if rsaKey, ok := key.(*rsa.PrivateKey); ok {
 			return rsaKey, nil
 		}
 		return nil, fmt.Errorf("unexpected private key type %T", key)
 	}
-	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
 		return key, nil
 	}
 	return nil, fmt.Errorf("unable to parse private key")
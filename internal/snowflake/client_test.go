@@ -0,0 +1,459 @@
+package snowflake
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/marcelinojackson-org/snow9s/internal/config"
+	"github.com/snowflakedb/gosnowflake"
+)
+
+func TestRedactedDSNHidesPassword(t *testing.T) {
+	cfg := config.Config{Account: "acct", User: "user", Password: "s3cr3t!", Database: "DB", Schema: "PUBLIC"}
+	dsn, err := RedactedDSN(cfg)
+	if err != nil {
+		t.Fatalf("RedactedDSN: %v", err)
+	}
+	if strings.Contains(dsn, "s3cr3t") {
+		t.Fatalf("password leaked in DSN: %s", dsn)
+	}
+	if !strings.Contains(dsn, redacted) || !strings.Contains(dsn, "acct") {
+		t.Fatalf("unexpected DSN: %s", dsn)
+	}
+}
+
+func TestBuildSnowflakeConfigAuthenticator(t *testing.T) {
+	cfg := config.Config{Account: "acct", User: "user", Authenticator: "externalbrowser"}
+	sfCfg, err := buildSnowflakeConfig(cfg)
+	if err != nil {
+		t.Fatalf("buildSnowflakeConfig: %v", err)
+	}
+	if sfCfg.Authenticator != gosnowflake.AuthTypeExternalBrowser || sfCfg.Password != "" {
+		t.Fatalf("expected external browser auth without password got %+v", sfCfg)
+	}
+
+	cfg = config.Config{Account: "acct", User: "user", Authenticator: "oauth", Token: "secret-token"}
+	dsn, err := RedactedDSN(cfg)
+	if err != nil {
+		t.Fatalf("RedactedDSN: %v", err)
+	}
+	if strings.Contains(dsn, "secret-token") || !strings.Contains(dsn, "authenticator=oauth") {
+		t.Fatalf("unexpected oauth DSN: %s", dsn)
+	}
+}
+
+func TestRedactedDSNHidesPrivateKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPath := filepath.Join(t.TempDir(), "key.p8")
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Config{Account: "acct", User: "user", PrivateKeyPath: keyPath}
+	dsn, err := RedactedDSN(cfg)
+	if err != nil {
+		t.Fatalf("RedactedDSN: %v", err)
+	}
+	if !strings.Contains(dsn, "privateKey="+redacted) {
+		t.Fatalf("private key not marked as redacted: %s", dsn)
+	}
+	if strings.Count(dsn, "privateKey=") != 1 {
+		t.Fatalf("private key material leaked in DSN: %s", dsn)
+	}
+}
+
+func TestInlinePrivateKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemKey := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	for name, value := range map[string]string{
+		"pem":         pemKey,
+		"escaped pem": strings.ReplaceAll(pemKey, "\n", `\n`),
+		"base64":      base64.StdEncoding.EncodeToString([]byte(pemKey)),
+	} {
+		// The inline key wins over a path that does not exist.
+		cfg := config.Config{Account: "acct", User: "user", PrivateKey: value, PrivateKeyPath: "/missing/key.p8"}
+		sfCfg, err := buildSnowflakeConfig(cfg)
+		if err != nil {
+			t.Fatalf("%s: buildSnowflakeConfig: %v", name, err)
+		}
+		if sfCfg.Authenticator != gosnowflake.AuthTypeJwt || !key.Equal(sfCfg.PrivateKey) {
+			t.Fatalf("%s: expected key pair auth with the inline key", name)
+		}
+	}
+
+	if _, err := buildSnowflakeConfig(config.Config{Account: "acct", User: "user", PrivateKey: "not a key!"}); err == nil || !strings.Contains(err.Error(), "neither PEM nor base64") {
+		t.Fatalf("expected a decode error got %v", err)
+	}
+	if _, err := buildSnowflakeConfig(config.Config{Account: "acct", User: "user", PrivateKey: base64.StdEncoding.EncodeToString(der)}); err == nil {
+		t.Fatalf("expected base64 that is not PEM to fail")
+	}
+}
+
+func TestRoleInDSN(t *testing.T) {
+	cfg := config.Config{Account: "acct", User: "user", Password: "pw", Role: "SPCS_OPERATOR"}
+	dsn, err := RedactedDSN(cfg)
+	if err != nil {
+		t.Fatalf("RedactedDSN: %v", err)
+	}
+	if !strings.Contains(dsn, "role=SPCS_OPERATOR") {
+		t.Fatalf("role missing from DSN: %s", dsn)
+	}
+}
+
+func TestSessionParamsInDSN(t *testing.T) {
+	cfg := config.Config{Account: "acct", User: "user", Password: "pw", SessionParams: map[string]string{"statement_timeout_in_seconds": "60"}}
+	dsn, err := RedactedDSN(cfg)
+	if err != nil {
+		t.Fatalf("RedactedDSN: %v", err)
+	}
+	if !strings.Contains(dsn, "STATEMENT_TIMEOUT_IN_SECONDS=60") || !strings.Contains(dsn, "QUERY_TAG=snow9s") {
+		t.Fatalf("session params missing from DSN: %s", dsn)
+	}
+
+	cfg.SessionParams = map[string]string{"QUERY_TAG": "ops"}
+	dsn, err = RedactedDSN(cfg)
+	if err != nil {
+		t.Fatalf("RedactedDSN: %v", err)
+	}
+	if !strings.Contains(dsn, "QUERY_TAG=ops") || strings.Contains(dsn, "QUERY_TAG=snow9s") {
+		t.Fatalf("custom query tag not honored: %s", dsn)
+	}
+}
+
+func TestSessionLocationFromOffset(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	mock.ExpectQuery("SELECT TO_VARCHAR\\(CURRENT_TIMESTAMP\\(\\), 'TZH:TZM'\\)").
+		WillReturnRows(sqlmock.NewRows([]string{"offset"}).AddRow("-07:00"))
+
+	loc, err := sessionLocation(context.Background(), db, "")
+	if err != nil {
+		t.Fatalf("sessionLocation: %v", err)
+	}
+	if _, offset := time.Date(2024, 1, 1, 0, 0, 0, 0, loc).Zone(); offset != -7*60*60 {
+		t.Fatalf("expected -07:00 offset got %d seconds", offset)
+	}
+
+	loc, err = sessionLocation(context.Background(), db, "UTC")
+	if err != nil || loc != time.UTC {
+		t.Fatalf("configured timezone not honored: %v %v", loc, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestQueryErrorDetailsAndRedact(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	mock.ExpectQuery("SHOW COMPUTE POOLS").WillReturnError(&gosnowflake.SnowflakeError{Number: 2003, QueryID: "01ab-cd", Message: "does not exist"})
+
+	_, err = NewSPCS(db, config.Config{}).ListComputePools(context.Background())
+	query, queryID := ErrorDetails(err)
+	if query != "SHOW COMPUTE POOLS" || queryID != "01ab-cd" {
+		t.Fatalf("unexpected details query=%q id=%q", query, queryID)
+	}
+
+	cfg := config.Config{Password: "hunter2"}
+	got := Redact(cfg, "login failed for hunter2 via user:pw@host?password=abc&privateKey=xyz")
+	if strings.Contains(got, "hunter2") || strings.Contains(got, "abc") || strings.Contains(got, "xyz") {
+		t.Fatalf("secrets not redacted: %s", got)
+	}
+}
+
+func TestHint(t *testing.T) {
+	cases := []struct {
+		err  error
+		want string
+	}{
+		{&gosnowflake.SnowflakeError{Number: 390100}, "Authentication failed"},
+		{fmt.Errorf("query services: %w", &QueryError{Query: "SHOW SERVICES", Err: &gosnowflake.SnowflakeError{Number: 2003}}), "does not exist"},
+		{&gosnowflake.SnowflakeError{Number: 604}, "cancelled"},
+		{&gosnowflake.SnowflakeError{Number: 606}, "--warehouse"},
+		{&gosnowflake.SnowflakeError{Number: 1, Message: "Warehouse 'WH' is suspended."}, "auto_resume_warehouse"},
+		{&gosnowflake.SnowflakeError{Number: 999999, SQLState: "08001"}, "Could not reach"},
+		{fmt.Errorf("ping: %w", context.DeadlineExceeded), "Timed out"},
+		{&net.DNSError{Err: "no such host", Name: "acct.snowflakecomputing.com"}, "account identifier"},
+		{&gosnowflake.SnowflakeError{Number: 1003}, ""},
+		{context.Canceled, ""},
+		{nil, ""},
+	}
+	for _, c := range cases {
+		got := Hint(c.err)
+		if (c.want == "") != (got == "") || !strings.Contains(got, c.want) {
+			t.Fatalf("Hint(%v) = %q, want it to contain %q", c.err, got, c.want)
+		}
+	}
+}
+
+func TestIsConnectionError(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, true},
+		{fmt.Errorf("query services: %w", driver.ErrBadConn), true},
+		{&gosnowflake.SnowflakeError{Number: gosnowflake.ErrCodeFailedToConnect}, true},
+		{&gosnowflake.SnowflakeError{Number: 390114, Message: "Authentication token has expired."}, true},
+		{&gosnowflake.SnowflakeError{Number: 999999, SQLState: "08001"}, true},
+		{&gosnowflake.SnowflakeError{Number: 2003, SQLState: "02000"}, false},
+		{fmt.Errorf("ping: %w", context.DeadlineExceeded), false},
+		{context.Canceled, false},
+		{errors.New(`sql: Scan error on column index 2, name "created_on"`), false},
+		{nil, false},
+	}
+	for _, c := range cases {
+		if got := IsConnectionError(c.err); got != c.want {
+			t.Fatalf("IsConnectionError(%v) = %v, want %v", c.err, got, c.want)
+		}
+	}
+}
+
+func TestReconnectPings(t *testing.T) {
+	// Reconnect closes the pooled sessions, so the client gets its own pool
+	// on a named mock that can open a fresh connection for the ping.
+	dsn := "sqlmock_" + t.Name()
+	mockDB, mock, err := sqlmock.NewWithDSN(dsn, sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer mockDB.Close()
+	db, err := sql.Open("sqlmock", dsn)
+	if err != nil {
+		t.Fatalf("open %s: %v", dsn, err)
+	}
+	defer db.Close()
+	client := &Client{db: db, logger: log.New(io.Discard, "", 0)}
+	spcs := NewSPCS(client, config.Config{})
+
+	// Leave one idle session in the pool for Reconnect to discard.
+	mock.ExpectPing()
+	if err := db.Ping(); err != nil {
+		t.Fatalf("ping: %v", err)
+	}
+	mock.ExpectPing()
+	if err := spcs.Reconnect(context.Background()); err != nil {
+		t.Fatalf("reconnect: %v", err)
+	}
+	if closed := db.Stats().MaxIdleClosed; closed != 1 {
+		t.Fatalf("expected the idle session to be discarded, %d were", closed)
+	}
+	mock.ExpectPing().WillReturnError(driver.ErrBadConn)
+	if err := spcs.Reconnect(context.Background()); !IsConnectionError(err) {
+		t.Fatalf("expected the ping error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestQueryRetriesTransientErrors(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	var logs strings.Builder
+	client := &Client{db: db, debug: true, logger: log.New(&logs, "", 0), maxRetries: 2, retryBase: time.Millisecond}
+
+	mock.ExpectQuery("SHOW SERVICES").WillReturnError(&gosnowflake.SnowflakeError{Number: gosnowflake.ErrFailedToPostQuery})
+	mock.ExpectQuery("SHOW SERVICES").WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("api"))
+	rows, err := client.Query(context.Background(), "SHOW SERVICES")
+	if err != nil {
+		t.Fatalf("expected transient error to be retried: %v", err)
+	}
+	rows.Close()
+	if !strings.Contains(logs.String(), "retry 1/2") {
+		t.Fatalf("expected retry to be logged, got %q", logs.String())
+	}
+
+	// Statement errors fail on the first attempt.
+	mock.ExpectQuery("SHOW SERVICES").WillReturnError(&gosnowflake.SnowflakeError{Number: 2003, Message: "does not exist"})
+	if _, err := client.Query(context.Background(), "SHOW SERVICES"); err == nil {
+		t.Fatal("expected statement error to be returned")
+	}
+
+	// Retries stop after maxRetries.
+	for i := 0; i < 3; i++ {
+		mock.ExpectQuery("SHOW SERVICES").WillReturnError(&gosnowflake.SnowflakeError{Number: gosnowflake.ErrCodeServiceUnavailable})
+	}
+	if _, err := client.Query(context.Background(), "SHOW SERVICES"); err == nil {
+		t.Fatal("expected error after exhausting retries")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestQueryResumesSuspendedWarehouse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	client := &Client{db: db, logger: log.New(io.Discard, "", 0), autoResume: true, warehouse: "WH"}
+	noWarehouse := &gosnowflake.SnowflakeError{Number: 606, Message: "No active warehouse selected in the current session."}
+	warehouses := func(state string) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"name", "state"}).AddRow("WH_OLD", "SUSPENDED").AddRow("WH", state)
+	}
+
+	// A message naming a suspended warehouse is resumed straight away.
+	mock.ExpectQuery("SHOW SERVICES").WillReturnError(&gosnowflake.SnowflakeError{Number: 1, Message: "Warehouse 'WH' is suspended."})
+	mock.ExpectExec(`ALTER WAREHOUSE WH RESUME IF SUSPENDED`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SHOW SERVICES").WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("api"))
+	rows, err := client.Query(context.Background(), "SHOW SERVICES")
+	if err != nil {
+		t.Fatalf("expected query to succeed after resuming: %v", err)
+	}
+	rows.Close()
+
+	// "No active warehouse" is only resumed once the warehouse is suspended.
+	mock.ExpectQuery("SHOW SERVICES").WillReturnError(noWarehouse)
+	mock.ExpectQuery(`SHOW WAREHOUSES LIKE 'WH'`).WillReturnRows(warehouses("SUSPENDED"))
+	mock.ExpectExec(`ALTER WAREHOUSE WH RESUME IF SUSPENDED`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SHOW SERVICES").WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("api"))
+	rows, err = client.Query(context.Background(), "SHOW SERVICES")
+	if err != nil {
+		t.Fatalf("expected query to succeed after resuming: %v", err)
+	}
+	rows.Close()
+
+	mock.ExpectQuery("SHOW SERVICES").WillReturnError(noWarehouse)
+	mock.ExpectQuery(`SHOW WAREHOUSES LIKE 'WH'`).WillReturnRows(warehouses("STARTED"))
+	if _, err := client.Query(context.Background(), "SHOW SERVICES"); !IsNoActiveWarehouse(err) {
+		t.Fatalf("a running warehouse must not be resumed, got %v", err)
+	}
+
+	// Without auto-resume the error is returned with its hint.
+	client.autoResume = false
+	mock.ExpectQuery("SHOW SERVICES").WillReturnError(noWarehouse)
+	_, err = client.Query(context.Background(), "SHOW SERVICES")
+	if err == nil || IsWarehouseSuspended(err) || !strings.Contains(Hint(err), "RESUME") {
+		t.Fatalf("expected the no warehouse error with its hint, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestMonitorSessionResumesMonitorWarehouse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	cfg := config.Config{Schema: "PUBLIC", Warehouse: "WH", MonitorWarehouse: "MON", AutoResumeWarehouse: true}
+	client := &Client{db: db, logger: log.New(io.Discard, "", 0), autoResume: true, warehouse: "WH"}
+
+	mock.ExpectExec("USE WAREHOUSE MON").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SHOW SERVICES").WillReturnError(&gosnowflake.SnowflakeError{Number: 1, Message: "Warehouse 'MON' is suspended."})
+	mock.ExpectExec(`ALTER WAREHOUSE MON RESUME IF SUSPENDED`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SHOW SERVICES").WillReturnRows(sqlmock.NewRows([]string{"name"}))
+	mock.ExpectExec("USE WAREHOUSE WH").WillReturnResult(sqlmock.NewResult(0, 0))
+	if _, err := NewSPCS(client, cfg).ListServices(context.Background()); err != nil {
+		t.Fatalf("ListServices: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestQueryLogsJSONWithDuration(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	var logs strings.Builder
+	client := &Client{db: db, debug: true, jsonLog: true, logger: log.New(&logs, "", 0)}
+
+	mock.ExpectQuery("SHOW SERVICES").WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("api"))
+	rows, err := client.Query(context.Background(), "SHOW SERVICES")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	rows.Close()
+
+	entry, ok := ParseLogEntry(strings.TrimSpace(logs.String()))
+	if !ok || entry.Level != LevelDebug || entry.Query != "SHOW SERVICES" || entry.DurationMS == nil || entry.Time.IsZero() {
+		t.Fatalf("expected a structured query entry, got %q", logs.String())
+	}
+	if got := entry.String(); !strings.HasPrefix(got, "SQL: SHOW SERVICES (") {
+		t.Fatalf("unexpected text rendering %q", got)
+	}
+	if _, ok := ParseLogEntry("snow9s SQL: SHOW SERVICES"); ok {
+		t.Fatal("text lines should not parse as entries")
+	}
+}
+
+func TestQueryRecordsSlowQueries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	client := &Client{db: db, logger: log.New(io.Discard, "", 0), slowQuery: time.Millisecond}
+	if _, ok := client.LastQuery(); ok {
+		t.Fatal("expected no timing before the first query")
+	}
+
+	mock.ExpectQuery("SHOW SERVICES").WillDelayFor(5 * time.Millisecond).WillReturnRows(sqlmock.NewRows([]string{"name"}))
+	rows, err := client.Query(context.Background(), "SHOW SERVICES")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	rows.Close()
+	timing, ok := client.LastQuery()
+	if !ok || !timing.Slow || timing.Elapsed < 5*time.Millisecond || !strings.HasPrefix(timing.String(), "slow query: SHOW SERVICES took ") {
+		t.Fatalf("expected a slow timing, got %+v", timing)
+	}
+
+	client.slowQuery = 0
+	mock.ExpectQuery("SHOW COMPUTE POOLS").WillDelayFor(5 * time.Millisecond).WillReturnRows(sqlmock.NewRows([]string{"name"}))
+	rows, err = client.Query(context.Background(), "SHOW COMPUTE POOLS")
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	rows.Close()
+	if timing, _ := client.LastQuery(); timing.Slow || timing.Query != "SHOW COMPUTE POOLS" {
+		t.Fatalf("a zero threshold should disable the warning, got %+v", timing)
+	}
+}
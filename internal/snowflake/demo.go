@@ -0,0 +1,329 @@
+package snowflake
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/marcelinojackson-org/snow9s/internal/config"
+)
+
+// Connection settings shown in demo mode when the config leaves them empty.
+const (
+	DemoAccount   = "demo-account"
+	DemoUser      = "DEMO_USER"
+	DemoDatabase  = "DEMO_DB"
+	DemoSchema    = "PUBLIC"
+	DemoWarehouse = "DEMO_WH"
+)
+
+// DemoConfig fills the connection settings a demo session displays, keeping
+// any the user set.
+func DemoConfig(cfg config.Config) config.Config {
+	cfg.Account = fallback(cfg.Account, DemoAccount)
+	cfg.User = fallback(cfg.User, DemoUser)
+	cfg.Database = fallback(cfg.Database, DemoDatabase)
+	cfg.Schema = fallback(cfg.Schema, DemoSchema)
+	cfg.Warehouse = fallback(cfg.Warehouse, DemoWarehouse)
+	return cfg
+}
+
+// NewDemoClient returns a Client backed by a canned, deterministic dataset
+// instead of Snowflake, for demos, screenshots, and UI development without
+// credentials. Reads return the dataset with ages relative to now; actions
+// succeed without changing it.
+func NewDemoClient(cfg config.Config, logger *log.Logger) *Client {
+	if logger == nil {
+		logger = log.New(io.Discard, "", 0)
+	}
+	return &Client{
+		db:        sql.OpenDB(demoConnector{}),
+		debug:     cfg.Debug,
+		jsonLog:   cfg.JSONLog(),
+		logger:    logger,
+		location:  time.UTC,
+		retryBase: queryRetryBase,
+		slowQuery: time.Duration(cfg.SlowQueryThreshold) * time.Second,
+	}
+}
+
+// demoService is one service of the demo dataset.
+type demoService struct {
+	name      string
+	status    string
+	pool      string
+	owner     string
+	instances int
+	age       time.Duration
+	public    bool
+	comment   string
+}
+
+var demoServices = []demoService{
+	{name: "API_GATEWAY", status: "RUNNING", pool: "CPU_POOL", owner: "SPCS_ADMIN", instances: 2, age: 12 * 24 * time.Hour, public: true, comment: "Public REST entrypoint"},
+	{name: "ML_INFERENCE", status: "RUNNING", pool: "GPU_POOL", owner: "ML_ENGINEER", instances: 1, age: 3 * 24 * time.Hour},
+	{name: "FEATURE_STORE", status: "PENDING", pool: "CPU_POOL", owner: "DATA_ENGINEER", instances: 1, age: 2 * time.Hour},
+	{name: "REPORTING_UI", status: "FAILED", pool: "CPU_POOL", owner: "ANALYST", instances: 1, age: 45 * time.Minute, public: true},
+	{name: "ETL_WORKER", status: "SUSPENDED", pool: "BATCH_POOL", owner: "DATA_ENGINEER", instances: 1, age: 40 * 24 * time.Hour},
+}
+
+// demoPool is one compute pool of the demo dataset.
+type demoPool struct {
+	name     string
+	state    string
+	family   string
+	minNodes int
+	maxNodes int
+	active   int
+	idle     int
+	age      time.Duration
+}
+
+var demoPools = []demoPool{
+	{name: "CPU_POOL", state: "ACTIVE", family: "CPU_X64_S", minNodes: 1, maxNodes: 3, active: 2, idle: 0, age: 30 * 24 * time.Hour},
+	{name: "GPU_POOL", state: "ACTIVE", family: "GPU_NV_S", minNodes: 1, maxNodes: 2, active: 1, idle: 1, age: 7 * 24 * time.Hour},
+	{name: "BATCH_POOL", state: "SUSPENDED", family: "CPU_X64_XS", minNodes: 1, maxNodes: 1, active: 0, idle: 0, age: 60 * 24 * time.Hour},
+}
+
+// demoConnector serves the demo dataset through database/sql so the demo
+// client runs the same query and scan code as a real connection.
+type demoConnector struct{}
+
+func (demoConnector) Connect(context.Context) (driver.Conn, error) { return demoConn{}, nil }
+func (demoConnector) Driver() driver.Driver                        { return demoDriver{} }
+
+type demoDriver struct{}
+
+func (demoDriver) Open(string) (driver.Conn, error) { return demoConn{}, nil }
+
+type demoConn struct{}
+
+func (demoConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("demo mode does not support prepared statements")
+}
+
+func (demoConn) Close() error { return nil }
+
+func (demoConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("demo mode does not support transactions")
+}
+
+func (demoConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(0), nil
+}
+
+func (demoConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	rows, ok := demoQuery(query, time.Now())
+	if !ok {
+		return nil, fmt.Errorf("demo mode has no data for %q", query)
+	}
+	return rows, nil
+}
+
+// demoRows is a canned result set.
+type demoRows struct {
+	cols []string
+	rows [][]driver.Value
+	next int
+}
+
+func (r *demoRows) Columns() []string { return r.cols }
+func (r *demoRows) Close() error      { return nil }
+
+func (r *demoRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.next])
+	r.next++
+	return nil
+}
+
+func (r *demoRows) add(values ...driver.Value) {
+	r.rows = append(r.rows, values)
+}
+
+// quotedIdents finds the double-quoted identifiers in a statement.
+var quotedIdents = regexp.MustCompile(`"((?:[^"]|"")*)"`)
+
+// lastIdent returns the last quoted identifier in query, which names the
+// object SHOW ... IN SERVICE, DESCRIBE, and the SYSTEM$ calls act on.
+func lastIdent(query string) string {
+	matches := quotedIdents.FindAllStringSubmatch(query, -1)
+	if len(matches) == 0 {
+		return ""
+	}
+	return strings.ReplaceAll(matches[len(matches)-1][1], `""`, `"`)
+}
+
+// demoQuery answers query from the demo dataset, reporting false for
+// statements it has no data for.
+func demoQuery(query string, now time.Time) (*demoRows, bool) {
+	upper := strings.ToUpper(strings.TrimSpace(query))
+	name := lastIdent(query)
+	stamp := func(age time.Duration) driver.Value {
+		return now.Add(-age).UTC().Format("2006-01-02 15:04:05.000 -0700")
+	}
+	switch {
+	case strings.HasPrefix(upper, "SHOW JOB SERVICES"):
+		rows := &demoRows{cols: []string{"name", "schema_name", "status", "compute_pool", "created_on", "updated_on"}}
+		rows.add("NIGHTLY_TRAIN", DemoSchema, "DONE", "GPU_POOL", stamp(26*time.Hour), stamp(20*time.Hour))
+		rows.add("BACKFILL_2024", DemoSchema, "RUNNING", "BATCH_POOL", stamp(90*time.Minute), stamp(time.Minute))
+		return rows, true
+	case strings.HasPrefix(upper, "SHOW SERVICES"):
+		rows := &demoRows{cols: []string{"name", "database_name", "schema_name", "owner", "compute_pool", "dns_name", "status", "min_instances", "max_instances", "spec_digest", "comment", "created_on"}}
+		for _, svc := range demoServices {
+			rows.add(svc.name, DemoDatabase, DemoSchema, svc.owner, svc.pool, demoDNSName(svc.name), svc.status,
+				strconv.Itoa(svc.instances), strconv.Itoa(svc.instances+1), demoDigest(svc.name), svc.comment, stamp(svc.age))
+		}
+		return rows, true
+	case strings.HasPrefix(upper, "SHOW SERVICE INSTANCES"):
+		rows := &demoRows{cols: []string{"service_name", "instance_id", "status", "node", "created_on"}}
+		if svc, ok := findDemoService(name); ok && svc.status != "SUSPENDED" {
+			for i := 0; i < svc.instances; i++ {
+				rows.add(svc.name, strconv.Itoa(i), demoInstanceStatus(svc.status), fmt.Sprintf("%s-node-%d", strings.ToLower(svc.pool), i), stamp(svc.age))
+			}
+		}
+		return rows, true
+	case strings.HasPrefix(upper, "SHOW COMPUTE POOLS"), strings.HasPrefix(upper, "DESCRIBE COMPUTE POOL"):
+		describe := strings.HasPrefix(upper, "DESCRIBE")
+		rows := &demoRows{cols: []string{"name", "state", "min_nodes", "max_nodes", "instance_family", "num_services", "num_jobs", "auto_suspend_secs", "auto_resume", "active_nodes", "idle_nodes", "target_nodes", "owner", "created_on"}}
+		for _, pool := range demoPools {
+			if describe && !strings.EqualFold(pool.name, name) {
+				continue
+			}
+			services := 0
+			for _, svc := range demoServices {
+				if svc.pool == pool.name {
+					services++
+				}
+			}
+			rows.add(pool.name, pool.state, strconv.Itoa(pool.minNodes), strconv.Itoa(pool.maxNodes), pool.family,
+				strconv.Itoa(services), "0", "3600", "true", strconv.Itoa(pool.active), strconv.Itoa(pool.idle),
+				strconv.Itoa(pool.active), "SPCS_ADMIN", stamp(pool.age))
+		}
+		return rows, true
+	case strings.HasPrefix(upper, "SHOW IMAGE REPOSITORIES"):
+		rows := &demoRows{cols: []string{"name", "database_name", "schema_name", "repository_url", "owner", "created_on"}}
+		rows.add("APP_IMAGES", DemoDatabase, DemoSchema, DemoAccount+".registry.snowflakecomputing.com/demo_db/public/app_images", "SPCS_ADMIN", stamp(90*24*time.Hour))
+		return rows, true
+	case strings.HasPrefix(upper, "SHOW IMAGES"):
+		rows := &demoRows{cols: []string{"image_name", "tags", "digest", "image_path", "created_on"}}
+		rows.add("api_gateway", `["v1.4.2","latest"]`, demoDigest("api_gateway"), "demo_db/public/app_images/api_gateway", stamp(12*24*time.Hour))
+		rows.add("ml_inference", `["2024.06"]`, demoDigest("ml_inference"), "demo_db/public/app_images/ml_inference", stamp(3*24*time.Hour))
+		return rows, true
+	case strings.HasPrefix(upper, "SHOW DATABASES"):
+		rows := &demoRows{cols: []string{"name", "owner", "created_on"}}
+		rows.add(DemoDatabase, "SYSADMIN", stamp(365*24*time.Hour))
+		return rows, true
+	case strings.HasPrefix(upper, "SHOW SCHEMAS"):
+		rows := &demoRows{cols: []string{"name", "database_name", "owner", "created_on"}}
+		rows.add(DemoSchema, DemoDatabase, "SYSADMIN", stamp(365*24*time.Hour))
+		rows.add("STAGING", DemoDatabase, "DATA_ENGINEER", stamp(100*24*time.Hour))
+		return rows, true
+	case strings.HasPrefix(upper, "SHOW ENDPOINTS"):
+		rows := &demoRows{cols: []string{"name", "port", "protocol", "is_public", "ingress_url"}}
+		if svc, ok := findDemoService(name); ok {
+			ingress := any(nil)
+			if svc.public {
+				ingress = strings.ToLower(strings.ReplaceAll(svc.name, "_", "-")) + "-" + DemoAccount + ".snowflakecomputing.app"
+			}
+			rows.add("http", "8080", "HTTP", strconv.FormatBool(svc.public), ingress)
+		}
+		return rows, true
+	case strings.HasPrefix(upper, "DESCRIBE SERVICE"):
+		rows := &demoRows{cols: []string{"name", "status", "compute_pool", "spec"}}
+		if svc, ok := findDemoService(name); ok {
+			rows.add(svc.name, svc.status, svc.pool, demoSpec(svc))
+		}
+		return rows, true
+	case strings.HasPrefix(upper, "SELECT SYSTEM$GET_SERVICE_STATUS"):
+		return &demoRows{cols: []string{"status"}, rows: [][]driver.Value{{demoServiceStatus(name, now)}}}, true
+	case strings.HasPrefix(upper, "SELECT SYSTEM$GET_SERVICE_LOGS"):
+		return &demoRows{cols: []string{"logs"}, rows: [][]driver.Value{{demoLogs(name)}}}, true
+	case strings.HasPrefix(upper, "ALTER "), strings.HasPrefix(upper, "DROP "), strings.HasPrefix(upper, "USE "):
+		return &demoRows{cols: []string{"status"}, rows: [][]driver.Value{{"Statement executed successfully."}}}, true
+	}
+	return nil, false
+}
+
+func findDemoService(name string) (demoService, bool) {
+	for _, svc := range demoServices {
+		if strings.EqualFold(svc.name, name) {
+			return svc, true
+		}
+	}
+	return demoService{}, false
+}
+
+func demoDNSName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", "-")) + ".demo.svc.spcs.internal"
+}
+
+// demoDigest derives a stable fake image digest from name.
+func demoDigest(name string) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(name)))
+}
+
+func demoInstanceStatus(serviceStatus string) string {
+	if serviceStatus == "RUNNING" {
+		return "READY"
+	}
+	return serviceStatus
+}
+
+func demoSpec(svc demoService) string {
+	image := "/demo_db/public/app_images/" + strings.ToLower(svc.name) + ":latest"
+	spec := "spec:\n  containers:\n  - name: main\n    image: " + image + "\n    env:\n      LOG_LEVEL: info\n"
+	spec += "  endpoints:\n  - name: http\n    port: 8080\n    public: " + strconv.FormatBool(svc.public) + "\n"
+	return spec
+}
+
+// demoServiceStatus renders SYSTEM$GET_SERVICE_STATUS for a demo service:
+// one "main" container per instance, none while suspended.
+func demoServiceStatus(name string, now time.Time) string {
+	entries := []serviceStatusEntry{}
+	svc, ok := findDemoService(name)
+	if ok && svc.status != "SUSPENDED" {
+		status, message, restarts := "READY", "Running", 0
+		switch svc.status {
+		case "PENDING":
+			status, message = "PENDING", "Waiting for compute pool capacity"
+		case "FAILED":
+			status, message, restarts = "FAILED", "Container exited with code 1: missing env REPORT_BUCKET", 5
+		}
+		for i := 0; i < svc.instances; i++ {
+			entries = append(entries, serviceStatusEntry{
+				Status:        status,
+				Message:       message,
+				ContainerName: "main",
+				InstanceID:    strconv.Itoa(i),
+				RestartCount:  restarts,
+				StartTime:     now.Add(-svc.age).UTC().Format(time.RFC3339),
+			})
+		}
+	}
+	raw, _ := json.Marshal(entries)
+	return string(raw)
+}
+
+func demoLogs(name string) string {
+	svc, ok := findDemoService(name)
+	if !ok {
+		return ""
+	}
+	if svc.status == "FAILED" {
+		return "starting " + strings.ToLower(svc.name) + "\nloading configuration\nerror: missing env REPORT_BUCKET\n"
+	}
+	return "starting " + strings.ToLower(svc.name) + "\nlistening on :8080\nGET /healthz 200 1ms\nGET /healthz 200 1ms\n"
+}
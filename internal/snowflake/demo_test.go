@@ -0,0 +1,68 @@
+package snowflake
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/marcelinojackson-org/snow9s/internal/config"
+	"github.com/marcelinojackson-org/snow9s/pkg/models"
+)
+
+func TestDemoClientServesDataset(t *testing.T) {
+	cfg := DemoConfig(config.Config{})
+	if cfg.Account != DemoAccount || cfg.Database != DemoDatabase || cfg.Schema != DemoSchema {
+		t.Fatalf("unexpected demo config %+v", cfg)
+	}
+	client := NewDemoClient(cfg, nil)
+	defer client.Close()
+	spcs := NewSPCS(client, cfg)
+	ctx := context.Background()
+
+	services, err := spcs.ListServices(ctx)
+	if err != nil {
+		t.Fatalf("ListServices: %v", err)
+	}
+	if len(services) != len(demoServices) {
+		t.Fatalf("expected %d services, got %d", len(demoServices), len(services))
+	}
+	api := services[0]
+	if api.Name != "API_GATEWAY" || api.Status != models.StatusRunning || api.Age != "1w" || api.Owner != "SPCS_ADMIN" {
+		t.Fatalf("unexpected first service %+v", api)
+	}
+	statuses := map[string]bool{}
+	for _, svc := range services {
+		statuses[svc.Status] = true
+	}
+	if len(statuses) < 4 {
+		t.Fatalf("expected varied statuses, got %v", statuses)
+	}
+
+	pools, err := spcs.ListComputePools(ctx)
+	if err != nil || len(pools) != len(demoPools) || pools[0].ActiveNodeCount != 2 {
+		t.Fatalf("unexpected pools %+v: %v", pools, err)
+	}
+	detail, err := spcs.GetComputePoolDetail(ctx, "GPU_POOL")
+	if err != nil || detail.Name != "GPU_POOL" || detail.NumServices != 1 {
+		t.Fatalf("unexpected pool detail %+v: %v", detail, err)
+	}
+
+	instances, err := spcs.ListServiceInstances(ctx, "API_GATEWAY")
+	if err != nil || len(instances) != 2 {
+		t.Fatalf("expected 2 instances, got %+v: %v", instances, err)
+	}
+	events, err := spcs.GetServiceEvents(ctx, "REPORTING_UI")
+	if err != nil || len(events) != 1 || events[0].Severity != models.SeverityError {
+		t.Fatalf("expected a failed container event, got %+v: %v", events, err)
+	}
+	spec, err := spcs.GetServiceSpec(ctx, "ML_INFERENCE")
+	if err != nil || !strings.Contains(spec, "ml_inference:latest") {
+		t.Fatalf("unexpected spec %q: %v", spec, err)
+	}
+	if err := spcs.SuspendService(ctx, "API_GATEWAY"); err != nil {
+		t.Fatalf("actions should succeed in demo mode: %v", err)
+	}
+	if _, err := spcs.RunQuery(ctx, "SELECT 1"); err == nil {
+		t.Fatal("expected statements without demo data to fail")
+	}
+}
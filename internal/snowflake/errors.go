@@ -0,0 +1,169 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/marcelinojackson-org/snow9s/internal/config"
+	"github.com/snowflakedb/gosnowflake"
+)
+
+// ErrNotFound reports that a requested resource does not exist in the
+// resolved database and schema.
+var ErrNotFound = errors.New("not found")
+
+// QueryError records the statement that failed alongside the driver error.
+type QueryError struct {
+	Query string
+	Err   error
+}
+
+func (e *QueryError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *QueryError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorDetails extracts the failing SQL and the Snowflake query ID from err,
+// returning empty strings for whatever is unavailable.
+func ErrorDetails(err error) (query, queryID string) {
+	var qe *QueryError
+	if errors.As(err, &qe) {
+		query = qe.Query
+	}
+	var sfErr *gosnowflake.SnowflakeError
+	if errors.As(err, &sfErr) {
+		queryID = sfErr.QueryID
+	}
+	return query, queryID
+}
+
+// Snowflake error numbers for a session that can no longer be used.
+const (
+	errSessionGone       = 390111
+	errSessionExpired    = 390112
+	errAuthTokenExpired  = 390114
+	errOAuthTokenExpired = 390318
+)
+
+// IsConnectionError reports whether err means Snowflake could not be reached
+// or the session is no longer valid, as opposed to a statement it rejected,
+// a cancelled or timed-out request, or a result that failed to scan.
+func IsConnectionError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var sfErr *gosnowflake.SnowflakeError
+	if errors.As(err, &sfErr) {
+		switch sfErr.Number {
+		case errSessionGone, errSessionExpired, errAuthTokenExpired, errOAuthTokenExpired,
+			gosnowflake.ErrCodeServiceUnavailable, gosnowflake.ErrCodeFailedToConnect:
+			return true
+		}
+		return strings.HasPrefix(sfErr.SQLState, "08")
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// IsWarehouseSuspended reports whether err says the query's warehouse is
+// suspended.
+func IsWarehouseSuspended(err error) bool {
+	var sfErr *gosnowflake.SnowflakeError
+	if !errors.As(err, &sfErr) {
+		return false
+	}
+	msg := strings.ToLower(sfErr.Message)
+	return strings.Contains(msg, "warehouse") && strings.Contains(msg, "suspended")
+}
+
+// IsNoActiveWarehouse reports whether err says the session has no usable
+// warehouse. Snowflake reports this for a warehouse that is not set, that the
+// role may not use, or that is suspended and may not resume itself.
+func IsNoActiveWarehouse(err error) bool {
+	var sfErr *gosnowflake.SnowflakeError
+	return errors.As(err, &sfErr) && sfErr.Number == errNoWarehouse
+}
+
+// Snowflake error numbers with a known remedy.
+const (
+	errQueryCancelled = 604
+	errNoWarehouse    = 606
+	errObjectNotFound = 2003
+	errNoPrivileges   = 3001
+	errAuthFailed     = 390100
+	errJWTInvalid     = 390144
+)
+
+const (
+	unreachableHint = "Could not reach Snowflake — check the account identifier (snow9s config dsn), network, and proxy"
+	timeoutHint     = "Timed out waiting for Snowflake — check the network, or retry once the warehouse has resumed"
+	warehouseHint   = "Warehouse not set or suspended — resume it with ALTER WAREHOUSE <name> RESUME (or set auto_resume_warehouse: true), and check --warehouse (and monitor_warehouse) and that the role may use it"
+)
+
+var errorHints = map[int]string{
+	errQueryCancelled:                     "Query was cancelled — it may have exceeded STATEMENT_TIMEOUT_IN_SECONDS (see session_params)",
+	errNoWarehouse:                        warehouseHint,
+	errObjectNotFound:                     "Object does not exist or is not authorized — check --database and --schema, and that the role can see it (--role)",
+	errNoPrivileges:                       "Insufficient privileges — switch to a role that owns or may operate on the object (--role)",
+	errAuthFailed:                         "Authentication failed — check the user and password (--user, SNOWFLAKE_PASSWORD) or private_key_path",
+	errJWTInvalid:                         "Key pair rejected — check that the user's RSA_PUBLIC_KEY matches private_key or private_key_path",
+	gosnowflake.ErrCodeServiceUnavailable: unreachableHint,
+	gosnowflake.ErrCodeFailedToConnect:    unreachableHint,
+}
+
+// Hint translates common Snowflake and network failures into guidance for
+// the user, returning "" when err has no better explanation than its own
+// message.
+func Hint(err error) string {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return ""
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return timeoutHint
+	}
+	if IsWarehouseSuspended(err) {
+		return warehouseHint
+	}
+	var sfErr *gosnowflake.SnowflakeError
+	if errors.As(err, &sfErr) {
+		if hint, ok := errorHints[sfErr.Number]; ok {
+			return hint
+		}
+		if strings.HasPrefix(sfErr.SQLState, "08") {
+			return unreachableHint
+		}
+		return ""
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return timeoutHint
+		}
+		return unreachableHint
+	}
+	return ""
+}
+
+var secretParamPattern = regexp.MustCompile(`(?i)(password|privatekey|token|passcode)=[^&\s]+`)
+
+// Redact masks cfg's secrets and any credential-looking key=value pairs in text.
+func Redact(cfg config.Config, text string) string {
+	for _, secret := range []string{cfg.Password, cfg.PrivateKey, cfg.PrivateKeyPassphrase, cfg.Token} {
+		if secret != "" {
+			text = strings.ReplaceAll(text, secret, redacted)
+		}
+	}
+	return secretParamPattern.ReplaceAllString(text, "${1}="+redacted)
+}
@@ -0,0 +1,92 @@
+package snowflake
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Log levels of debug entries.
+const (
+	LevelDebug = "debug"
+	LevelWarn  = "warn"
+	LevelError = "error"
+)
+
+// LogEntry is one debug log line. With log_format json it is written as a
+// JSON object; otherwise its String form goes through the logger as text.
+type LogEntry struct {
+	Time       time.Time `json:"timestamp"`
+	Level      string    `json:"level"`
+	Msg        string    `json:"msg"`
+	Query      string    `json:"query,omitempty"`
+	DurationMS *int64    `json:"duration_ms,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// String renders the entry the way the text log format prints it.
+func (e LogEntry) String() string {
+	if e.Query == "" {
+		return e.Msg
+	}
+	line := "SQL: " + e.Query
+	if e.DurationMS != nil {
+		line += fmt.Sprintf(" (%dms)", *e.DurationMS)
+	}
+	if e.Error != "" {
+		line += ": " + e.Error
+	}
+	return line
+}
+
+// ParseLogEntry decodes a line written with log_format json. It reports
+// false for anything else, such as text lines.
+func ParseLogEntry(line string) (LogEntry, bool) {
+	var entry LogEntry
+	if err := json.Unmarshal([]byte(line), &entry); err != nil || entry.Msg == "" {
+		return LogEntry{}, false
+	}
+	return entry, true
+}
+
+// writeLog prints entry through logger as text or, when asJSON is set, as
+// a single JSON line.
+func writeLog(logger *log.Logger, asJSON bool, entry LogEntry) {
+	if !asJSON {
+		logger.Print(entry.String())
+		return
+	}
+	entry.Time = time.Now()
+	line, err := json.Marshal(entry)
+	if err != nil {
+		logger.Print(entry.String())
+		return
+	}
+	logger.Print(string(line))
+}
+
+// queryEntry describes one executed statement and how long it took.
+func queryEntry(query string, elapsed time.Duration, err error) LogEntry {
+	ms := elapsed.Milliseconds()
+	entry := LogEntry{Level: LevelDebug, Msg: "query", Query: query, DurationMS: &ms}
+	if err != nil {
+		entry.Level = LevelError
+		entry.Error = err.Error()
+	}
+	return entry
+}
+
+// debugf logs a message when debug logging is on.
+func (c *Client) debugf(level, format string, args ...any) {
+	if c.debug {
+		writeLog(c.logger, c.jsonLog, LogEntry{Level: level, Msg: fmt.Sprintf(format, args...)})
+	}
+}
+
+// logQuery logs an executed statement when debug logging is on.
+func (c *Client) logQuery(query string, elapsed time.Duration, err error) {
+	if c.debug {
+		writeLog(c.logger, c.jsonLog, queryEntry(query, elapsed, err))
+	}
+}
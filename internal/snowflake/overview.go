@@ -0,0 +1,44 @@
+package snowflake
+
+import (
+	"context"
+
+	"github.com/marcelinojackson-org/snow9s/pkg/models"
+	"golang.org/x/sync/errgroup"
+)
+
+// Overview is a snapshot of every resource type. Each list is loaded
+// independently: a failed query leaves its slice nil and records its error
+// without affecting the others.
+type Overview struct {
+	Services        []models.Service
+	Pools           []models.ComputePool
+	Repositories    []models.ImageRepository
+	ServicesErr     error
+	PoolsErr        error
+	RepositoriesErr error
+}
+
+// LoadOverview lists services, compute pools, and image repositories
+// concurrently under ctx. The returned error is the first failure, if any;
+// the Overview still carries every list that loaded.
+func (s *SPCS) LoadOverview(ctx context.Context) (Overview, error) {
+	var o Overview
+	// A plain Group rather than WithContext: one failure must not cancel the
+	// remaining queries.
+	var g errgroup.Group
+	g.Go(func() error {
+		o.Services, o.ServicesErr = s.ListServices(ctx)
+		return o.ServicesErr
+	})
+	g.Go(func() error {
+		o.Pools, o.PoolsErr = s.ListComputePools(ctx)
+		return o.PoolsErr
+	})
+	g.Go(func() error {
+		o.Repositories, o.RepositoriesErr = s.ListImageRepositories(ctx)
+		return o.RepositoriesErr
+	})
+	err := g.Wait()
+	return o, err
+}
@@ -0,0 +1,85 @@
+package snowflake
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"testing"
+)
+
+// encryptPKCS8 mirrors openssl pkcs8 -topk8 -v2 aes-256-cbc.
+func encryptPKCS8(t *testing.T, der []byte, passphrase string) []byte {
+	t.Helper()
+	salt, iv := make([]byte, 8), make([]byte, aes.BlockSize)
+	rand.Read(salt)
+	rand.Read(iv)
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, 2048, 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pad := aes.BlockSize - len(der)%aes.BlockSize
+	plain := append(append([]byte{}, der...), bytes.Repeat([]byte{byte(pad)}, pad)...)
+	block, _ := aes.NewCipher(key)
+	data := make([]byte, len(plain))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(data, plain)
+
+	mustMarshal := func(v any) asn1.RawValue {
+		b, err := asn1.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return asn1.RawValue{FullBytes: b}
+	}
+	kdf := pkix.AlgorithmIdentifier{Algorithm: oidPBKDF2, Parameters: mustMarshal(pbkdf2Params{
+		Salt: salt, Iterations: 2048, PRF: pkix.AlgorithmIdentifier{Algorithm: oidHMACWithSHA256, Parameters: asn1.NullRawValue},
+	})}
+	enc := pkix.AlgorithmIdentifier{Algorithm: oidAES256CBC, Parameters: mustMarshal(iv)}
+	info := encryptedPrivateKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: oidPBES2, Parameters: mustMarshal(pbes2Params{KeyDerivation: kdf, Encryption: enc})},
+		Data:      data,
+	}
+	out, err := asn1.Marshal(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: out})
+}
+
+func TestParseEncryptedPrivateKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted := encryptPKCS8(t, der, "correct horse")
+
+	parsed, err := parseRSAPrivateKey(encrypted, "correct horse")
+	if err != nil {
+		t.Fatalf("parseRSAPrivateKey: %v", err)
+	}
+	if !parsed.Equal(key) {
+		t.Fatalf("decrypted key does not match")
+	}
+	if _, err := parseRSAPrivateKey(encrypted, "battery staple"); !errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("expected ErrWrongPassphrase got %v", err)
+	}
+	if _, err := parseRSAPrivateKey(encrypted, ""); !errors.Is(err, ErrPassphraseRequired) {
+		t.Fatalf("expected ErrPassphraseRequired got %v", err)
+	}
+	garbage := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("not a key")})
+	if _, err := parseRSAPrivateKey(garbage, "correct horse"); err == nil || errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("expected an unparseable key error got %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package snowflake
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/snowflakedb/gosnowflake"
+)
+
+// queryRetryBase is the delay before the first retry of a transient failure;
+// it doubles on each further attempt.
+const queryRetryBase = 250 * time.Millisecond
+
+// retryableCodes are driver and server error numbers for failures that are
+// worth retrying: the service was unreachable or the session needed renewal.
+var retryableCodes = map[int]bool{
+	gosnowflake.ErrCodeServiceUnavailable: true,
+	gosnowflake.ErrCodeFailedToConnect:    true,
+	gosnowflake.ErrFailedToPostQuery:      true,
+	gosnowflake.ErrFailedToRenewSession:   true,
+	gosnowflake.ErrFailedToHeartbeat:      true,
+	gosnowflake.ErrFailedToGetChunk:       true,
+	errSessionExpired:                     true,
+	errAuthTokenExpired:                   true,
+}
+
+// isRetryable reports whether err is a transient failure. Errors Snowflake
+// returned for the statement itself, such as syntax or permission errors,
+// are not.
+func isRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var sfErr *gosnowflake.SnowflakeError
+	if errors.As(err, &sfErr) {
+		return retryableCodes[sfErr.Number] || strings.HasPrefix(sfErr.SQLState, "08")
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// retryDelay returns the wait before retry attempt (0-based): base doubled
+// per attempt plus up to half again of random jitter.
+func retryDelay(base time.Duration, attempt int) time.Duration {
+	delay := base << attempt
+	return delay + rand.N(delay/2+1)
+}
+
+// sleepCtx waits for d, returning false without waiting when ctx ends first
+// or its deadline falls before the wait would finish.
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < d {
+		return false
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,155 @@
+package snowflake
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// maxScratchRows caps how many rows RunQuery keeps, so a SELECT without a
+// LIMIT cannot flood the terminal.
+const maxScratchRows = 1000
+
+// readOnlyKeywords are the statements RunQuery accepts.
+var readOnlyKeywords = []string{"SELECT", "SHOW", "DESCRIBE", "DESC", "WITH", "EXPLAIN", "LIST", "LS"}
+
+// mutatingKeywords may not appear anywhere in a statement RunQuery accepts,
+// which catches a data-modifying CTE such as WITH ... DELETE.
+var mutatingKeywords = []string{
+	"INSERT", "UPDATE", "DELETE", "MERGE", "TRUNCATE", "COPY", "PUT", "REMOVE", "RM",
+	"CREATE", "ALTER", "DROP", "UNDROP", "GRANT", "REVOKE", "CALL", "EXECUTE", "USE",
+}
+
+// QueryResult is an ad-hoc result set with every value as text. NULLs are
+// empty strings. Truncated reports rows dropped beyond maxScratchRows.
+type QueryResult struct {
+	Columns   []string
+	Rows      [][]string
+	Truncated bool
+}
+
+// IsReadOnly reports whether statement is a single statement that starts
+// with a keyword that cannot change data, such as SELECT or SHOW, and names
+// no mutating keyword anywhere outside strings, quoted identifiers and
+// comments. It errs on the side of refusing, so a column that happens to be
+// called DELETE has to be quoted.
+func IsReadOnly(statement string) bool {
+	code := strings.TrimSpace(stripLiterals(statement))
+	code = strings.TrimSpace(strings.TrimSuffix(code, ";"))
+	if strings.Contains(code, ";") {
+		return false
+	}
+	words := strings.FieldsFunc(strings.ToUpper(code), func(r rune) bool {
+		return !(r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r))
+	})
+	if len(words) == 0 || !slices.Contains(readOnlyKeywords, words[0]) {
+		return false
+	}
+	for _, word := range words[1:] {
+		if slices.Contains(mutatingKeywords, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// stripLiterals blanks out comments, string literals, $$-quoted blocks and
+// quoted identifiers so only the statement's own keywords and punctuation
+// are left. An unterminated literal blanks the rest of the statement.
+func stripLiterals(statement string) string {
+	var b strings.Builder
+	for i := 0; i < len(statement); {
+		rest := statement[i:]
+		var end int
+		switch {
+		case strings.HasPrefix(rest, "--"), strings.HasPrefix(rest, "//"):
+			end = strings.IndexByte(rest, '\n')
+		case strings.HasPrefix(rest, "/*"):
+			if end = strings.Index(rest[2:], "*/"); end >= 0 {
+				end += 4
+			}
+		case strings.HasPrefix(rest, "$$"):
+			if end = strings.Index(rest[2:], "$$"); end >= 0 {
+				end += 4
+			}
+		case rest[0] == '\'' || rest[0] == '"':
+			end = closingQuote(rest)
+		default:
+			b.WriteByte(rest[0])
+			i++
+			continue
+		}
+		if end < 0 {
+			end = len(rest)
+		}
+		b.WriteByte(' ')
+		i += end
+	}
+	return b.String()
+}
+
+// closingQuote returns the length of the quoted text at the start of s,
+// honouring doubled quotes and, in string literals, backslash escapes. It
+// returns -1 when the quote is never closed.
+func closingQuote(s string) int {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && quote == '\'':
+			i++
+		case s[i] == quote && i+1 < len(s) && s[i+1] == quote:
+			i++
+		case s[i] == quote:
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// RunQuery runs a read-only statement typed by the user and returns its
+// result set, keeping at most maxScratchRows rows. Other statements are
+// refused so mutations keep going through their confirmation prompts.
+func (s *SPCS) RunQuery(ctx context.Context, statement string) (QueryResult, error) {
+	statement = strings.TrimSuffix(strings.TrimSpace(statement), ";")
+	if !IsReadOnly(statement) {
+		return QueryResult{}, fmt.Errorf("only a single read-only statement (%s) can run here", strings.Join(readOnlyKeywords, ", "))
+	}
+	q, release, err := s.session(ctx, queryRead)
+	if err != nil {
+		return QueryResult{}, err
+	}
+	defer release()
+	rows, err := queryRows(ctx, q, statement)
+	if err != nil {
+		return QueryResult{}, err
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return QueryResult{}, fmt.Errorf("fetch columns: %w", err)
+	}
+	keys := columnKeys(cols)
+	result := QueryResult{Columns: cols, Rows: [][]string{}}
+	for rows.Next() {
+		if len(result.Rows) == maxScratchRows {
+			result.Truncated = true
+			break
+		}
+		rec, err := scanRowToMap(rows, cols)
+		if err != nil {
+			return QueryResult{}, fmt.Errorf("scan row: %w", err)
+		}
+		values := make([]string, len(keys))
+		for i, key := range keys {
+			values[i] = rec[key]
+		}
+		result.Rows = append(result.Rows, values)
+	}
+	if err := rows.Err(); err != nil {
+		return QueryResult{}, err
+	}
+	return result, nil
+}
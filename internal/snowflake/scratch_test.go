@@ -0,0 +1,106 @@
+package snowflake
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/marcelinojackson-org/snow9s/internal/config"
+)
+
+func TestIsReadOnly(t *testing.T) {
+	for stmt, want := range map[string]bool{
+		"select 1":                             true,
+		"  SHOW SERVICES;":                     true,
+		"(SELECT 1) UNION (SELECT 2)":          true,
+		"desc service foo":                     true,
+		"with x as (select 1) select * from x": true,
+		"DROP SERVICE foo":                     false,
+		"alter service foo suspend":            false,
+		"selectx":                              false,
+		"":                                     false,
+		"-- latest first\nSELECT 1":            true,
+		"SELECT 'drop; table' AS note":         true,
+		`SELECT "DELETE" FROM t`:               true,
+		"SELECT $$ update $$":                  true,
+		"SELECT 1; DROP TABLE t":               false,
+		"SELECT 1;;":                           false,
+		"with x as (delete from t) select 1":   false,
+		"SELECT * FROM t /* ; */ -- ;":         true,
+		"show grants":                          true,
+		"/* DROP */":                           false,
+	} {
+		if got := IsReadOnly(stmt); got != want {
+			t.Errorf("IsReadOnly(%q) = %v, want %v", stmt, got, want)
+		}
+	}
+}
+
+func TestRunQuery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT name, name, note FROM t`).WillReturnRows(
+		sqlmock.NewRows([]string{"NAME", "NAME", "NOTE"}).
+			AddRow("a", "b", nil).
+			AddRow("c", "d", "x"))
+
+	spcs := NewSPCS(db, config.Config{})
+	result, err := spcs.RunQuery(context.Background(), "SELECT name, name, note FROM t;")
+	if err != nil {
+		t.Fatalf("RunQuery: %v", err)
+	}
+	if strings.Join(result.Columns, ",") != "NAME,NAME,NOTE" {
+		t.Fatalf("unexpected columns: %v", result.Columns)
+	}
+	if len(result.Rows) != 2 || strings.Join(result.Rows[0], ",") != "a,b," || strings.Join(result.Rows[1], ",") != "c,d,x" {
+		t.Fatalf("unexpected rows: %v", result.Rows)
+	}
+	if result.Truncated {
+		t.Fatal("two rows should not be truncated")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestRunQueryTruncates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"N"})
+	for i := 0; i <= maxScratchRows; i++ {
+		rows.AddRow(i)
+	}
+	mock.ExpectQuery(`SELECT n FROM big`).WillReturnRows(rows)
+
+	result, err := NewSPCS(db, config.Config{}).RunQuery(context.Background(), "SELECT n FROM big")
+	if err != nil {
+		t.Fatalf("RunQuery: %v", err)
+	}
+	if len(result.Rows) != maxScratchRows || !result.Truncated {
+		t.Fatalf("expected %d rows and truncation, got %d rows truncated=%v", maxScratchRows, len(result.Rows), result.Truncated)
+	}
+}
+
+func TestRunQueryRejectsMutations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := NewSPCS(db, config.Config{}).RunQuery(context.Background(), "DROP SERVICE foo"); err == nil {
+		t.Fatal("expected DROP to be rejected")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("no query should run: %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/marcelinojackson-org/snow9s/internal/config"
+)
+
+// sessionStatements returns the USE statements that pin a session to the
+// configured role, warehouse, database, and schema. The role goes first so
+// the others are resolved with its grants.
+func sessionStatements(cfg config.Config) []string {
+	var stmts []string
+	if cfg.Role != "" {
+		stmts = append(stmts, "USE ROLE "+configIdent(cfg.Role))
+	}
+	if cfg.Warehouse != "" {
+		stmts = append(stmts, "USE WAREHOUSE "+configIdent(cfg.Warehouse))
+	}
+	if cfg.Database != "" {
+		stmts = append(stmts, "USE DATABASE "+configIdent(cfg.Database))
+	}
+	if cfg.Schema != "" {
+		stmts = append(stmts, "USE SCHEMA "+configIdent(cfg.Schema))
+	}
+	return stmts
+}
+
+// plainIdent matches identifiers Snowflake accepts unquoted.
+var plainIdent = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*$`)
+
+// configIdent renders a role, warehouse, database, or schema name from the
+// config. A plain name stays unquoted so it resolves case-insensitively, as it
+// does in the DSN; a name the user quoted is kept as is, and anything else is
+// quoted.
+func configIdent(name string) string {
+	if plainIdent.MatchString(name) {
+		return name
+	}
+	if len(name) > 1 && strings.HasPrefix(name, `"`) && strings.HasSuffix(name, `"`) {
+		return name
+	}
+	return quoteIdent(name)
+}
+
+// sessionConnector runs the USE statements on every new connection, since
+// some accounts ignore the equivalent DSN parameters and database/sql may
+// open any pooled connection for a later query.
+type sessionConnector struct {
+	driver.Connector
+	statements []string
+	logger     *log.Logger // nil unless debug logging is on
+	jsonLog    bool
+}
+
+func (c sessionConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil || len(c.statements) == 0 {
+		return conn, err
+	}
+	execer, ok := conn.(driver.ExecerContext)
+	if !ok {
+		conn.Close()
+		return nil, errors.New("set session context: connection cannot execute statements")
+	}
+	for _, stmt := range c.statements {
+		start := time.Now()
+		_, err := execer.ExecContext(ctx, stmt, nil)
+		if c.logger != nil {
+			writeLog(c.logger, c.jsonLog, queryEntry(stmt, time.Since(start), err))
+		}
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("set session context: %s: %w", stmt, err)
+		}
+	}
+	return conn, nil
+}
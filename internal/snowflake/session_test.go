@@ -0,0 +1,85 @@
+package snowflake
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/marcelinojackson-org/snow9s/internal/config"
+)
+
+type fakeConn struct {
+	executed []string
+	failOn   string
+	closed   bool
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *fakeConn) Close() error                        { c.closed = true; return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.executed = append(c.executed, query)
+	if c.failOn != "" && strings.HasPrefix(query, c.failOn) {
+		return nil, errors.New("002043 (02000): Object does not exist, or operation cannot be performed.")
+	}
+	return driver.RowsAffected(0), nil
+}
+
+type fakeConnector struct{ conn *fakeConn }
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) { return c.conn, nil }
+func (c fakeConnector) Driver() driver.Driver                        { return nil }
+
+func TestSessionStatements(t *testing.T) {
+	got := sessionStatements(config.Config{Role: "ANALYST", Warehouse: "WH", Database: "DB", Schema: "PUBLIC"})
+	want := []string{`USE ROLE ANALYST`, `USE WAREHOUSE WH`, `USE DATABASE DB`, `USE SCHEMA PUBLIC`}
+	if strings.Join(got, ";") != strings.Join(want, ";") {
+		t.Fatalf("unexpected statements: %v", got)
+	}
+	// Lowercase names resolve case-insensitively, as they do in the DSN;
+	// only names that need it are quoted.
+	got = sessionStatements(config.Config{Role: "analyst", Warehouse: "compute_wh", Database: "my-db", Schema: `"MixedCase"`})
+	want = []string{`USE ROLE analyst`, `USE WAREHOUSE compute_wh`, `USE DATABASE "my-db"`, `USE SCHEMA "MixedCase"`}
+	if strings.Join(got, ";") != strings.Join(want, ";") {
+		t.Fatalf("unexpected statements for lowercase config: %v", got)
+	}
+	if got := sessionStatements(config.Config{Schema: "PUBLIC"}); len(got) != 1 {
+		t.Fatalf("expected only the schema statement, got %v", got)
+	}
+}
+
+func TestSessionConnectorRunsUseStatements(t *testing.T) {
+	conn := &fakeConn{}
+	var logs bytes.Buffer
+	connector := sessionConnector{
+		Connector:  fakeConnector{conn: conn},
+		statements: []string{`USE WAREHOUSE "WH"`, `USE SCHEMA "PUBLIC"`},
+		logger:     log.New(&logs, "", 0),
+	}
+	if _, err := connector.Connect(context.Background()); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	if len(conn.executed) != 2 || !strings.Contains(logs.String(), `USE SCHEMA "PUBLIC"`) {
+		t.Fatalf("statements not run and logged: %v %q", conn.executed, logs.String())
+	}
+}
+
+func TestSessionConnectorFailsOnUseError(t *testing.T) {
+	conn := &fakeConn{failOn: "USE WAREHOUSE"}
+	connector := sessionConnector{
+		Connector:  fakeConnector{conn: conn},
+		statements: []string{`USE WAREHOUSE "MISSING"`, `USE SCHEMA "PUBLIC"`},
+	}
+	_, err := connector.Connect(context.Background())
+	if err == nil || !strings.Contains(err.Error(), `USE WAREHOUSE "MISSING"`) {
+		t.Fatalf("expected the failing statement in the error, got %v", err)
+	}
+	if !conn.closed || len(conn.executed) != 1 {
+		t.Fatalf("connection should be closed after the first failure: closed=%v executed=%v", conn.closed, conn.executed)
+	}
+}
@@ -3,8 +3,13 @@ package snowflake
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/marcelinojackson-org/snow9s/internal/config"
@@ -15,11 +20,85 @@ import (
 type SPCS struct {
 	client Queryable
 	cfg    config.Config
+	loc    *time.Location
+	cache  *queryCache
+
+	// schemasMu guards allSchemas.
+	schemasMu  sync.Mutex
+	allSchemas bool
+}
+
+// queryCategory separates read-only metadata queries from actions so each can
+// run on its own warehouse.
+type queryCategory int
+
+const (
+	queryRead queryCategory = iota
+	queryAction
+)
+
+// sessionProvider is implemented by clients that can pin a single session,
+// such as *sql.DB and Client.
+type sessionProvider interface {
+	Conn(ctx context.Context) (*sql.Conn, error)
+}
+
+// locationProvider is implemented by clients that know the session time zone,
+// such as Client.
+type locationProvider interface {
+	Location() *time.Location
+}
+
+// timingProvider is implemented by clients that time their queries, such as
+// Client.
+type timingProvider interface {
+	LastQuery() (QueryTiming, bool)
+}
+
+// resumer is implemented by clients that can resume a pinned session's
+// suspended warehouse, such as Client.
+type resumer interface {
+	withResume(conn *sql.Conn, warehouse string) Queryable
+}
+
+// reconnector is implemented by clients that can replace their sessions,
+// such as Client.
+type reconnector interface {
+	Reconnect(ctx context.Context) error
 }
 
 // NewSPCS constructs the service wrapper.
 func NewSPCS(client Queryable, cfg config.Config) *SPCS {
-	return &SPCS{client: client, cfg: cfg}
+	loc := time.UTC
+	if provider, ok := client.(locationProvider); ok && provider.Location() != nil {
+		loc = provider.Location()
+	}
+	cache := newQueryCache(time.Duration(cfg.CacheTTL) * time.Second)
+	return &SPCS{client: client, cfg: cfg, loc: loc, cache: cache}
+}
+
+// LastQuery returns the timing of the most recent query when the client
+// records one.
+func (s *SPCS) LastQuery() (QueryTiming, bool) {
+	if provider, ok := s.client.(timingProvider); ok {
+		return provider.LastQuery()
+	}
+	return QueryTiming{}, false
+}
+
+// Reconnect re-establishes the client's sessions after a connection error.
+// Clients that cannot reconnect, such as test doubles, report success.
+func (s *SPCS) Reconnect(ctx context.Context) error {
+	if r, ok := s.client.(reconnector); ok {
+		return r.Reconnect(ctx)
+	}
+	return nil
+}
+
+// InvalidateCache drops cached list results so the next call queries
+// Snowflake, as a manual refresh should.
+func (s *SPCS) InvalidateCache() {
+	s.cache.clear()
 }
 
 // SetSchema updates the active schema for subsequent queries.
@@ -27,10 +106,108 @@ func (s *SPCS) SetSchema(schema string) {
 	s.cfg.Schema = schema
 }
 
+// SetAllSchemas switches service listing between the active schema and every
+// schema of the active database. Per-service calls still address the active
+// schema; use InSchema for a service listed elsewhere.
+func (s *SPCS) SetAllSchemas(all bool) {
+	s.schemasMu.Lock()
+	defer s.schemasMu.Unlock()
+	s.allSchemas = all
+}
+
+// InSchema returns an SPCS whose per-service calls address schema instead of
+// the active one, sharing s's client and cache. An empty schema keeps the
+// active one.
+func (s *SPCS) InSchema(schema string) *SPCS {
+	cfg := s.cfg
+	if schema != "" {
+		cfg.Schema = schema
+	}
+	return &SPCS{client: s.client, cfg: cfg, loc: s.loc, cache: s.cache}
+}
+
+// AllSchemas reports whether services are listed across the whole database.
+func (s *SPCS) AllSchemas() bool {
+	s.schemasMu.Lock()
+	defer s.schemasMu.Unlock()
+	return s.allSchemas && s.cfg.Database != ""
+}
+
+// SetDatabase updates the active database for subsequent queries.
+func (s *SPCS) SetDatabase(database string) {
+	s.cfg.Database = database
+}
+
+// session returns the Queryable to use for a query category. When a monitor
+// warehouse is configured, read queries run on a pinned session switched to
+// that warehouse; release restores the primary warehouse and frees it.
+func (s *SPCS) session(ctx context.Context, category queryCategory) (Queryable, func(), error) {
+	noop := func() {}
+	if category != queryRead || s.cfg.MonitorWarehouse == "" {
+		return s.client, noop, nil
+	}
+	provider, ok := s.client.(sessionProvider)
+	if !ok {
+		return s.client, noop, nil
+	}
+	conn, err := provider.Conn(ctx)
+	if err != nil {
+		return nil, noop, fmt.Errorf("open session: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, "USE WAREHOUSE "+configIdent(s.cfg.MonitorWarehouse)); err != nil {
+		conn.Close()
+		return nil, noop, fmt.Errorf("use monitor warehouse: %w", err)
+	}
+	release := func() {
+		if s.cfg.Warehouse != "" {
+			_, _ = conn.ExecContext(context.Background(), "USE WAREHOUSE "+configIdent(s.cfg.Warehouse))
+		}
+		conn.Close()
+	}
+	if r, ok := s.client.(resumer); ok {
+		return r.withResume(conn, s.cfg.MonitorWarehouse), release, nil
+	}
+	return conn, release, nil
+}
+
 // ListServices runs SHOW SERVICES and maps the results to Service models.
 func (s *SPCS) ListServices(ctx context.Context) ([]models.Service, error) {
+	return s.ListServicesByStatus(ctx, "")
+}
+
+// ListServicesByStatus lists the services whose status equals status,
+// ignoring case; an empty status lists them all. SHOW SERVICES has no status
+// predicate, so the rows are filtered here and the cache keeps the full list.
+func (s *SPCS) ListServicesByStatus(ctx context.Context, status string) ([]models.Service, error) {
+	services, err := s.listServices(ctx)
+	status = models.NormalizeStatus(status)
+	if err != nil || status == "" {
+		return services, err
+	}
+	matched := []models.Service{}
+	for _, service := range services {
+		if service.Status == status {
+			matched = append(matched, service)
+		}
+	}
+	return matched, nil
+}
+
+func (s *SPCS) listServices(ctx context.Context) ([]models.Service, error) {
 	query := buildShowServicesQuery(s.cfg)
-	rows, err := s.client.QueryContext(ctx, query)
+	if s.AllSchemas() {
+		query = "SHOW SERVICES IN DATABASE " + quoteIdent(s.cfg.Database)
+	}
+	cached, gen, ok := cacheGet[[]models.Service](s.cache, query)
+	if ok {
+		return cached, nil
+	}
+	q, release, err := s.session(ctx, queryRead)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	rows, err := queryRows(ctx, q, query)
 	if err != nil {
 		return nil, fmt.Errorf("query services: %w", err)
 	}
@@ -49,14 +226,20 @@ func (s *SPCS) ListServices(ctx context.Context) ([]models.Service, error) {
 		}
 
 		service := models.Service{
-			Name:        rec["name"],
-			Namespace:   fallback(rec["schema_name"], s.cfg.Schema),
-			Status:      strings.ToLower(fallback(rec["status"], rec["state"])),
-			ComputePool: rec["compute_pool"],
+			Name:         rec.get("name"),
+			Namespace:    fallback(rec.get("schema_name"), s.cfg.Schema),
+			Status:       models.NormalizeStatus(rec.get("status", "state")),
+			ComputePool:  rec.get("compute_pool"),
+			DNSName:      rec.get("dns_name"),
+			Owner:        rec.get("owner"),
+			Comment:      rec.get("comment"),
+			MinInstances: rec.get("min_instances"),
+			MaxInstances: rec.get("max_instances"),
+			SpecDigest:   rec.get("spec_digest"),
 		}
 
-		if created := rec["created_on"]; created != "" {
-			service.CreatedAt = parseSnowflakeTime(created)
+		if created := rec.get("created_on"); created != "" {
+			service.CreatedAt = parseSnowflakeTime(created, s.loc)
 			service.Age = models.HumanizeAge(service.CreatedAt)
 		}
 
@@ -67,13 +250,84 @@ func (s *SPCS) ListServices(ctx context.Context) ([]models.Service, error) {
 		return nil, err
 	}
 
+	s.cache.put(query, gen, services)
 	return services, nil
 }
 
+// jobFinished lists the job statuses after which a job no longer changes.
+var jobFinished = []string{models.StatusDone, models.StatusFailed, models.StatusInternalError, models.StatusCancelled}
+
+// ListJobs runs SHOW JOB SERVICES and maps the results to Job models. SHOW
+// has no completion column, so a finished job's last update is taken as its
+// completion time.
+func (s *SPCS) ListJobs(ctx context.Context) ([]models.Job, error) {
+	query := buildShowJobServicesQuery(s.cfg)
+	cached, gen, ok := cacheGet[[]models.Job](s.cache, query)
+	if ok {
+		return cached, nil
+	}
+	q, release, err := s.session(ctx, queryRead)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	rows, err := queryRows(ctx, q, query)
+	if err != nil {
+		return nil, fmt.Errorf("query jobs: %w", err)
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("fetch columns: %w", err)
+	}
+
+	jobs := []models.Job{}
+	for rows.Next() {
+		rec, err := scanRowToMap(rows, cols)
+		if err != nil {
+			return nil, fmt.Errorf("scan job row: %w", err)
+		}
+		job := models.Job{
+			Name:        rec.get("name"),
+			Namespace:   fallback(rec.get("schema_name"), s.cfg.Schema),
+			Status:      models.NormalizeStatus(rec.get("status", "state")),
+			ComputePool: rec.get("compute_pool"),
+		}
+		if created := rec.get("created_on"); created != "" {
+			job.CreatedAt = parseSnowflakeTime(created, s.loc)
+		}
+		if updated := rec.get("updated_on"); updated != "" && slices.Contains(jobFinished, job.Status) {
+			job.CompletedAt = parseSnowflakeTime(updated, s.loc)
+		}
+		switch {
+		case !job.CompletedAt.IsZero():
+			job.Age = models.HumanizeAge(job.CompletedAt)
+		case !job.CreatedAt.IsZero():
+			job.Age = models.HumanizeAge(job.CreatedAt)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	s.cache.put(query, gen, jobs)
+	return jobs, nil
+}
+
 // ListComputePools runs SHOW COMPUTE POOLS and maps the results.
 func (s *SPCS) ListComputePools(ctx context.Context) ([]models.ComputePool, error) {
 	query := "SHOW COMPUTE POOLS"
-	rows, err := s.client.QueryContext(ctx, query)
+	cached, gen, ok := cacheGet[[]models.ComputePool](s.cache, query)
+	if ok {
+		return cached, nil
+	}
+	q, release, err := s.session(ctx, queryRead)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	rows, err := queryRows(ctx, q, query)
 	if err != nil {
 		return nil, fmt.Errorf("query compute pools: %w", err)
 	}
@@ -91,14 +345,21 @@ func (s *SPCS) ListComputePools(ctx context.Context) ([]models.ComputePool, erro
 			return nil, fmt.Errorf("scan compute pool row: %w", err)
 		}
 		pool := models.ComputePool{
-			Name:           rec["name"],
-			State:          strings.ToLower(fallback(rec["state"], rec["status"])),
-			MinNodes:       rec["min_nodes"],
-			MaxNodes:       rec["max_nodes"],
-			InstanceFamily: rec["instance_family"],
-		}
-		if created := rec["created_on"]; created != "" {
-			pool.CreatedAt = parseSnowflakeTime(created)
+			Name:           rec.get("name"),
+			State:          models.NormalizeStatus(rec.get("state", "status")),
+			MinNodes:       rec.get("min_nodes"),
+			MaxNodes:       rec.get("max_nodes"),
+			InstanceFamily: rec.get("instance_family"),
+			ActiveNodes:    rec.get("active_nodes"),
+			IdleNodes:      rec.get("idle_nodes"),
+			AutoResume:     strings.EqualFold(rec.get("auto_resume"), "true"),
+		}
+		pool.MinNodeCount, _ = strconv.Atoi(pool.MinNodes)
+		pool.MaxNodeCount, _ = strconv.Atoi(pool.MaxNodes)
+		pool.ActiveNodeCount, _ = strconv.Atoi(pool.ActiveNodes)
+		pool.IdleNodeCount, _ = strconv.Atoi(pool.IdleNodes)
+		if created := rec.get("created_on"); created != "" {
+			pool.CreatedAt = parseSnowflakeTime(created, s.loc)
 			pool.Age = models.HumanizeAge(pool.CreatedAt)
 		}
 		pools = append(pools, pool)
@@ -106,13 +367,23 @@ func (s *SPCS) ListComputePools(ctx context.Context) ([]models.ComputePool, erro
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
+	s.cache.put(query, gen, pools)
 	return pools, nil
 }
 
 // ListImageRepositories runs SHOW IMAGE REPOSITORIES and maps the results.
 func (s *SPCS) ListImageRepositories(ctx context.Context) ([]models.ImageRepository, error) {
 	query := buildShowImageReposQuery(s.cfg)
-	rows, err := s.client.QueryContext(ctx, query)
+	cached, gen, ok := cacheGet[[]models.ImageRepository](s.cache, query)
+	if ok {
+		return cached, nil
+	}
+	q, release, err := s.session(ctx, queryRead)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	rows, err := queryRows(ctx, q, query)
 	if err != nil {
 		return nil, fmt.Errorf("query image repositories: %w", err)
 	}
@@ -130,12 +401,12 @@ func (s *SPCS) ListImageRepositories(ctx context.Context) ([]models.ImageReposit
 			return nil, fmt.Errorf("scan repo row: %w", err)
 		}
 		repo := models.ImageRepository{
-			Name:          rec["name"],
-			RepositoryURL: rec["repository_url"],
-			Owner:         rec["owner"],
+			Name:          rec.get("name"),
+			RepositoryURL: rec.get("repository_url"),
+			Owner:         rec.get("owner"),
 		}
-		if created := rec["created_on"]; created != "" {
-			repo.CreatedAt = parseSnowflakeTime(created)
+		if created := rec.get("created_on"); created != "" {
+			repo.CreatedAt = parseSnowflakeTime(created, s.loc)
 			repo.Age = models.HumanizeAge(repo.CreatedAt)
 		}
 		repos = append(repos, repo)
@@ -143,13 +414,123 @@ func (s *SPCS) ListImageRepositories(ctx context.Context) ([]models.ImageReposit
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
+	s.cache.put(query, gen, repos)
 	return repos, nil
 }
 
-// DescribeService returns a key/value map from SHOW SERVICES LIKE.
+// ListDatabases runs SHOW DATABASES and maps the results.
+func (s *SPCS) ListDatabases(ctx context.Context) ([]models.Database, error) {
+	query := "SHOW DATABASES"
+	cached, gen, ok := cacheGet[[]models.Database](s.cache, query)
+	if ok {
+		return cached, nil
+	}
+	q, release, err := s.session(ctx, queryRead)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	rows, err := queryRows(ctx, q, query)
+	if err != nil {
+		return nil, fmt.Errorf("query databases: %w", err)
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("fetch columns: %w", err)
+	}
+
+	databases := []models.Database{}
+	for rows.Next() {
+		rec, err := scanRowToMap(rows, cols)
+		if err != nil {
+			return nil, fmt.Errorf("scan database row: %w", err)
+		}
+		db := models.Database{
+			Name:  rec.get("name"),
+			Owner: rec.get("owner"),
+		}
+		if created := rec.get("created_on"); created != "" {
+			db.CreatedAt = parseSnowflakeTime(created, s.loc)
+			db.Age = models.HumanizeAge(db.CreatedAt)
+		}
+		databases = append(databases, db)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	s.cache.put(query, gen, databases)
+	return databases, nil
+}
+
+// ListSchemas runs SHOW SCHEMAS IN DATABASE and maps the results.
+func (s *SPCS) ListSchemas(ctx context.Context, database string) ([]models.Schema, error) {
+	query := "SHOW SCHEMAS IN DATABASE " + quoteIdent(database)
+	cached, gen, ok := cacheGet[[]models.Schema](s.cache, query)
+	if ok {
+		return cached, nil
+	}
+	q, release, err := s.session(ctx, queryRead)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	rows, err := queryRows(ctx, q, query)
+	if err != nil {
+		return nil, fmt.Errorf("query schemas: %w", err)
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("fetch columns: %w", err)
+	}
+
+	schemas := []models.Schema{}
+	for rows.Next() {
+		rec, err := scanRowToMap(rows, cols)
+		if err != nil {
+			return nil, fmt.Errorf("scan schema row: %w", err)
+		}
+		schema := models.Schema{
+			Name:     rec.get("name"),
+			Database: fallback(rec.get("database_name"), database),
+			Owner:    rec.get("owner"),
+		}
+		if created := rec.get("created_on"); created != "" {
+			schema.CreatedAt = parseSnowflakeTime(created, s.loc)
+			schema.Age = models.HumanizeAge(schema.CreatedAt)
+		}
+		schemas = append(schemas, schema)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	s.cache.put(query, gen, schemas)
+	return schemas, nil
+}
+
+// DescribeService returns a key/value map from SHOW SERVICES LIKE. It returns
+// an error wrapping ErrNotFound when no service has that name.
 func (s *SPCS) DescribeService(ctx context.Context, name string) (map[string]string, error) {
+	attrs, err := s.DescribeServiceAttributes(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return AttributeMap(attrs), nil
+}
+
+// DescribeServiceAttributes is DescribeService keeping the column order of
+// SHOW SERVICES, for views that list every attribute.
+func (s *SPCS) DescribeServiceAttributes(ctx context.Context, name string) ([]Attribute, error) {
 	query := buildShowServicesLikeQuery(s.cfg, name)
-	rows, err := s.client.QueryContext(ctx, query)
+	q, release, err := s.session(ctx, queryRead)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	rows, err := queryRows(ctx, q, query)
 	if err != nil {
 		return nil, fmt.Errorf("describe service: %w", err)
 	}
@@ -158,20 +539,330 @@ func (s *SPCS) DescribeService(ctx context.Context, name string) (map[string]str
 	if err != nil {
 		return nil, fmt.Errorf("fetch columns: %w", err)
 	}
+	for rows.Next() {
+		attrs, err := scanRowToAttributes(rows, cols)
+		if err != nil {
+			return nil, fmt.Errorf("scan service row: %w", err)
+		}
+		// LIKE treats _ as a wildcard, so confirm the exact name.
+		if strings.EqualFold(record(AttributeMap(attrs)).get("name"), name) {
+			return attrs, nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("service %s in %s: %w", name, namespaceLabel(s.cfg), ErrNotFound)
+}
+
+func namespaceLabel(cfg config.Config) string {
+	if cfg.Database != "" {
+		return cfg.Database + "." + cfg.Schema
+	}
+	return cfg.Schema
+}
+
+// ListServiceEndpoints runs SHOW ENDPOINTS IN SERVICE and maps the results.
+func (s *SPCS) ListServiceEndpoints(ctx context.Context, name string) ([]models.Endpoint, error) {
+	query := "SHOW ENDPOINTS IN SERVICE " + qualifiedName(s.cfg, name)
+	cached, gen, ok := cacheGet[[]models.Endpoint](s.cache, query)
+	if ok {
+		return cached, nil
+	}
+	q, release, err := s.session(ctx, queryRead)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	rows, err := queryRows(ctx, q, query)
+	if err != nil {
+		return nil, fmt.Errorf("query service endpoints: %w", err)
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("fetch columns: %w", err)
+	}
+
+	endpoints := []models.Endpoint{}
+	for rows.Next() {
+		rec, err := scanRowToMap(rows, cols)
+		if err != nil {
+			return nil, fmt.Errorf("scan endpoint row: %w", err)
+		}
+		endpoints = append(endpoints, models.Endpoint{
+			Name:       rec.get("name"),
+			Port:       rec.get("port", "port_range"),
+			Protocol:   strings.ToUpper(rec.get("protocol")),
+			Public:     strings.EqualFold(rec.get("is_public"), "true"),
+			IngressURL: rec.get("ingress_url"),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	s.cache.put(query, gen, endpoints)
+	return endpoints, nil
+}
+
+// ListImageTags runs SHOW IMAGES IN IMAGE REPOSITORY and returns one entry
+// per image tag.
+func (s *SPCS) ListImageTags(ctx context.Context, repoName string) ([]models.ImageTag, error) {
+	query := "SHOW IMAGES IN IMAGE REPOSITORY " + qualifiedName(s.cfg, repoName)
+	cached, gen, ok := cacheGet[[]models.ImageTag](s.cache, query)
+	if ok {
+		return cached, nil
+	}
+	q, release, err := s.session(ctx, queryRead)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	rows, err := queryRows(ctx, q, query)
+	if err != nil {
+		return nil, fmt.Errorf("query images: %w", err)
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("fetch columns: %w", err)
+	}
+
+	tags := []models.ImageTag{}
+	for rows.Next() {
+		rec, err := scanRowToMap(rows, cols)
+		if err != nil {
+			return nil, fmt.Errorf("scan image row: %w", err)
+		}
+		image := models.ImageTag{
+			Image:  rec.get("image_name"),
+			Digest: rec.get("digest"),
+			Path:   rec.get("image_path"),
+		}
+		if created := rec.get("created_on"); created != "" {
+			image.CreatedAt = parseSnowflakeTime(created, s.loc)
+			image.Age = models.HumanizeAge(image.CreatedAt)
+		}
+		names := parseImageTags(rec.get("tags"))
+		if len(names) == 0 {
+			names = []string{""}
+		}
+		for _, tag := range names {
+			image.Tag = tag
+			tags = append(tags, image)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	s.cache.put(query, gen, tags)
+	return tags, nil
+}
+
+// parseImageTags reads the tags column, a JSON array such as ["v1","latest"]
+// or a plain comma-separated list.
+func parseImageTags(raw string) []string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil
+	}
+	var tags []string
+	if err := json.Unmarshal([]byte(raw), &tags); err != nil {
+		tags = strings.Split(raw, ",")
+	}
+	out := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			out = append(out, tag)
+		}
+	}
+	return out
+}
+
+// DescribeComputePool returns a key/value map from DESCRIBE COMPUTE POOL. It
+// returns an error wrapping ErrNotFound when the pool returns no row.
+func (s *SPCS) DescribeComputePool(ctx context.Context, name string) (map[string]string, error) {
+	query := "DESCRIBE COMPUTE POOL " + quoteIdent(name)
+	q, release, err := s.session(ctx, queryRead)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	rows, err := queryRows(ctx, q, query)
+	if err != nil {
+		return nil, fmt.Errorf("describe compute pool: %w", err)
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("fetch columns: %w", err)
+	}
 	if rows.Next() {
 		rec, err := scanRowToMap(rows, cols)
 		if err != nil {
-			return nil, fmt.Errorf("scan service row: %w", err)
+			return nil, fmt.Errorf("scan compute pool row: %w", err)
 		}
 		return rec, nil
 	}
-	return map[string]string{}, nil
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("compute pool %s: %w", name, ErrNotFound)
+}
+
+// GetComputePoolDetail describes a compute pool and parses the result.
+func (s *SPCS) GetComputePoolDetail(ctx context.Context, name string) (models.ComputePoolDetail, error) {
+	attrs, err := s.DescribeComputePool(ctx, name)
+	if err != nil {
+		return models.ComputePoolDetail{}, err
+	}
+	return computePoolDetail(attrs, s.loc), nil
+}
+
+// computePoolDetail maps DESCRIBE COMPUTE POOL columns onto the typed model;
+// counts that are missing or not numbers read as zero.
+func computePoolDetail(attrs map[string]string, loc *time.Location) models.ComputePoolDetail {
+	rec := record(attrs)
+	count := func(columns ...string) int {
+		n, _ := strconv.Atoi(rec.get(columns...))
+		return n
+	}
+	timestamp := func(columns ...string) time.Time {
+		if raw := rec.get(columns...); raw != "" {
+			return parseSnowflakeTime(raw, loc)
+		}
+		return time.Time{}
+	}
+	return models.ComputePoolDetail{
+		Name:            rec.get("name"),
+		State:           models.NormalizeStatus(rec.get("state", "status")),
+		InstanceFamily:  rec.get("instance_family"),
+		MinNodes:        count("min_nodes"),
+		MaxNodes:        count("max_nodes"),
+		ActiveNodes:     count("active_nodes"),
+		IdleNodes:       count("idle_nodes"),
+		TargetNodes:     count("target_nodes"),
+		NumServices:     count("num_services"),
+		NumJobs:         count("num_jobs"),
+		AutoSuspendSecs: count("auto_suspend_secs"),
+		AutoResume:      strings.EqualFold(rec.get("auto_resume"), "true"),
+		Application:     rec.get("application"),
+		Owner:           rec.get("owner"),
+		Comment:         rec.get("comment"),
+		StatusMessage:   rec.get("status_message"),
+		CreatedOn:       timestamp("created_on"),
+		ResumedOn:       timestamp("resumed_on"),
+		UpdatedOn:       timestamp("updated_on"),
+	}
+}
+
+// AlterComputePool sets a compute pool's MIN_NODES and MAX_NODES.
+func (s *SPCS) AlterComputePool(ctx context.Context, name string, minNodes, maxNodes int) error {
+	if minNodes < 1 {
+		return fmt.Errorf("min nodes must be at least 1, got %d", minNodes)
+	}
+	if minNodes > maxNodes {
+		return fmt.Errorf("min nodes (%d) must not exceed max nodes (%d)", minNodes, maxNodes)
+	}
+	defer s.cache.clear()
+	query := fmt.Sprintf("ALTER COMPUTE POOL %s SET MIN_NODES = %d MAX_NODES = %d", quoteIdent(name), minNodes, maxNodes)
+	q, release, err := s.session(ctx, queryAction)
+	if err != nil {
+		return err
+	}
+	defer release()
+	rows, err := queryRows(ctx, q, query)
+	if err != nil {
+		return fmt.Errorf("alter compute pool: %w", err)
+	}
+	return rows.Close()
+}
+
+// SuspendService runs ALTER SERVICE ... SUSPEND.
+func (s *SPCS) SuspendService(ctx context.Context, name string) error {
+	return s.alterService(ctx, name, "SUSPEND")
+}
+
+// ResumeService runs ALTER SERVICE ... RESUME.
+func (s *SPCS) ResumeService(ctx context.Context, name string) error {
+	return s.alterService(ctx, name, "RESUME")
+}
+
+// DropService runs DROP SERVICE IF EXISTS, so dropping a service that is
+// already gone succeeds.
+func (s *SPCS) DropService(ctx context.Context, name string) error {
+	// Listings cached before the change no longer reflect it.
+	defer s.cache.clear()
+	query := "DROP SERVICE IF EXISTS " + qualifiedName(s.cfg, name)
+	q, release, err := s.session(ctx, queryAction)
+	if err != nil {
+		return err
+	}
+	defer release()
+	rows, err := queryRows(ctx, q, query)
+	if err != nil {
+		return fmt.Errorf("drop service: %w", err)
+	}
+	return rows.Close()
+}
+
+func (s *SPCS) alterService(ctx context.Context, name, action string) error {
+	defer s.cache.clear()
+	query := fmt.Sprintf("ALTER SERVICE %s %s", qualifiedName(s.cfg, name), action)
+	q, release, err := s.session(ctx, queryAction)
+	if err != nil {
+		return err
+	}
+	defer release()
+	rows, err := queryRows(ctx, q, query)
+	if err != nil {
+		return fmt.Errorf("%s service: %w", strings.ToLower(action), err)
+	}
+	return rows.Close()
+}
+
+// GetServiceSpec returns the YAML specification of a service from DESCRIBE SERVICE.
+func (s *SPCS) GetServiceSpec(ctx context.Context, name string) (string, error) {
+	query := fmt.Sprintf("DESCRIBE SERVICE %s", qualifiedName(s.cfg, name))
+	q, release, err := s.session(ctx, queryRead)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+	rows, err := queryRows(ctx, q, query)
+	if err != nil {
+		return "", fmt.Errorf("describe service spec: %w", err)
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return "", fmt.Errorf("fetch columns: %w", err)
+	}
+	if !rows.Next() {
+		return "", rows.Err()
+	}
+	rec, err := scanRowToMap(rows, cols)
+	if err != nil {
+		return "", fmt.Errorf("scan service spec: %w", err)
+	}
+	return rec.get("spec"), nil
 }
 
 // ListServiceInstances runs SHOW SERVICE INSTANCES for a service.
 func (s *SPCS) ListServiceInstances(ctx context.Context, name string) ([]models.ServiceInstance, error) {
 	query := buildShowServiceInstancesQuery(s.cfg, name)
-	rows, err := s.client.QueryContext(ctx, query)
+	cached, gen, ok := cacheGet[[]models.ServiceInstance](s.cache, query)
+	if ok {
+		return cached, nil
+	}
+	q, release, err := s.session(ctx, queryRead)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	rows, err := queryRows(ctx, q, query)
 	if err != nil {
 		return nil, fmt.Errorf("query service instances: %w", err)
 	}
@@ -189,12 +880,12 @@ func (s *SPCS) ListServiceInstances(ctx context.Context, name string) ([]models.
 			return nil, fmt.Errorf("scan instance row: %w", err)
 		}
 		inst := models.ServiceInstance{
-			Name:   fallback(rec["name"], rec["instance_name"]),
-			Status: strings.ToLower(fallback(rec["status"], rec["state"])),
-			Node:   fallback(rec["node"], rec["host"]),
+			Name:   rec.get("instance_id", "name", "instance_name"),
+			Status: models.NormalizeStatus(rec.get("status", "state")),
+			Node:   rec.get("node", "host"),
 		}
-		if created := rec["created_on"]; created != "" {
-			inst.CreatedAt = parseSnowflakeTime(created)
+		if created := rec.get("created_on"); created != "" {
+			inst.CreatedAt = parseSnowflakeTime(created, s.loc)
 			inst.Age = models.HumanizeAge(inst.CreatedAt)
 		}
 		instances = append(instances, inst)
@@ -202,50 +893,332 @@ func (s *SPCS) ListServiceInstances(ctx context.Context, name string) ([]models.
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
+	s.cache.put(query, gen, instances)
 	return instances, nil
 }
 
+// serviceStatusEntry mirrors one element of the SYSTEM$GET_SERVICE_STATUS array.
+type serviceStatusEntry struct {
+	Status        string `json:"status"`
+	Message       string `json:"message"`
+	ContainerName string `json:"containerName"`
+	InstanceID    string `json:"instanceId"`
+	RestartCount  int    `json:"restartCount"`
+	StartTime     string `json:"startTime"`
+}
+
+// GetServiceEvents reads SYSTEM$GET_SERVICE_STATUS for a service and returns
+// its container status messages, newest first.
+func (s *SPCS) GetServiceEvents(ctx context.Context, name string) ([]models.ServiceEvent, error) {
+	raw, err := s.serviceStatus(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return parseServiceEvents(raw, s.loc)
+}
+
+// GetServiceStatus returns per-container readiness from
+// SYSTEM$GET_SERVICE_STATUS, ordered by instance and container.
+func (s *SPCS) GetServiceStatus(ctx context.Context, name string) ([]models.ContainerStatus, error) {
+	raw, err := s.serviceStatus(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return parseContainerStatuses(raw, s.loc)
+}
+
+// serviceStatus returns the raw JSON array from SYSTEM$GET_SERVICE_STATUS.
+func (s *SPCS) serviceStatus(ctx context.Context, name string) (string, error) {
+	query := fmt.Sprintf("SELECT SYSTEM$GET_SERVICE_STATUS(%s)", quoteLiteral(qualifiedName(s.cfg, name)))
+	q, release, err := s.session(ctx, queryRead)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+	rows, err := queryRows(ctx, q, query)
+	if err != nil {
+		return "", fmt.Errorf("query service status: %w", err)
+	}
+	defer rows.Close()
+
+	var raw sql.NullString
+	if rows.Next() {
+		if err := rows.Scan(&raw); err != nil {
+			return "", fmt.Errorf("scan service status: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	return raw.String, nil
+}
+
+func parseContainerStatuses(raw string, loc *time.Location) ([]models.ContainerStatus, error) {
+	statuses := []models.ContainerStatus{}
+	if strings.TrimSpace(raw) == "" {
+		return statuses, nil
+	}
+	var entries []serviceStatusEntry
+	if err := json.Unmarshal([]byte(raw), &entries); err != nil {
+		return nil, fmt.Errorf("parse service status: %w", err)
+	}
+	for _, e := range entries {
+		statuses = append(statuses, models.ContainerStatus{
+			Status:        strings.ToUpper(e.Status),
+			Message:       e.Message,
+			ContainerName: e.ContainerName,
+			InstanceID:    e.InstanceID,
+			RestartCount:  e.RestartCount,
+			StartTime:     parseSnowflakeTime(e.StartTime, loc),
+		})
+	}
+	sort.SliceStable(statuses, func(i, j int) bool {
+		if statuses[i].InstanceID != statuses[j].InstanceID {
+			return statuses[i].InstanceID < statuses[j].InstanceID
+		}
+		return statuses[i].ContainerName < statuses[j].ContainerName
+	})
+	return statuses, nil
+}
+
+// GetServiceLogs returns the last numLines log lines of a container in the
+// service's first instance. An empty containerName picks the first container
+// reported by SYSTEM$GET_SERVICE_STATUS.
+func (s *SPCS) GetServiceLogs(ctx context.Context, name, containerName string, numLines int) (string, error) {
+	if containerName == "" {
+		events, err := s.GetServiceEvents(ctx, name)
+		if err != nil {
+			return "", fmt.Errorf("list containers: %w", err)
+		}
+		for _, e := range events {
+			if e.Container != "" {
+				containerName = e.Container
+				break
+			}
+		}
+		if containerName == "" {
+			return "", fmt.Errorf("service %s reports no containers yet", name)
+		}
+	}
+	query := fmt.Sprintf("SELECT SYSTEM$GET_SERVICE_LOGS(%s, 0, %s, %d)", quoteLiteral(qualifiedName(s.cfg, name)), quoteLiteral(containerName), numLines)
+	q, release, err := s.session(ctx, queryRead)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+	rows, err := queryRows(ctx, q, query)
+	if err != nil {
+		return "", fmt.Errorf("query service logs: %w", err)
+	}
+	defer rows.Close()
+
+	var logs sql.NullString
+	if rows.Next() {
+		if err := rows.Scan(&logs); err != nil {
+			return "", fmt.Errorf("scan service logs: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	return logs.String, nil
+}
+
+func parseServiceEvents(raw string, loc *time.Location) ([]models.ServiceEvent, error) {
+	events := []models.ServiceEvent{}
+	if strings.TrimSpace(raw) == "" {
+		return events, nil
+	}
+	var entries []serviceStatusEntry
+	if err := json.Unmarshal([]byte(raw), &entries); err != nil {
+		return nil, fmt.Errorf("parse service status: %w", err)
+	}
+	for _, e := range entries {
+		events = append(events, models.ServiceEvent{
+			Time:      parseSnowflakeTime(e.StartTime, loc),
+			Severity:  eventSeverity(e.Status),
+			Status:    models.NormalizeStatus(e.Status),
+			Message:   e.Message,
+			Container: e.ContainerName,
+			Instance:  e.InstanceID,
+		})
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.After(events[j].Time)
+	})
+	return events, nil
+}
+
+func eventSeverity(status string) string {
+	switch strings.ToUpper(status) {
+	case "FAILED", "INTERNAL_ERROR", "DELETED":
+		return models.SeverityError
+	case "PENDING", "SUSPENDING", "SUSPENDED", "UNKNOWN":
+		return models.SeverityWarning
+	default:
+		return models.SeverityInfo
+	}
+}
+
+// qualifiedName quotes name, qualified by cfg's database and schema when set,
+// for any schema-level object: services, image repositories, and so on.
+func qualifiedName(cfg config.Config, name string) string {
+	if cfg.Database != "" && cfg.Schema != "" {
+		return quoteIdent(cfg.Database) + "." + quoteIdent(cfg.Schema) + "." + quoteIdent(name)
+	}
+	if cfg.Schema != "" {
+		return quoteIdent(cfg.Schema) + "." + quoteIdent(name)
+	}
+	return quoteIdent(name)
+}
+
+// quoteIdent double-quotes a Snowflake identifier, doubling embedded double
+// quotes so the name cannot end the identifier early.
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// quoteLiteral single-quotes a Snowflake string literal. Backslashes are
+// escape characters inside literals, so they are doubled along with quotes.
+func quoteLiteral(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// ExplainListServices returns the statements ListServices would run for cfg,
+// without needing a connection.
+func ExplainListServices(cfg config.Config) []string {
+	return explainRead(cfg, buildShowServicesQuery(cfg))
+}
+
+// ExplainListComputePools returns the statements ListComputePools would run.
+func ExplainListComputePools(cfg config.Config) []string {
+	return explainRead(cfg, "SHOW COMPUTE POOLS")
+}
+
+// ExplainListImageRepositories returns the statements ListImageRepositories
+// would run.
+func ExplainListImageRepositories(cfg config.Config) []string {
+	return explainRead(cfg, buildShowImageReposQuery(cfg))
+}
+
+// explainRead wraps a read query with the warehouse switches session applies.
+func explainRead(cfg config.Config, query string) []string {
+	if cfg.MonitorWarehouse == "" {
+		return []string{query}
+	}
+	statements := []string{"USE WAREHOUSE " + configIdent(cfg.MonitorWarehouse), query}
+	if cfg.Warehouse != "" {
+		statements = append(statements, "USE WAREHOUSE "+configIdent(cfg.Warehouse))
+	}
+	return statements
+}
+
 func buildShowServicesQuery(cfg config.Config) string {
 	if cfg.Database != "" && cfg.Schema != "" {
-		return fmt.Sprintf("SHOW SERVICES IN SCHEMA \"%s\".\"%s\"", cfg.Database, cfg.Schema)
+		return fmt.Sprintf("SHOW SERVICES IN SCHEMA %s.%s", quoteIdent(cfg.Database), quoteIdent(cfg.Schema))
 	}
 	if cfg.Schema != "" {
-		return fmt.Sprintf("SHOW SERVICES IN SCHEMA \"%s\"", cfg.Schema)
+		return "SHOW SERVICES IN SCHEMA " + quoteIdent(cfg.Schema)
 	}
 	return "SHOW SERVICES"
 }
 
+func buildShowJobServicesQuery(cfg config.Config) string {
+	if cfg.Database != "" && cfg.Schema != "" {
+		return fmt.Sprintf("SHOW JOB SERVICES IN SCHEMA %s.%s", quoteIdent(cfg.Database), quoteIdent(cfg.Schema))
+	}
+	if cfg.Schema != "" {
+		return "SHOW JOB SERVICES IN SCHEMA " + quoteIdent(cfg.Schema)
+	}
+	return "SHOW JOB SERVICES"
+}
+
 func buildShowServicesLikeQuery(cfg config.Config, name string) string {
 	if cfg.Database != "" && cfg.Schema != "" {
-		return fmt.Sprintf("SHOW SERVICES LIKE '%s' IN SCHEMA \"%s\".\"%s\"", name, cfg.Database, cfg.Schema)
+		return fmt.Sprintf("SHOW SERVICES LIKE %s IN SCHEMA %s.%s", quoteLiteral(name), quoteIdent(cfg.Database), quoteIdent(cfg.Schema))
 	}
 	if cfg.Schema != "" {
-		return fmt.Sprintf("SHOW SERVICES LIKE '%s' IN SCHEMA \"%s\"", name, cfg.Schema)
+		return fmt.Sprintf("SHOW SERVICES LIKE %s IN SCHEMA %s", quoteLiteral(name), quoteIdent(cfg.Schema))
 	}
-	return fmt.Sprintf("SHOW SERVICES LIKE '%s'", name)
+	return "SHOW SERVICES LIKE " + quoteLiteral(name)
 }
 
 func buildShowImageReposQuery(cfg config.Config) string {
 	if cfg.Database != "" && cfg.Schema != "" {
-		return fmt.Sprintf("SHOW IMAGE REPOSITORIES IN SCHEMA \"%s\".\"%s\"", cfg.Database, cfg.Schema)
+		return fmt.Sprintf("SHOW IMAGE REPOSITORIES IN SCHEMA %s.%s", quoteIdent(cfg.Database), quoteIdent(cfg.Schema))
 	}
 	if cfg.Schema != "" {
-		return fmt.Sprintf("SHOW IMAGE REPOSITORIES IN SCHEMA \"%s\"", cfg.Schema)
+		return "SHOW IMAGE REPOSITORIES IN SCHEMA " + quoteIdent(cfg.Schema)
 	}
 	return "SHOW IMAGE REPOSITORIES"
 }
 
 func buildShowServiceInstancesQuery(cfg config.Config, name string) string {
-	if cfg.Database != "" && cfg.Schema != "" {
-		return fmt.Sprintf("SHOW SERVICE INSTANCES IN SERVICE \"%s\".\"%s\".\"%s\"", cfg.Database, cfg.Schema, name)
+	return fmt.Sprintf("SHOW SERVICE INSTANCES IN SERVICE %s", qualifiedName(cfg, name))
+}
+
+// queryRows runs query on q, attaching the statement to any error.
+func queryRows(ctx context.Context, q Queryable, query string) (*sql.Rows, error) {
+	rows, err := q.QueryContext(ctx, query)
+	if err != nil {
+		return nil, &QueryError{Query: query, Err: err}
 	}
-	if cfg.Schema != "" {
-		return fmt.Sprintf("SHOW SERVICE INSTANCES IN SERVICE \"%s\".\"%s\"", cfg.Schema, name)
+	return rows, nil
+}
+
+// record is a scanned row keyed by normalized column name.
+type record map[string]string
+
+// normalizeColumn folds the case and quoting Snowflake versions return
+// column names in (STATUS, "status") to one lowercase snake_case key.
+func normalizeColumn(name string) string {
+	name = strings.Trim(strings.TrimSpace(name), `"`)
+	return strings.ReplaceAll(strings.ToLower(name), " ", "_")
+}
+
+// get returns the first non-empty value among the given columns.
+func (r record) get(columns ...string) string {
+	for _, col := range columns {
+		if v := strings.TrimSpace(r[normalizeColumn(col)]); v != "" {
+			return v
+		}
 	}
-	return fmt.Sprintf("SHOW SERVICE INSTANCES IN SERVICE \"%s\"", name)
+	return ""
+}
+
+// Attribute is one column of a scanned row, under its normalized name.
+type Attribute struct {
+	Name  string
+	Value string
 }
 
-func scanRowToMap(rows *sql.Rows, cols []string) (map[string]string, error) {
+// columnKeys normalizes cols, suffixing names that collide with an earlier
+// column (name, name_2, name_3) so no value overwrites another.
+func columnKeys(cols []string) []string {
+	keys := make([]string, len(cols))
+	seen := make(map[string]bool, len(cols))
+	for i, col := range cols {
+		key := normalizeColumn(col)
+		for n := 2; seen[key]; n++ {
+			key = fmt.Sprintf("%s_%d", normalizeColumn(col), n)
+		}
+		seen[key] = true
+		keys[i] = key
+	}
+	return keys
+}
+
+func scanRowToMap(rows *sql.Rows, cols []string) (record, error) {
+	attrs, err := scanRowToAttributes(rows, cols)
+	if err != nil {
+		return nil, err
+	}
+	return AttributeMap(attrs), nil
+}
+
+// scanRowToAttributes scans a row into its non-NULL columns, in column order.
+func scanRowToAttributes(rows *sql.Rows, cols []string) ([]Attribute, error) {
 	values := make([]sql.NullString, len(cols))
 	ptrs := make([]any, len(cols))
 	for i := range values {
@@ -254,27 +1227,54 @@ func scanRowToMap(rows *sql.Rows, cols []string) (map[string]string, error) {
 	if err := rows.Scan(ptrs...); err != nil {
 		return nil, err
 	}
-	out := make(map[string]string, len(cols))
-	for i, col := range cols {
+	out := make([]Attribute, 0, len(cols))
+	for i, key := range columnKeys(cols) {
 		if values[i].Valid {
-			out[strings.ToLower(col)] = values[i].String
+			out = append(out, Attribute{Name: key, Value: values[i].String})
 		}
 	}
 	return out, nil
 }
 
-func parseSnowflakeTime(raw string) time.Time {
+// AttributeMap indexes attrs by name, e.g. for JSON output where column order
+// does not matter.
+func AttributeMap(attrs []Attribute) map[string]string {
+	out := make(map[string]string, len(attrs))
+	for _, attr := range attrs {
+		out[attr.Name] = attr.Value
+	}
+	return out
+}
+
+// ParseTime parses the timestamp formats Snowflake returns in SHOW output,
+// reading offsetless values in the session time zone. It returns the zero
+// time when no format matches.
+func (s *SPCS) ParseTime(raw string) time.Time {
+	if s == nil {
+		return parseSnowflakeTime(raw, time.UTC)
+	}
+	return parseSnowflakeTime(raw, s.loc)
+}
+
+func parseSnowflakeTime(raw string, loc *time.Location) time.Time {
 	layouts := []string{
 		time.RFC3339Nano,
 		"2006-01-02 15:04:05.999 -0700",
 		"2006-01-02 15:04:05 -0700",
-		"2006-01-02 15:04:05",
 	}
 	for _, layout := range layouts {
 		if ts, err := time.Parse(layout, raw); err == nil {
 			return ts
 		}
 	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05.999999999", "2006-01-02T15:04:05.999999999"} {
+		if ts, err := time.ParseInLocation(layout, raw, loc); err == nil {
+			return ts
+		}
+	}
 	return time.Time{}
 }
 
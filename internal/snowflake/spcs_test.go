@@ -2,12 +2,17 @@ package snowflake
 
 import (
 	"context"
+	"errors"
 	"os"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/marcelinojackson-org/snow9s/internal/config"
+	"github.com/marcelinojackson-org/snow9s/pkg/models"
+	"github.com/snowflakedb/gosnowflake"
 )
 
 func TestListServices(t *testing.T) {
@@ -18,8 +23,8 @@ func TestListServices(t *testing.T) {
 	defer db.Close()
 
 	cfg := config.Config{Database: "DB", Schema: "PUBLIC"}
-	rows := sqlmock.NewRows([]string{"created_on", "name", "schema_name", "status", "compute_pool"}).
-		AddRow("2024-01-01 00:00:00 -0700", "svc1", "PUBLIC", "RUNNING", "pool1")
+	rows := sqlmock.NewRows([]string{"created_on", "name", "schema_name", "status", "compute_pool", "dns_name", "owner", "min_instances", "max_instances", "spec_digest", "comment"}).
+		AddRow("2024-01-01 00:00:00 -0700", "svc1", "PUBLIC", "RUNNING", "pool1", "svc1.abcd.svc.spcs.internal", "SYSADMIN", "1", "3", "sha256:feed", "billing API")
 
 	mock.ExpectQuery("SHOW SERVICES IN SCHEMA \"DB\".\"PUBLIC\"").WillReturnRows(rows)
 
@@ -37,6 +42,216 @@ func TestListServices(t *testing.T) {
 	if services[0].Age == "" {
 		t.Fatalf("age not set")
 	}
+	if s := services[0]; s.DNSName != "svc1.abcd.svc.spcs.internal" || s.Owner != "SYSADMIN" || s.MinInstances != "1" || s.MaxInstances != "3" || s.SpecDigest != "sha256:feed" || s.Comment != "billing API" {
+		t.Fatalf("wide fields not set: %+v", s)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestListServicesAllSchemas(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	// The same service name lives in two schemas.
+	mock.ExpectQuery(regexp.QuoteMeta(`SHOW SERVICES IN DATABASE "DB"`)).WillReturnRows(
+		sqlmock.NewRows([]string{"name", "schema_name", "status"}).
+			AddRow("svc", "PUBLIC", "RUNNING").
+			AddRow("svc", "STAGING", "FAILED"))
+	mock.ExpectQuery(regexp.QuoteMeta(`ALTER SERVICE "DB"."STAGING"."svc" SUSPEND`)).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("Statement executed successfully."))
+	mock.ExpectQuery(regexp.QuoteMeta(`ALTER SERVICE "DB"."PUBLIC"."svc" SUSPEND`)).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("Statement executed successfully."))
+
+	spcs := NewSPCS(db, config.Config{Database: "DB", Schema: "PUBLIC"})
+	spcs.SetAllSchemas(true)
+	if !spcs.AllSchemas() {
+		t.Fatalf("expected all schemas mode")
+	}
+	services, err := spcs.ListServices(context.Background())
+	if err != nil {
+		t.Fatalf("ListServices: %v", err)
+	}
+	if len(services) != 2 || services[0].Namespace != "PUBLIC" || services[1].Namespace != "STAGING" {
+		t.Fatalf("unexpected services: %+v", services)
+	}
+	for _, service := range []models.Service{services[1], services[0]} {
+		if err := spcs.InSchema(service.Namespace).SuspendService(context.Background(), service.Name); err != nil {
+			t.Fatalf("SuspendService in %s: %v", service.Namespace, err)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+	if got := qualifiedName(spcs.InSchema("").cfg, "svc"); got != `"DB"."PUBLIC"."svc"` {
+		t.Fatalf("an empty schema should keep the active one, got %s", got)
+	}
+}
+
+func TestListServicesByStatus(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SHOW SERVICES IN SCHEMA").WillReturnRows(
+		sqlmock.NewRows([]string{"name", "status"}).
+			AddRow("svc1", "RUNNING").
+			AddRow("svc2", "FAILED").
+			AddRow("svc3", "RUNNING"))
+
+	spcs := NewSPCS(db, config.Config{Database: "DB", Schema: "PUBLIC", CacheTTL: 10})
+	services, err := spcs.ListServicesByStatus(context.Background(), " Running")
+	if err != nil {
+		t.Fatalf("ListServicesByStatus: %v", err)
+	}
+	if len(services) != 2 || services[0].Name != "svc1" || services[1].Name != "svc3" {
+		t.Fatalf("unexpected services: %+v", services)
+	}
+
+	// The cache holds the full listing, so other filters need no query.
+	if services, err = spcs.ListServicesByStatus(context.Background(), "failed"); err != nil || len(services) != 1 {
+		t.Fatalf("failed services: %+v, %v", services, err)
+	}
+	if services, err = spcs.ListServices(context.Background()); err != nil || len(services) != 3 {
+		t.Fatalf("all services: %+v, %v", services, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestListMixedCaseColumns(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	cfg := config.Config{Database: "DB", Schema: "PUBLIC"}
+	mock.ExpectQuery("SHOW SERVICES IN SCHEMA").WillReturnRows(
+		sqlmock.NewRows([]string{"CREATED_ON", "NAME", "Schema_Name", "STATUS", `"COMPUTE_POOL"`}).
+			AddRow("2024-01-01 00:00:00 -0700", "SVC1", "APP", "RUNNING", "POOL1"))
+	mock.ExpectQuery("SHOW COMPUTE POOLS").WillReturnRows(
+		sqlmock.NewRows([]string{"Name", "STATE", "MIN_NODES", "Max_Nodes", "INSTANCE_FAMILY", "ACTIVE_NODES", "AUTO_RESUME"}).
+			AddRow("POOL1", "ACTIVE", "1", "3", "CPU_X64_XS", "2", "TRUE"))
+
+	spcs := NewSPCS(db, cfg)
+	services, err := spcs.ListServices(context.Background())
+	if err != nil {
+		t.Fatalf("ListServices: %v", err)
+	}
+	want := models.Service{Name: "SVC1", Namespace: "APP", Status: "running", ComputePool: "POOL1"}
+	got := services[0]
+	if got.Name != want.Name || got.Namespace != want.Namespace || got.Status != want.Status || got.ComputePool != want.ComputePool {
+		t.Fatalf("unexpected service: %+v", got)
+	}
+	if got.CreatedAt.IsZero() {
+		t.Fatalf("created_on not mapped")
+	}
+
+	pools, err := spcs.ListComputePools(context.Background())
+	if err != nil {
+		t.Fatalf("ListComputePools: %v", err)
+	}
+	pool := pools[0]
+	if pool.Name != "POOL1" || pool.State != "active" || pool.MinNodes != "1" || pool.MaxNodes != "3" ||
+		pool.InstanceFamily != "CPU_X64_XS" || pool.ActiveNodes != "2" || !pool.AutoResume {
+		t.Fatalf("unexpected pool: %+v", pool)
+	}
+	if pool.MinNodeCount != 1 || pool.MaxNodeCount != 3 || pool.ActiveNodeCount != 2 {
+		t.Fatalf("node counts not parsed: %+v", pool)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestListDocumentedColumns(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SHOW IMAGE REPOSITORIES IN SCHEMA").WillReturnRows(
+		sqlmock.NewRows([]string{"CREATED_ON", "NAME", "DATABASE_NAME", "SCHEMA_NAME", "REPOSITORY_URL", "OWNER", "OWNER_ROLE_TYPE", "COMMENT"}).
+			AddRow("2024-01-01 00:00:00 +0000", "REPO1", "DB", "PUBLIC", "org-acct.registry.snowflakecomputing.com/db/public/repo1", "SYSADMIN", "ROLE", ""))
+	mock.ExpectQuery("SHOW DATABASES").WillReturnRows(
+		sqlmock.NewRows([]string{"created_on", "name", "is_default", "is_current", "origin", "owner", "comment", "options", "retention_time", "kind"}).
+			AddRow("2024-01-01 00:00:00 +0000", "DB", "N", "Y", "", "SYSADMIN", "", "", "1", "STANDARD"))
+	mock.ExpectQuery("SHOW SERVICE INSTANCES IN SERVICE").WillReturnRows(
+		sqlmock.NewRows([]string{"DATABASE_NAME", "SCHEMA_NAME", "SERVICE_NAME", "SERVICE_STATUS", "INSTANCE_ID", "STATUS", "SPEC_DIGEST", "CREATION_TIME", "START_TIME"}).
+			AddRow("DB", "PUBLIC", "API", "RUNNING", "0", "READY", "abc", "2024-01-01 00:00:00 +0000", "2024-01-01 00:01:00 +0000"))
+
+	spcs := NewSPCS(db, config.Config{Database: "DB", Schema: "PUBLIC"})
+	ctx := context.Background()
+	repos, err := spcs.ListImageRepositories(ctx)
+	if err != nil {
+		t.Fatalf("ListImageRepositories: %v", err)
+	}
+	if r := repos[0]; r.Name != "REPO1" || r.Owner != "SYSADMIN" || !strings.HasSuffix(r.RepositoryURL, "/repo1") || r.CreatedAt.IsZero() {
+		t.Fatalf("unexpected repository: %+v", r)
+	}
+	dbs, err := spcs.ListDatabases(ctx)
+	if err != nil {
+		t.Fatalf("ListDatabases: %v", err)
+	}
+	if d := dbs[0]; d.Name != "DB" || d.Owner != "SYSADMIN" || d.CreatedAt.IsZero() {
+		t.Fatalf("unexpected database: %+v", d)
+	}
+	instances, err := spcs.ListServiceInstances(ctx, "API")
+	if err != nil {
+		t.Fatalf("ListServiceInstances: %v", err)
+	}
+	// SHOW SERVICE INSTANCES names its instances by instance_id and reports
+	// the service's own status separately.
+	if inst := instances[0]; inst.Name != "0" || inst.Status != "ready" {
+		t.Fatalf("unexpected instance: %+v", inst)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestListJobs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(`SHOW JOB SERVICES IN SCHEMA "DB"."PUBLIC"`).WillReturnRows(
+		sqlmock.NewRows([]string{"name", "status", "compute_pool", "created_on", "updated_on"}).
+			AddRow("train", "DONE", "gpu", "2024-01-01 00:00:00 +0000", "2024-01-01 02:30:00 +0000").
+			AddRow("etl", "RUNNING", "cpu", "2024-01-02 00:00:00 +0000", "2024-01-02 00:05:00 +0000"))
+
+	spcs := NewSPCS(db, config.Config{Database: "DB", Schema: "PUBLIC"})
+	jobs, err := spcs.ListJobs(context.Background())
+	if err != nil {
+		t.Fatalf("ListJobs: %v", err)
+	}
+	if len(jobs) != 2 {
+		t.Fatalf("expected 2 jobs got %d", len(jobs))
+	}
+	done := jobs[0]
+	if done.Name != "train" || done.Status != "done" || done.Namespace != "PUBLIC" || done.ComputePool != "gpu" {
+		t.Fatalf("unexpected job: %+v", done)
+	}
+	if want := time.Date(2024, 1, 1, 2, 30, 0, 0, time.UTC); !done.CompletedAt.Equal(want) {
+		t.Fatalf("expected completion at %s got %s", want, done.CompletedAt)
+	}
+	if !jobs[1].CompletedAt.IsZero() {
+		t.Fatalf("a running job has no completion time: %+v", jobs[1])
+	}
+	if done.Age == "" || jobs[1].Age == "" {
+		t.Fatalf("ages not set: %+v", jobs)
+	}
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Fatalf("expectations: %v", err)
 	}
@@ -66,6 +281,252 @@ func TestListServicesEmpty(t *testing.T) {
 	}
 }
 
+func TestListServicesUsesMonitorWarehouse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	cfg := config.Config{Schema: "PUBLIC", Warehouse: "WH", MonitorWarehouse: "MON"}
+	mock.ExpectExec("USE WAREHOUSE MON").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SHOW SERVICES IN SCHEMA \"PUBLIC\"").WillReturnRows(sqlmock.NewRows([]string{"name"}))
+	mock.ExpectExec("USE WAREHOUSE WH").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	spcs := NewSPCS(db, cfg)
+	if _, err := spcs.ListServices(context.Background()); err != nil {
+		t.Fatalf("ListServices: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestGetServiceLogsResolvesContainer(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	cfg := config.Config{Database: "DB", Schema: "PUBLIC"}
+	status := `[{"status":"READY","containerName":"main","instanceId":"0","startTime":"2024-01-01T00:00:00Z"}]`
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT SYSTEM$GET_SERVICE_STATUS('"DB"."PUBLIC"."svc"')`)).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(status))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT SYSTEM$GET_SERVICE_LOGS('"DB"."PUBLIC"."svc"', 0, 'main', 100)`)).
+		WillReturnRows(sqlmock.NewRows([]string{"logs"}).AddRow("listening on :8080\n"))
+
+	logs, err := NewSPCS(db, cfg).GetServiceLogs(context.Background(), "svc", "", 100)
+	if err != nil {
+		t.Fatalf("GetServiceLogs: %v", err)
+	}
+	if logs != "listening on :8080\n" {
+		t.Fatalf("unexpected logs %q", logs)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestParseServiceEvents(t *testing.T) {
+	raw := `[{"status":"READY","message":"Running","containerName":"main","instanceId":"0","startTime":"2024-01-01T00:00:00Z"},
+	{"status":"FAILED","message":"Crash loop","containerName":"main","instanceId":"1","startTime":"2024-01-02T00:00:00Z"}]`
+	events, err := parseServiceEvents(raw, time.UTC)
+	if err != nil {
+		t.Fatalf("parseServiceEvents: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events got %d", len(events))
+	}
+	if events[0].Instance != "1" || events[0].Severity != "error" {
+		t.Fatalf("expected newest failed event first: %+v", events[0])
+	}
+	if events[1].Severity != "info" || events[1].Status != "ready" {
+		t.Fatalf("unexpected ready event: %+v", events[1])
+	}
+	if _, err := parseServiceEvents("not json", time.UTC); err == nil {
+		t.Fatalf("expected parse error for malformed status")
+	}
+}
+
+func TestParseContainerStatuses(t *testing.T) {
+	raw := `[
+		{"status":"READY","message":"Running","containerName":"sidecar","instanceId":"0","restartCount":0,"startTime":"2024-01-01T00:00:00Z"},
+		{"status":"pending","message":"Waiting for compute pool nodes","containerName":"main","instanceId":"0","restartCount":3}
+	]`
+	statuses, err := parseContainerStatuses(raw, time.UTC)
+	if err != nil {
+		t.Fatalf("parseContainerStatuses: %v", err)
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("expected 2 statuses got %d", len(statuses))
+	}
+	pending := statuses[0]
+	if pending.ContainerName != "main" || pending.Status != "PENDING" || pending.RestartCount != 3 || pending.Message != "Waiting for compute pool nodes" || !pending.StartTime.IsZero() {
+		t.Fatalf("unexpected status: %+v", pending)
+	}
+	if statuses[1].StartTime.IsZero() {
+		t.Fatalf("start time not parsed: %+v", statuses[1])
+	}
+	if _, err := parseContainerStatuses("not json", time.UTC); err == nil {
+		t.Fatalf("expected error for invalid JSON")
+	}
+}
+
+func TestParseSnowflakeTimeUsesSessionZone(t *testing.T) {
+	pacific := time.FixedZone("UTC-07:00", -7*60*60)
+	got := parseSnowflakeTime("2024-01-01 10:00:00.000", pacific)
+	if want := time.Date(2024, 1, 1, 17, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("offsetless timestamp read as %v, want %v", got, want)
+	}
+	got = parseSnowflakeTime("2024-01-01 10:00:00 +0000", pacific)
+	if want := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("explicit offset ignored: got %v, want %v", got, want)
+	}
+}
+
+func TestDescribeServiceExactMatchAndNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	cfg := config.Config{Database: "DB", Schema: "PUBLIC"}
+	mock.ExpectQuery("SHOW SERVICES LIKE 'my_svc' IN SCHEMA \"DB\".\"PUBLIC\"").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "status"}).AddRow("MYXSVC", "RUNNING").AddRow("MY_SVC", "SUSPENDED"))
+	mock.ExpectQuery("SHOW SERVICES LIKE 'gone'").WillReturnRows(sqlmock.NewRows([]string{"name", "status"}))
+
+	spcs := NewSPCS(db, cfg)
+	rec, err := spcs.DescribeService(context.Background(), "my_svc")
+	if err != nil || rec["status"] != "SUSPENDED" {
+		t.Fatalf("expected exact match, got %v %v", rec, err)
+	}
+	if _, err := spcs.DescribeService(context.Background(), "gone"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestGetServiceSpec(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	cfg := config.Config{Database: "DB", Schema: "PUBLIC"}
+	mock.ExpectQuery(`DESCRIBE SERVICE "DB"."PUBLIC"."API"`).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "spec"}).AddRow("API", "spec:\n  containers: []\n"))
+	mock.ExpectQuery(`DESCRIBE SERVICE "DB"."PUBLIC"."EMPTY"`).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "spec"}))
+
+	spcs := NewSPCS(db, cfg)
+	spec, err := spcs.GetServiceSpec(context.Background(), "API")
+	if err != nil || !strings.HasPrefix(spec, "spec:") {
+		t.Fatalf("expected the raw spec, got %q %v", spec, err)
+	}
+	if spec, err := spcs.GetServiceSpec(context.Background(), "EMPTY"); err != nil || spec != "" {
+		t.Fatalf("expected no spec without an error, got %q %v", spec, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestDescribeServiceAttributesKeepsOrderAndDuplicates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	cfg := config.Config{Database: "DB", Schema: "PUBLIC"}
+	mock.ExpectQuery("SHOW SERVICES LIKE 'api'").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "status", "Status", "compute_pool", "comment"}).
+			AddRow("API", "RUNNING", "READY", "POOL1", nil))
+
+	attrs, err := NewSPCS(db, cfg).DescribeServiceAttributes(context.Background(), "api")
+	if err != nil {
+		t.Fatalf("describe: %v", err)
+	}
+	want := []Attribute{{"name", "API"}, {"status", "RUNNING"}, {"status_2", "READY"}, {"compute_pool", "POOL1"}}
+	if len(attrs) != len(want) {
+		t.Fatalf("expected %v got %v", want, attrs)
+	}
+	for i := range want {
+		if attrs[i] != want[i] {
+			t.Fatalf("attribute %d: expected %v got %v", i, want[i], attrs[i])
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestColumnKeysSuffixCollisions(t *testing.T) {
+	got := columnKeys([]string{"NAME", "name", `"Name"`, "name_2", "Created On"})
+	want := []string{"name", "name_2", "name_3", "name_2_2", "created_on"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v got %v", want, got)
+	}
+}
+
+func TestQueryBuildersEscapeNames(t *testing.T) {
+	cfg := config.Config{Database: `we"ird`, Schema: "PUBLIC"}
+	cases := []struct {
+		got, want string
+	}{
+		{buildShowServicesQuery(cfg), `SHOW SERVICES IN SCHEMA "we""ird"."PUBLIC"`},
+		{buildShowServicesLikeQuery(cfg, "my'svc"), `SHOW SERVICES LIKE 'my''svc' IN SCHEMA "we""ird"."PUBLIC"`},
+		{buildShowImageReposQuery(config.Config{Schema: `a"b`}), `SHOW IMAGE REPOSITORIES IN SCHEMA "a""b"`},
+		{buildShowServiceInstancesQuery(cfg, `we"ird`), `SHOW SERVICE INSTANCES IN SERVICE "we""ird"."PUBLIC"."we""ird"`},
+		{quoteLiteral(`it's \ here`), `'it''s \\ here'`},
+	}
+	for _, tc := range cases {
+		if tc.got != tc.want {
+			t.Fatalf("expected %s got %s", tc.want, tc.got)
+		}
+	}
+}
+
+func TestListSchemas(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"created_on", "name", "database_name", "owner"}).
+		AddRow("2024-01-01 00:00:00 -0700", "PUBLIC", "DB", "SYSADMIN")
+	mock.ExpectQuery("SHOW SCHEMAS IN DATABASE \"DB\"").WillReturnRows(rows)
+
+	spcs := NewSPCS(db, config.Config{})
+	schemas, err := spcs.ListSchemas(context.Background(), "DB")
+	if err != nil {
+		t.Fatalf("ListSchemas: %v", err)
+	}
+	if len(schemas) != 1 || schemas[0].Name != "PUBLIC" || schemas[0].Owner != "SYSADMIN" || schemas[0].Age == "" {
+		t.Fatalf("unexpected schemas: %+v", schemas)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestExplainListServices(t *testing.T) {
+	got := ExplainListServices(config.Config{Database: "DB", Schema: "my schema"})
+	if len(got) != 1 || got[0] != `SHOW SERVICES IN SCHEMA "DB"."my schema"` {
+		t.Fatalf("unexpected statements: %q", got)
+	}
+	got = ExplainListServices(config.Config{Schema: "PUBLIC", Warehouse: "WH", MonitorWarehouse: "MON"})
+	if len(got) != 3 || got[0] != `USE WAREHOUSE MON` || got[2] != `USE WAREHOUSE WH` {
+		t.Fatalf("unexpected monitor statements: %q", got)
+	}
+}
+
 // Integration test, skipped when credentials are absent.
 func TestIntegrationSnowflakePing(t *testing.T) {
 	required := []string{"SNOWFLAKE_ACCOUNT", "SNOWFLAKE_USER", "SNOWFLAKE_PASSWORD"}
@@ -94,3 +555,232 @@ func TestIntegrationSnowflakePing(t *testing.T) {
 		t.Fatalf("ping: %v", err)
 	}
 }
+
+func TestSuspendResumeService(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	spcs := NewSPCS(db, config.Config{Database: "DB", Schema: "PUBLIC"})
+	mock.ExpectQuery(regexp.QuoteMeta(`ALTER SERVICE "DB"."PUBLIC"."svc" SUSPEND`)).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("Statement executed successfully."))
+	if err := spcs.SuspendService(context.Background(), "svc"); err != nil {
+		t.Fatalf("SuspendService: %v", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`ALTER SERVICE "DB"."PUBLIC"."svc" RESUME`)).
+		WillReturnError(&gosnowflake.SnowflakeError{Number: 3001, SQLState: "42501", Message: "Insufficient privileges to operate on service 'SVC'"})
+	err = spcs.ResumeService(context.Background(), "svc")
+	if err == nil || !strings.Contains(err.Error(), "Insufficient privileges") {
+		t.Fatalf("expected privilege error, got %v", err)
+	}
+	if query, _ := ErrorDetails(err); !strings.Contains(query, "RESUME") {
+		t.Fatalf("expected failing query in error details, got %q", query)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestDropService(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`DROP SERVICE IF EXISTS "DB"."PUBLIC"."svc"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("SVC successfully dropped."))
+	if err := NewSPCS(db, config.Config{Database: "DB", Schema: "PUBLIC"}).DropService(context.Background(), "svc"); err != nil {
+		t.Fatalf("DropService: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestAlterComputePool(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	spcs := NewSPCS(db, config.Config{})
+	mock.ExpectQuery(regexp.QuoteMeta(`ALTER COMPUTE POOL "POOL" SET MIN_NODES = 1 MAX_NODES = 3`)).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("Statement executed successfully."))
+	if err := spcs.AlterComputePool(context.Background(), "POOL", 1, 3); err != nil {
+		t.Fatalf("AlterComputePool: %v", err)
+	}
+	if err := spcs.AlterComputePool(context.Background(), "POOL", 4, 2); err == nil {
+		t.Fatal("expected min > max to be rejected before querying")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestDescribeComputePoolDetail(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	cols := []string{"name", "state", "min_nodes", "max_nodes", "instance_family", "num_services", "num_jobs", "auto_suspend_secs", "auto_resume", "active_nodes", "idle_nodes", "target_nodes", "created_on", "application"}
+	mock.ExpectQuery(regexp.QuoteMeta(`DESCRIBE COMPUTE POOL "POOL"`)).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow("POOL", "ACTIVE", "1", "4", "CPU_X64_XS", "2", "0", "3600", "true", "2", "1", "3", "2024-01-02 03:04:05.000 -0800", ""))
+	mock.ExpectQuery(regexp.QuoteMeta(`DESCRIBE COMPUTE POOL "MISSING"`)).
+		WillReturnRows(sqlmock.NewRows(cols))
+
+	spcs := NewSPCS(db, config.Config{})
+	detail, err := spcs.GetComputePoolDetail(context.Background(), "POOL")
+	if err != nil {
+		t.Fatalf("GetComputePoolDetail: %v", err)
+	}
+	if detail.State != "active" || detail.MinNodes != 1 || detail.MaxNodes != 4 || detail.ActiveNodes != 2 || detail.IdleNodes != 1 || detail.TargetNodes != 3 {
+		t.Fatalf("unexpected detail %+v", detail)
+	}
+	if detail.PendingNodes() != 1 || detail.AutoSuspendSecs != 3600 || !detail.AutoResume || detail.NumServices != 2 {
+		t.Fatalf("unexpected detail %+v", detail)
+	}
+	if want := time.Date(2024, 1, 2, 11, 4, 5, 0, time.UTC); !detail.CreatedOn.Equal(want) {
+		t.Fatalf("created_on = %v, want %v", detail.CreatedOn, want)
+	}
+
+	if _, err := spcs.DescribeComputePool(context.Background(), "MISSING"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestListServiceEndpoints(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SHOW ENDPOINTS IN SERVICE "DB"."PUBLIC"."svc"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "port", "port_range", "protocol", "is_public", "ingress_url"}).
+			AddRow("web", "8000", "", "http", "true", "abc-org-acct.snowflakecomputing.app").
+			AddRow("metrics", "", "9000-9010", "tcp", "false", ""))
+
+	endpoints, err := NewSPCS(db, config.Config{Database: "DB", Schema: "PUBLIC"}).ListServiceEndpoints(context.Background(), "svc")
+	if err != nil {
+		t.Fatalf("ListServiceEndpoints: %v", err)
+	}
+	want := []models.Endpoint{
+		{Name: "web", Port: "8000", Protocol: "HTTP", Public: true, IngressURL: "abc-org-acct.snowflakecomputing.app"},
+		{Name: "metrics", Port: "9000-9010", Protocol: "TCP"},
+	}
+	if len(endpoints) != len(want) || endpoints[0] != want[0] || endpoints[1] != want[1] {
+		t.Fatalf("unexpected endpoints %+v", endpoints)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestLoadOverviewKeepsPartialResults(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	mock.MatchExpectationsInOrder(false)
+
+	mock.ExpectQuery("SHOW SERVICES").WillReturnRows(sqlmock.NewRows([]string{"name", "status"}).
+		AddRow("svc1", "RUNNING").AddRow("svc2", "SUSPENDED"))
+	mock.ExpectQuery("SHOW COMPUTE POOLS").WillReturnError(errors.New("insufficient privileges"))
+	mock.ExpectQuery("SHOW IMAGE REPOSITORIES").WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("repo1"))
+
+	overview, err := NewSPCS(db, config.Config{Database: "DB", Schema: "PUBLIC"}).LoadOverview(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "insufficient privileges") {
+		t.Fatalf("expected the pool failure to be returned, got %v", err)
+	}
+	if len(overview.Services) != 2 || len(overview.Repositories) != 1 {
+		t.Fatalf("expected partial results, got %+v", overview)
+	}
+	if overview.PoolsErr == nil || overview.ServicesErr != nil || overview.RepositoriesErr != nil {
+		t.Fatalf("unexpected per-type errors: %+v", overview)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestListResultsCachedWithinTTL(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	spcs := NewSPCS(db, config.Config{CacheTTL: 10})
+	spcs.cache.now = func() time.Time { return now }
+	pools := func() *sqlmock.Rows { return sqlmock.NewRows([]string{"name"}).AddRow("POOL1") }
+
+	mock.ExpectQuery("SHOW COMPUTE POOLS").WillReturnRows(pools())
+	for i := 0; i < 2; i++ {
+		if got, err := spcs.ListComputePools(context.Background()); err != nil || len(got) != 1 {
+			t.Fatalf("call %d: %v %v", i, got, err)
+		}
+	}
+
+	// Manual refresh, expiry, and mutations each force a new query.
+	spcs.InvalidateCache()
+	mock.ExpectQuery("SHOW COMPUTE POOLS").WillReturnRows(pools())
+	if _, err := spcs.ListComputePools(context.Background()); err != nil {
+		t.Fatalf("after invalidate: %v", err)
+	}
+	now = now.Add(11 * time.Second)
+	mock.ExpectQuery("SHOW COMPUTE POOLS").WillReturnRows(pools())
+	if _, err := spcs.ListComputePools(context.Background()); err != nil {
+		t.Fatalf("after expiry: %v", err)
+	}
+	mock.ExpectQuery("ALTER COMPUTE POOL").WillReturnRows(sqlmock.NewRows([]string{"status"}))
+	if err := spcs.AlterComputePool(context.Background(), "POOL1", 1, 2); err != nil {
+		t.Fatalf("alter: %v", err)
+	}
+	mock.ExpectQuery("SHOW COMPUTE POOLS").WillReturnRows(pools())
+	if _, err := spcs.ListComputePools(context.Background()); err != nil {
+		t.Fatalf("after mutation: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+}
+
+func TestListImageTags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	rows := sqlmock.NewRows([]string{"created_on", "image_name", "tags", "digest", "image_path"}).
+		AddRow("2024-01-01 00:00:00 -0700", "api", `["v1","latest"]`, "sha256:abc", "db/public/repo/api").
+		AddRow("2024-01-02 00:00:00 -0700", "worker", "", "sha256:def", "db/public/repo/worker")
+	mock.ExpectQuery(regexp.QuoteMeta(`SHOW IMAGES IN IMAGE REPOSITORY "DB"."PUBLIC"."REPO"`)).WillReturnRows(rows)
+
+	tags, err := NewSPCS(db, config.Config{Database: "DB", Schema: "PUBLIC"}).ListImageTags(context.Background(), "REPO")
+	if err != nil {
+		t.Fatalf("ListImageTags: %v", err)
+	}
+	if len(tags) != 3 {
+		t.Fatalf("expected one row per tag plus the untagged image, got %+v", tags)
+	}
+	if tags[0].Image != "api" || tags[0].Tag != "v1" || tags[1].Tag != "latest" || tags[1].Digest != "sha256:abc" {
+		t.Fatalf("unexpected tags: %+v", tags[:2])
+	}
+	if tags[2].Image != "worker" || tags[2].Tag != "" || tags[2].Path != "db/public/repo/worker" || tags[2].CreatedAt.IsZero() {
+		t.Fatalf("unexpected untagged image: %+v", tags[2])
+	}
+	if got := parseImageTags("v1, v2"); len(got) != 2 || got[1] != "v2" {
+		t.Fatalf("comma-separated tags not parsed: %v", got)
+	}
+}
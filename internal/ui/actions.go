@@ -0,0 +1,271 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/marcelinojackson-org/snow9s/internal/snowflake"
+)
+
+// mutation is a user-initiated change to a resource that can be repeated on
+// another selection with '.', vim-style.
+// pending, if set, is the optimistic status shown on the target while the
+// mutation is in flight (e.g. SUSPENDING). typedConfirm additionally requires
+// the target's name to be typed before it runs, and removes marks mutations
+// that delete the target so its row goes away without waiting for a refresh.
+// run is given the client addressing the target's schema.
+type mutation struct {
+	name         string
+	view         viewKind
+	pending      string
+	typedConfirm bool
+	removes      bool
+	run          func(ctx context.Context, spcs *snowflake.SPCS, target string) error
+}
+
+// runMutation executes m against target in namespace off the UI goroutine,
+// remembers it for '.', and refreshes the view once Snowflake has applied the
+// change. Background refreshes pause while it runs. It must be called on the
+// UI goroutine.
+func (a *App) runMutation(m mutation, namespace, target string) {
+	a.lastMutation = &m
+	a.beginMutation(target, m.pending)
+	spcs := a.spcsIn(namespace)
+	go func() {
+		ctx, cancel := context.WithTimeout(a.rootContext(), a.cfg.Timeout())
+		defer cancel()
+		err := m.run(ctx, spcs, target)
+		a.safeUpdate(func() {
+			a.endMutation(target)
+			if err != nil {
+				msg := fmt.Sprintf("%s %s failed: %s", m.name, target, withHint(err))
+				a.recordError(msg, err)
+				a.setError(msg)
+				return
+			}
+			a.setError("")
+			if m.removes {
+				a.removeRow(target)
+			}
+			a.fetchCurrentView(a.rootContext())
+		})
+	}()
+}
+
+// beginMutation marks a mutation in flight, invalidating fetches already
+// running, and shows its pending marker on target.
+func (a *App) beginMutation(target, status string) {
+	a.refreshMu.Lock()
+	a.mutations++
+	a.fetchGen++
+	a.refreshMu.Unlock()
+	if status != "" {
+		a.pending[target] = status
+		a.redraw()
+	}
+}
+
+// endMutation clears target's pending marker and lets refreshes resume.
+func (a *App) endMutation(target string) {
+	a.refreshMu.Lock()
+	a.mutations--
+	a.fetchGen++
+	a.refreshMu.Unlock()
+	if _, ok := a.pending[target]; ok {
+		delete(a.pending, target)
+		a.redraw()
+	}
+}
+
+// fetchGeneration returns the current fetch generation and whether a
+// mutation is in flight. Mutations and view switches bump the generation so
+// results of fetches started before them are discarded.
+func (a *App) fetchGeneration() (uint64, bool) {
+	a.refreshMu.Lock()
+	defer a.refreshMu.Unlock()
+	return a.fetchGen, a.mutations > 0
+}
+
+// markPending overlays pending mutation markers on the status column.
+func (a *App) markPending(rows []TableRow) []TableRow {
+	col := a.table.StatusColumn()
+	if len(a.pending) == 0 || col < 0 {
+		return rows
+	}
+	nameCol := a.nameColumn()
+	for i, row := range rows {
+		if row.Group != "" || nameCol >= len(row.Cells) || col >= len(row.Cells) {
+			continue
+		}
+		if status, ok := a.pending[row.Cells[nameCol]]; ok {
+			cells := append([]string(nil), row.Cells...)
+			cells[col] = status
+			rows[i].Cells = cells
+		}
+	}
+	return rows
+}
+
+// removeRow drops target's row from the loaded rows and redraws.
+func (a *App) removeRow(target string) {
+	nameCol := a.nameColumn()
+	kept := make([]TableRow, 0, len(a.rows))
+	for _, row := range a.rows {
+		if row.Group == "" && nameCol < len(row.Cells) && row.Cells[nameCol] == target {
+			continue
+		}
+		kept = append(kept, row)
+	}
+	a.rows = kept
+	a.redraw()
+}
+
+// redraw re-renders the last loaded rows with current overlays.
+func (a *App) redraw() {
+	a.table.SetData(a.table.Headers(), a.displayRows(cloneRows(a.rows)))
+}
+
+// repeatLastMutation re-dispatches the previous mutation on the current
+// selection after confirmation.
+func (a *App) repeatLastMutation() {
+	m := a.lastMutation
+	if m == nil {
+		a.footer.SetStatus(fmt.Sprintf("%s  no previous action to repeat", a.table.SelectionInfo()))
+		return
+	}
+	if m.view != a.view {
+		a.showError(fmt.Sprintf("Last action (%s) applies to the %s view", m.name, m.view))
+		return
+	}
+	target := a.selectedName()
+	if target == "" {
+		a.showError(fmt.Sprintf("Select a %s target first", m.name))
+		return
+	}
+	a.confirmMutation(*m, target, fmt.Sprintf("Repeat %s on %s?", m.name, target))
+}
+
+// suspendService asks to suspend the selected service.
+func (a *App) suspendService() {
+	a.serviceAction(mutation{
+		name:    "suspend",
+		view:    viewServices,
+		pending: "SUSPENDING",
+		run: func(ctx context.Context, spcs *snowflake.SPCS, target string) error {
+			return spcs.SuspendService(ctx, target)
+		},
+	})
+}
+
+// resumeService asks to resume the selected service.
+func (a *App) resumeService() {
+	a.serviceAction(mutation{
+		name:    "resume",
+		view:    viewServices,
+		pending: "RESUMING",
+		run: func(ctx context.Context, spcs *snowflake.SPCS, target string) error {
+			return spcs.ResumeService(ctx, target)
+		},
+	})
+}
+
+// dropService asks to drop the selected service; the name must be typed
+// to confirm.
+func (a *App) dropService() {
+	a.serviceAction(mutation{
+		name:         "drop",
+		view:         viewServices,
+		pending:      "DROPPING",
+		typedConfirm: true,
+		removes:      true,
+		run: func(ctx context.Context, spcs *snowflake.SPCS, target string) error {
+			return spcs.DropService(ctx, target)
+		},
+	})
+}
+
+// serviceAction confirms m against the selected service and runs it.
+func (a *App) serviceAction(m mutation) {
+	if a.view != viewServices {
+		return
+	}
+	target := a.selectedName()
+	if target == "" {
+		a.showError(fmt.Sprintf("Select a service to %s", m.name))
+		return
+	}
+	a.confirmMutation(m, target, fmt.Sprintf("%s service %s?", strings.ToUpper(m.name[:1])+m.name[1:], target))
+}
+
+// confirmMutation asks message and runs m on target, the selected row, once
+// accepted. Mutations with typedConfirm then also ask for the target's name to
+// be typed. With skip_confirmations set only that typed name is asked for.
+func (a *App) confirmMutation(m mutation, target, message string) {
+	namespace := a.selectedNamespace()
+	if a.cfg.SkipConfirmations && !m.typedConfirm {
+		a.runMutation(m, namespace, target)
+		return
+	}
+	ConfirmModal(a, message, func() {
+		if !m.typedConfirm {
+			a.runMutation(m, namespace, target)
+			return
+		}
+		a.typedConfirm = &typedConfirmation{target: target, onConfirm: func() {
+			a.runMutation(m, namespace, target)
+		}}
+		a.activateInput(inputConfirmName, fmt.Sprintf("type %s to %s: ", target, m.name))
+	})
+}
+
+// typedConfirmation is a pending confirmation that needs target typed in the
+// input bar.
+type typedConfirmation struct {
+	target    string
+	onConfirm func()
+}
+
+// selectedName returns the resource name of the selected row in the active view.
+func (a *App) selectedName() string {
+	row, ok := a.table.SelectedRow()
+	if !ok || row.Group != "" {
+		return ""
+	}
+	col := a.nameColumn()
+	if col >= len(row.Cells) {
+		return ""
+	}
+	return row.Cells[col]
+}
+
+// selectedNamespace returns the schema of the selected row in views whose rows
+// lead with one, or "" elsewhere.
+func (a *App) selectedNamespace() string {
+	row, ok := a.table.SelectedRow()
+	if !ok || row.Group != "" || a.nameColumn() != 1 || len(row.Cells) == 0 {
+		return ""
+	}
+	return row.Cells[0]
+}
+
+// spcsIn returns the client that addresses services in namespace, so a
+// service listed across the database is reached in its own schema rather
+// than the active one.
+func (a *App) spcsIn(namespace string) *snowflake.SPCS {
+	if a.spcs == nil || namespace == "" {
+		return a.spcs
+	}
+	return a.spcs.InSchema(namespace)
+}
+
+// nameColumn is the index of the resource name in the active view's rows.
+func (a *App) nameColumn() int {
+	switch a.view {
+	case viewServices, viewJobs:
+		// Both lead with the namespace.
+		return 1
+	default:
+		return 0
+	}
+}
@@ -2,13 +2,17 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/signal"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -16,11 +20,10 @@ import (
 	"github.com/rivo/tview"
 	"github.com/marcelinojackson-org/snow9s/internal/config"
 	"github.com/marcelinojackson-org/snow9s/internal/snowflake"
+	"github.com/marcelinojackson-org/snow9s/internal/version"
 	"github.com/marcelinojackson-org/snow9s/pkg/models"
 )
 
-const appVersion = "0.1.0"
-
 type viewKind string
 
 const (
@@ -28,6 +31,13 @@ const (
 	viewPools     viewKind = "Pools"
 	viewRepos     viewKind = "Repos"
 	viewInstances viewKind = "Instances"
+	viewEvents    viewKind = "Events"
+	viewEndpoints viewKind = "Endpoints"
+	viewImages    viewKind = "Images"
+	viewDatabases viewKind = "Databases"
+	viewSchemas   viewKind = "Schemas"
+	viewJobs      viewKind = "Jobs"
+	viewSQL       viewKind = "SQL"
 )
 
 type inputMode int
@@ -36,6 +46,7 @@ const (
 	inputNone inputMode = iota
 	inputFilter
 	inputCommand
+	inputConfirmName
 )
 
 type viewData struct {
@@ -43,43 +54,88 @@ type viewData struct {
 	rows         []TableRow
 	statusColumn int
 	warning      string
+	services     []models.Service
+	pools        []models.ComputePool
+	formats      map[int]ColumnFormat
 }
 
 // App wires the widgets, navigation, and data refresh loop.
 type App struct {
-	app           *tview.Application
-	styles        StyleConfig
-	header        *Header
-	footer        *Footer
-	errorView     *tview.TextView
-	table         *DataTable
-	filterField   *tview.InputField
-	spcs          *snowflake.SPCS
-	cfg           config.Config
-	refreshTicker *time.Ticker
-	refreshMu     sync.Mutex
-	loading       bool
-	cancel        context.CancelFunc
-	debugView     *tview.TextView
-	debugEnabled  bool
-	helpVisible   bool
-	defaultHints  []string
-	pages         *tview.Pages
-	bottomPages   *tview.Pages
-	detailView    *tview.TextView
-	detailVisible bool
-	view          viewKind
-	activeService string
-	inputMode     inputMode
+	app             *tview.Application
+	styles          StyleConfig
+	header          *Header
+	footer          *Footer
+	errorView       *tview.TextView
+	table           *DataTable
+	filterField     *tview.InputField
+	spcs            *snowflake.SPCS
+	cfg             config.Config
+	refreshTicker   *time.Ticker
+	refreshMu       sync.Mutex
+	loading         bool
+	spinning        bool
+	paused          bool
+	errorExpiry     time.Time
+	errorTimeout    time.Duration
+	ctx             context.Context
+	cancel          context.CancelFunc
+	debugView       *tview.TextView
+	debugEnabled    bool
+	helpVisible     bool
+	defaultHints    []string
+	pages           *tview.Pages
+	bottomPages     *tview.Pages
+	detailView      *tview.TextView
+	detailVisible   bool
+	describeView    *DetailView
+	describeVisible bool
+	view            viewKind
+	activeService   string
+	activeSchema    string // the schema activeService was listed in
+	activeRepo      string
+	sqlStatement    string
+	inputMode       inputMode
+	services        []models.Service
+	summaryTotal    bool
+	wide            bool
+	grouped         bool
+	prober          *endpointProber
+	collapsed       map[string]bool
+	lastMutation    *mutation
+	typedConfirm    *typedConfirmation
+	clipboard       func(text string) error
+	modalVisible    bool
+	state           config.State
+	counts          resourceCounts
+	lastError       *errorDetails
+	retry           *backoff
+	conn            connHealth
+	reconnect       atomic.Bool
+	clock           models.Clock
+	lastRefresh     time.Time
+	rows            []TableRow
+	mutations       int
+	fetchGen        uint64
+	detailGen       uint64
+	describeGen     uint64
+	refreshReset    chan struct{}
+	pending         map[string]string
+	fetchCancel     context.CancelFunc
+	fetchManual     bool
+	fetchStarted    time.Time
+	stopped         atomic.Bool
+	contextSwitch   *ContextSwitch
+	closeSession    func() error
+	switching       bool
 }
 
 // NewApp constructs the layout with k9s-inspired styling.
 func NewApp(cfg config.Config, spcs *snowflake.SPCS, debugEnabled bool) *App {
-	styles := DefaultStyles()
+	styles, themeWarnings := ThemeStyles(cfg.Theme)
 	app := tview.NewApplication()
 	app.EnableMouse(true)
 
-	header := NewHeader(cfg, appVersion, styles)
+	header := NewHeader(cfg, version.Version, styles)
 	footer := NewFooter(styles)
 	footer.SetHints(defaultKeyHints())
 
@@ -90,6 +146,8 @@ func NewApp(cfg config.Config, spcs *snowflake.SPCS, debugEnabled bool) *App {
 	errorView.SetText("")
 
 	table := NewDataTable(styles)
+	table.SetLayout(cfg.TableBorders, cfg.CellPadding)
+	table.SetASCII(noColorRequested())
 	table.SetTitle(" Services ").SetTitleAlign(tview.AlignLeft)
 
 	filterField := tview.NewInputField().SetLabel("")
@@ -106,6 +164,14 @@ func NewApp(cfg config.Config, spcs *snowflake.SPCS, debugEnabled bool) *App {
 		debugView.SetTextColor(styles.SecondaryText)
 		debugView.SetBorder(true)
 		debugView.SetTitle(" Debug ")
+		for _, warning := range themeWarnings {
+			fmt.Fprintf(debugView, "%s; using the default color\n", tview.Escape(warning))
+		}
+	}
+
+	state, err := config.LoadState(cfg)
+	if err != nil {
+		state = config.State{}
 	}
 
 	appState := &App{
@@ -115,6 +181,7 @@ func NewApp(cfg config.Config, spcs *snowflake.SPCS, debugEnabled bool) *App {
 		footer:       footer,
 		errorView:    errorView,
 		table:        table,
+		describeView: NewDetailView(styles),
 		filterField:  filterField,
 		spcs:         spcs,
 		cfg:          cfg,
@@ -122,15 +189,36 @@ func NewApp(cfg config.Config, spcs *snowflake.SPCS, debugEnabled bool) *App {
 		debugEnabled: debugEnabled,
 		defaultHints: defaultKeyHints(),
 		view:         viewServices,
+		collapsed:    map[string]bool{},
+		state:        state,
+		counts:       newResourceCounts(),
+		retry:        newBackoff(retryBaseDelay, time.Duration(cfg.RetryMaxBackoff)*time.Second),
+		errorTimeout: time.Duration(cfg.ErrorTimeout) * time.Second,
+		clock:        models.RealClock,
+		pending:      map[string]string{},
+		refreshReset: make(chan struct{}, 1),
+		clipboard:    copyToClipboard,
 	}
 
+	header.SetUTC(state.UTC)
+	appState.setConnection()
+	appState.buildLayout()
+
 	filterField.SetChangedFunc(func(text string) {
+		if appState.inputMode == inputConfirmName {
+			return
+		}
 		if appState.inputMode != inputFilter {
 			appState.footer.SetStatus(fmt.Sprintf("%s  cmd: %s", appState.table.SelectionInfo(), text))
 			return
 		}
+		if appState.describeVisible {
+			appState.describeView.SetFilter(text)
+			appState.footer.SetStatus(fmt.Sprintf("%d keys  filter: %s", len(appState.describeView.Shown()), text))
+			return
+		}
 		appState.table.SetFilter(text)
-		appState.footer.SetStatus(fmt.Sprintf("%s  filter: %s", appState.table.SelectionInfo(), text))
+		appState.updateFooterStatus()
 	})
 	filterField.SetDoneFunc(func(key tcell.Key) {
 		appState.completeInput(key)
@@ -139,18 +227,15 @@ func NewApp(cfg config.Config, spcs *snowflake.SPCS, debugEnabled bool) *App {
 	return appState
 }
 
-// Run boots the TUI, wiring key bindings and refresh loop.
-func (a *App) Run(ctx context.Context) error {
-	ctx, cancel := context.WithCancel(ctx)
-	a.cancel = cancel
-	defer cancel()
-
+// buildLayout assembles the panes and pages. It runs in NewApp so the detail
+// pane and overlays exist before Run, as key handlers expect.
+func (a *App) buildLayout() {
 	a.detailView = tview.NewTextView().SetDynamicColors(true)
 	a.detailView.SetBackgroundColor(a.styles.Background)
 	a.detailView.SetTextColor(a.styles.PrimaryText)
 	a.detailView.SetBorder(true)
 	a.detailView.SetBorderColor(a.styles.Border)
-	a.detailView.SetTitle(" Details (Esc to close) ")
+	a.applyWrap()
 
 	rootFlex := tview.NewFlex().SetDirection(tview.FlexRow)
 	rootFlex.SetBackgroundColor(a.styles.Background)
@@ -172,10 +257,20 @@ func (a *App) Run(ctx context.Context) error {
 	a.pages = tview.NewPages()
 	a.pages.AddPage("main", rootFlex, true, true)
 	a.pages.AddPage("detail", a.detailView, true, false)
+	a.pages.AddPage(describePage, a.describeView, true, false)
+}
+
+// Run boots the TUI, wiring key bindings and refresh loop.
+func (a *App) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	a.ctx = ctx
+	a.cancel = cancel
+	defer cancel()
+
 	a.app.SetRoot(a.pages, true)
 	a.app.SetFocus(a.table)
 	a.bindKeys()
-	a.setView(viewServices)
+	a.restoreView()
 
 	// handle Ctrl+C
 	go func() {
@@ -185,91 +280,287 @@ func (a *App) Run(ctx context.Context) error {
 		case <-ctx.Done():
 		case <-sigCh:
 			cancel()
-			a.app.Stop()
+			a.stop()
 		}
 	}()
 
 	a.startRefreshLoop(ctx)
 
-	if err := a.app.Run(); err != nil {
-		return err
+	err := a.app.Run()
+	a.stopped.Store(true)
+	a.saveViewState()
+	return err
+}
+
+// safeUpdate queues fn on the UI goroutine, dropping it once the app has
+// stopped so late background updates cannot block or panic.
+func (a *App) safeUpdate(fn func()) {
+	if a.stopped.Load() {
+		return
 	}
-	return nil
+	a.app.QueueUpdateDraw(fn)
+}
+
+func (a *App) stop() {
+	a.stopped.Store(true)
+	a.app.Stop()
 }
 
+// startRefreshLoop reloads the active view every refreshInterval. After a
+// failure it waits out the retry backoff instead, counting down in the footer.
+// While paused it keeps ticking but fetches nothing.
 func (a *App) startRefreshLoop(ctx context.Context) {
-	a.refreshTicker = time.NewTicker(5 * time.Second)
+	a.refreshTicker = time.NewTicker(time.Second)
 	go func() {
 		defer a.refreshTicker.Stop()
 		a.fetchCurrentView(ctx)
+		a.fetchOverview(ctx)
+		lastFetch := a.clock.Now()
+		lastOverview := lastFetch
 		for {
 			select {
 			case <-ctx.Done():
 				return
+			case <-a.refreshReset:
+				lastFetch = a.clock.Now()
 			case <-a.refreshTicker.C:
-				a.fetchCurrentView(ctx)
+				now := a.clock.Now()
+				if a.errorExpired(now) {
+					a.safeUpdate(a.clearExpiredError)
+				}
+				if !a.isPaused() && now.Sub(lastOverview) >= overviewInterval {
+					a.fetchOverview(ctx)
+					lastOverview = now
+				}
+				if !a.refreshDue(now, lastFetch) {
+					continue
+				}
+				a.refreshCurrentView(ctx)
+				lastFetch = now
 			}
 		}
 	}()
 }
 
+// refreshDue reports whether the loop should fetch at now, given the time of
+// its last fetch. It refreshes the retry countdown in the footer meanwhile.
+func (a *App) refreshDue(now, lastFetch time.Time) bool {
+	if a.isPaused() {
+		return false
+	}
+	if a.view == viewSQL {
+		// An ad-hoc query may be expensive; it only reruns on Ctrl+r, even
+		// after a failure.
+		return false
+	}
+	if a.retry.pending() {
+		if a.retry.remaining(now) > 0 {
+			a.safeUpdate(a.updateFooterStatus)
+			return false
+		}
+		return true
+	}
+	return now.Sub(lastFetch) >= refreshInterval
+}
+
+// isPaused reports whether automatic refreshes are paused.
+func (a *App) isPaused() bool {
+	a.refreshMu.Lock()
+	defer a.refreshMu.Unlock()
+	return a.paused
+}
+
+// togglePause stops or resumes automatic refreshes. Ctrl+r still refreshes
+// once while paused.
+func (a *App) togglePause() {
+	a.refreshMu.Lock()
+	a.paused = !a.paused
+	a.refreshMu.Unlock()
+	a.updateFooterStatus()
+}
+
+// fetchCurrentView loads the active view in the background on the user's
+// behalf; Esc cancels it while it runs.
 func (a *App) fetchCurrentView(ctx context.Context) {
+	a.fetchView(ctx, true)
+}
+
+// refreshCurrentView is fetchCurrentView for automatic refreshes, which Esc
+// leaves to finish.
+func (a *App) refreshCurrentView(ctx context.Context) {
+	a.fetchView(ctx, false)
+}
+
+func (a *App) fetchView(ctx context.Context, manual bool) {
 	if a.inputMode != inputNone || a.detailVisible {
 		return
 	}
-	a.refreshMu.Lock()
-	if a.loading {
-		a.refreshMu.Unlock()
+	gen, busy := a.fetchGeneration()
+	if busy {
+		// A mutation is in flight; its completion triggers the next refresh.
+		return
+	}
+	if !a.claimLoading() {
+		// A manual and a ticker fetch raced; the one already running wins.
 		return
 	}
-	a.refreshMu.Unlock()
 
 	go func() {
-		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		timeoutCtx, cancel := context.WithTimeout(ctx, a.cfg.Timeout())
 		defer cancel()
 
-		a.setLoading(true)
-		data, err := a.loadViewData(timeoutCtx)
+		a.refreshMu.Lock()
+		a.fetchCancel = cancel
+		a.fetchManual = manual
+		a.refreshMu.Unlock()
+
+		data, err := a.reconnectAndLoad(timeoutCtx)
 		a.setLoading(false)
 
-		a.app.QueueUpdateDraw(func() {
-			if err != nil {
-				a.setError(fmt.Sprintf("Error fetching %s: %v (Ctrl+r to retry)", strings.ToLower(string(a.view)), err))
-			} else if data.warning != "" {
-				a.setError(data.warning)
-			} else {
-				a.setError("")
-			}
-			if err == nil {
-				a.table.SetStatusColumn(data.statusColumn)
-				a.table.SetData(data.headers, data.rows)
-			}
-			a.updateFooterStatus()
-			a.header.Refresh()
-			if a.inputMode == inputNone && !a.detailVisible {
-				a.app.SetFocus(a.table)
-			}
+		a.refreshMu.Lock()
+		a.fetchCancel = nil
+		a.refreshMu.Unlock()
+
+		a.safeUpdate(func() {
+			a.applyViewData(data, err, gen)
 		})
 	}()
 }
 
+// reconnectAndLoad loads the active view, first replacing the client's
+// sessions when the previous fetch lost the connection.
+func (a *App) reconnectAndLoad(ctx context.Context) (viewData, error) {
+	if a.reconnect.Load() && a.spcs != nil {
+		if err := a.spcs.Reconnect(ctx); err != nil {
+			return viewData{}, err
+		}
+	}
+	return a.loadViewData(ctx)
+}
+
+// applyViewData shows the result of a fetch started at generation gen. Results
+// that overlap a mutation are discarded so they cannot overwrite its pending
+// marker or show pre-mutation state; a fresh fetch follows once it is safe.
+func (a *App) applyViewData(data viewData, err error, gen uint64) bool {
+	if current, busy := a.fetchGeneration(); busy || current != gen {
+		if !busy {
+			a.fetchCurrentView(a.rootContext())
+		}
+		return false
+	}
+	if errors.Is(err, context.Canceled) {
+		a.setError(fmt.Sprintf("Cancelled fetching %s (Ctrl+r to retry)", strings.ToLower(string(a.view))))
+	} else if err != nil {
+		retrying := a.view != viewSQL && a.retry.fail(a.clock.Now()) > 0
+		a.conn.observe(err, retrying)
+		a.reconnect.Store(a.conn.state != connConnected)
+		msg := a.conn.errorMessage(strings.ToLower(string(a.view)), err)
+		a.recordError(msg, err)
+		a.setError(msg)
+	} else if data.warning != "" {
+		a.setError(data.warning)
+	} else {
+		a.setError("")
+	}
+	if err == nil {
+		a.retry.reset()
+		a.conn.observe(nil, false)
+		a.reconnect.Store(false)
+		a.lastRefresh = a.clock.Now()
+		a.services = data.services
+		a.rows = data.rows
+		a.recordCounts(data)
+		a.table.SetStatusColumn(data.statusColumn)
+		a.table.SetColumnFormats(data.formats)
+		a.table.SetData(data.headers, a.displayRows(cloneRows(data.rows)))
+	}
+	a.setConnection()
+	a.updateFooterStatus()
+	a.header.Refresh()
+	if a.inputMode == inputNone && !a.detailVisible && !a.describeVisible {
+		a.app.SetFocus(a.table)
+	}
+	return true
+}
+
+// staleAfter is how old the displayed data may get before the footer flags it.
+const staleAfter = 3 * refreshInterval
+
+// staleness describes how out of date the displayed data is, or returns ""
+// while it is fresh or nothing has loaded yet.
+func (a *App) staleness() string {
+	if a.lastRefresh.IsZero() {
+		return ""
+	}
+	age := a.clock.Now().Sub(a.lastRefresh)
+	if age <= staleAfter {
+		return ""
+	}
+	return fmt.Sprintf("[stale %s]", models.FormatAge(age))
+}
+
+// recordCounts updates the cost-awareness totals from freshly loaded data and
+// refreshes the header warning.
+func (a *App) recordCounts(data viewData) {
+	if data.services != nil {
+		a.counts.runningServices = countRunningServices(data.services)
+	}
+	if data.pools != nil {
+		a.counts.activeNodes = countActiveNodes(data.pools)
+	}
+	a.header.SetWarnings(costWarnings(a.cfg.CostWarnings, a.counts))
+}
+
 func (a *App) showError(msg string) {
-	a.app.QueueUpdateDraw(func() {
+	a.safeUpdate(func() {
 		a.setError(msg)
 	})
 }
 
+// setError shows msg in the error bar, or clears it when msg is empty.
+// Errors clear themselves after errorTimeout; a new message restarts the
+// countdown, and the "No items"/"No services" notices stay until replaced.
 func (a *App) setError(msg string) {
 	a.errorView.SetText(msg)
 	bg := a.styles.Background
+	transient := false
 	if msg != "" {
-		if strings.HasPrefix(msg, "No items") || strings.HasPrefix(msg, "No services") {
+		if isNotice(msg) {
 			bg = a.styles.RowAltBg
 		} else {
 			bg = tcell.ColorRed
+			transient = true
 		}
 	}
 	a.errorView.SetBackgroundColor(bg)
+
+	a.refreshMu.Lock()
+	a.errorExpiry = time.Time{}
+	if transient && a.errorTimeout > 0 {
+		a.errorExpiry = a.clock.Now().Add(a.errorTimeout)
+	}
+	a.refreshMu.Unlock()
+}
+
+// isNotice reports whether msg is an informational empty-result notice
+// rather than an error.
+func isNotice(msg string) bool {
+	return strings.HasPrefix(msg, "No items") || strings.HasPrefix(msg, "No services")
+}
+
+// errorExpired reports whether the error bar is due to clear itself at now.
+func (a *App) errorExpired(now time.Time) bool {
+	a.refreshMu.Lock()
+	defer a.refreshMu.Unlock()
+	return !a.errorExpiry.IsZero() && !now.Before(a.errorExpiry)
+}
+
+// clearExpiredError clears the error bar once its timeout has passed. It
+// checks again on the UI goroutine, as a new error may have arrived since.
+func (a *App) clearExpiredError() {
+	if a.errorExpired(a.clock.Now()) {
+		a.setError("")
+	}
 }
 
 func (a *App) setLoading(loading bool) {
@@ -282,35 +573,152 @@ func (a *App) setLoading(loading bool) {
 	}
 
 	if loading {
-		go a.spin()
+		a.startSpinner()
 	} else {
-		a.app.QueueUpdateDraw(func() {
+		a.safeUpdate(func() {
 			a.footer.SetHints(a.defaultHints)
 			a.updateFooterStatus()
 		})
 	}
 }
 
+// claimLoading marks a fetch as running and starts the spinner, reporting
+// false when another fetch already holds it.
+func (a *App) claimLoading() bool {
+	a.refreshMu.Lock()
+	if a.loading {
+		a.refreshMu.Unlock()
+		return false
+	}
+	a.loading = true
+	a.fetchStarted = a.clock.Now()
+	a.refreshMu.Unlock()
+	a.startSpinner()
+	return true
+}
+
+// startSpinner runs the footer spinner unless one is already running, and
+// reports whether it started one.
+func (a *App) startSpinner() bool {
+	a.refreshMu.Lock()
+	defer a.refreshMu.Unlock()
+	if a.spinning {
+		return false
+	}
+	a.spinning = true
+	go a.spin()
+	return true
+}
+
+// rootContext is the context Run was started with, cancelled on quit, so work
+// begun from key handlers stops with the app.
+func (a *App) rootContext() context.Context {
+	if a.ctx == nil {
+		return context.Background()
+	}
+	return a.ctx
+}
+
+// cancelFetch aborts the in-flight fetch, if any, and reports whether one was
+// running.
+func (a *App) cancelFetch() bool {
+	a.refreshMu.Lock()
+	defer a.refreshMu.Unlock()
+	if a.fetchCancel == nil {
+		return false
+	}
+	a.fetchCancel()
+	a.fetchCancel = nil
+	return true
+}
+
+// cancelUserFetch is cancelFetch limited to fetches the user started, so Esc
+// does not abort an automatic refresh.
+func (a *App) cancelUserFetch() bool {
+	a.refreshMu.Lock()
+	defer a.refreshMu.Unlock()
+	if a.fetchCancel == nil || !a.fetchManual {
+		return false
+	}
+	a.fetchCancel()
+	a.fetchCancel = nil
+	return true
+}
+
+// viewStatement names the statement that loads view, for the status line.
+func viewStatement(view viewKind) string {
+	switch view {
+	case viewServices:
+		return "SHOW SERVICES"
+	case viewPools:
+		return "SHOW COMPUTE POOLS"
+	case viewRepos:
+		return "SHOW IMAGE REPOSITORIES"
+	case viewInstances:
+		return "SHOW SERVICE INSTANCES"
+	case viewEvents:
+		return "SYSTEM$GET_SERVICE_STATUS"
+	case viewEndpoints:
+		return "SHOW ENDPOINTS"
+	case viewImages:
+		return "SHOW IMAGES"
+	case viewDatabases:
+		return "SHOW DATABASES"
+	case viewSchemas:
+		return "SHOW SCHEMAS"
+	case viewJobs:
+		return "SHOW JOB SERVICES"
+	case viewSQL:
+		return "SQL"
+	default:
+		return strings.ToLower(string(view))
+	}
+}
+
+// spinnerDelay is how long a fetch runs before the footer shows the spinner,
+// so quick refreshes leave the footer alone.
+const spinnerDelay = 300 * time.Millisecond
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spin animates the footer while loading. It carries on across a fetch that
+// starts before it notices the previous one ended, and clears spinning under
+// the same lock it sees loading end, so at most one runs.
 func (a *App) spin() {
-	frames := []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
 	idx := 0
 	for {
 		a.refreshMu.Lock()
 		if !a.loading {
+			a.spinning = false
 			a.refreshMu.Unlock()
 			return
 		}
+		elapsed := a.clock.Now().Sub(a.fetchStarted)
+		cancellable := a.fetchCancel != nil && a.fetchManual
 		a.refreshMu.Unlock()
 
-		frame := frames[idx%len(frames)]
-		a.app.QueueUpdateDraw(func() {
-			a.footer.SetHints([]string{fmt.Sprintf("%s Fetching %s...", frame, strings.ToLower(string(a.view)))})
-		})
-		idx++
+		if elapsed >= spinnerDelay {
+			frame := idx
+			a.safeUpdate(func() {
+				a.footer.SetHints(spinnerHints(frame, elapsed, viewStatement(a.view), cancellable))
+			})
+			idx++
+		}
 		time.Sleep(120 * time.Millisecond)
 	}
 }
 
+// spinnerHints returns the footer hints for frame idx of a fetch that has been
+// running statement for elapsed, offering Esc when the fetch is cancellable.
+func spinnerHints(idx int, elapsed time.Duration, statement string, cancellable bool) []string {
+	frame := spinnerFrames[idx%len(spinnerFrames)]
+	hints := []string{fmt.Sprintf("%s running %s… (%ds)", frame, statement, int(elapsed/time.Second))}
+	if cancellable {
+		hints = append(hints, "esc Cancel")
+	}
+	return hints
+}
+
 func (a *App) bindKeys() {
 	a.app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
 		if a.handleKey(event) {
@@ -318,15 +726,60 @@ func (a *App) bindKeys() {
 		}
 		return event
 	})
+	a.table.SetMouseCapture(a.handleMouse)
+}
+
+// handleMouse moves the selection with the wheel and selects the clicked row,
+// opening it on double click, as j/k and Enter do. Overlays and input keep
+// tview's default handling.
+func (a *App) handleMouse(action tview.MouseAction, event *tcell.EventMouse) (tview.MouseAction, *tcell.EventMouse) {
+	if a.modalVisible || a.helpVisible || a.detailVisible || a.describeVisible || a.inputMode != inputNone {
+		return action, event
+	}
+	switch action {
+	case tview.MouseScrollUp:
+		a.move(-1)
+	case tview.MouseScrollDown:
+		a.move(1)
+	case tview.MouseLeftClick, tview.MouseLeftDoubleClick:
+		row, _ := a.table.CellAt(event.Position())
+		if row < 1 || row >= a.table.GetRowCount() {
+			// The header and the space below the last row select nothing.
+			return action, nil
+		}
+		a.selectRow(row)
+		if action == tview.MouseLeftDoubleClick {
+			a.openSelected()
+		}
+	default:
+		return action, event
+	}
+	return action, nil
 }
 
 func (a *App) handleKey(event *tcell.EventKey) bool {
+	if a.modalVisible {
+		return false
+	}
+	if a.helpVisible {
+		if event.Key() == tcell.KeyEsc || (event.Key() == tcell.KeyRune && event.Rune() == '?') {
+			a.toggleHelp()
+			return true
+		}
+		// Let the help text scroll.
+		return false
+	}
 	if a.detailVisible {
-		if event.Key() == tcell.KeyEsc {
+		switch {
+		case event.Key() == tcell.KeyEsc:
 			a.closeDetail()
 			return true
+		case event.Key() == tcell.KeyRune && event.Rune() == 'w':
+			a.toggleWrap(wrapDetail)
+			return true
 		}
-		return true
+		// Let the text view scroll, including horizontally when unwrapped.
+		return false
 	}
 	if a.inputMode != inputNone && a.app.GetFocus() == a.filterField {
 		if event.Key() == tcell.KeyEsc {
@@ -335,17 +788,39 @@ func (a *App) handleKey(event *tcell.EventKey) bool {
 		}
 		return false
 	}
+	if a.describeVisible {
+		switch {
+		case event.Key() == tcell.KeyEsc:
+			a.closeDescribe()
+			return true
+		case event.Key() == tcell.KeyRune && event.Rune() == '/':
+			a.activateInput(inputFilter, "/ ")
+			return true
+		}
+		// Let the table scroll.
+		return false
+	}
 	switch event.Key() {
 	case tcell.KeyCtrlC:
-		a.app.Stop()
+		a.stop()
 		return true
 	case tcell.KeyEsc:
-		a.filterField.SetText("")
-		a.table.SetFilter("")
-		a.updateFooterStatus()
-		return true
+		// Overlays and the filter field handle Esc above; here it only
+		// cancels a query the user started, so an applied filter survives
+		// and automatic refreshes finish.
+		return a.cancelUserFetch()
 	case tcell.KeyCtrlR:
-		a.fetchCurrentView(context.Background())
+		a.retry.reset()
+		if a.spcs != nil {
+			a.spcs.InvalidateCache()
+		}
+		a.fetchCurrentView(a.rootContext())
+		return true
+	case tcell.KeyCtrlW:
+		a.toggleWide()
+		return true
+	case tcell.KeyCtrlX:
+		a.openContexts()
 		return true
 	case tcell.KeyCtrlD:
 		a.page(1)
@@ -360,12 +835,12 @@ func (a *App) handleKey(event *tcell.EventKey) bool {
 		a.move(-1)
 		return true
 	case tcell.KeyEnter:
-		a.openDetail()
+		a.openSelected()
 		return true
 	case tcell.KeyRune:
 		switch event.Rune() {
 		case 'q':
-			a.app.Stop()
+			a.stop()
 			return true
 		case 'j':
 			a.move(1)
@@ -396,17 +871,101 @@ func (a *App) handleKey(event *tcell.EventKey) bool {
 			return true
 		case 'i':
 			if a.view == viewServices {
-				a.openInstancesView()
+				a.openServiceSubview(viewInstances)
+			}
+			return true
+		case 'e':
+			if a.view == viewServices {
+				a.openServiceSubview(viewEvents)
+			}
+			return true
+		case 'o':
+			if a.view == viewServices {
+				a.openServiceSubview(viewEndpoints)
+			}
+			return true
+		case 'c':
+			a.copyEndpointURL()
+			return true
+		case 'l':
+			a.openLogs()
+			return true
+		case 'v':
+			if a.view == viewServices {
+				a.openDetail()
 			}
 			return true
+		case 'm':
+			a.openSpec()
+			return true
 		case 'b':
-			if a.view == viewInstances {
+			if isServiceSubview(a.view) {
 				a.setView(viewServices)
 			}
+			if a.view == viewSchemas {
+				a.setView(viewDatabases)
+			}
+			if a.view == viewImages {
+				a.setView(viewRepos)
+			}
+			return true
+		case 'd':
+			a.setView(viewDatabases)
 			return true
 		case 'n':
 			a.activateInput(inputCommand, ":ns ")
 			return true
+		case 'z':
+			a.toggleGrouped()
+			return true
+		case ' ':
+			a.toggleSelectedGroup()
+			return true
+		case 'S':
+			a.suspendService()
+			return true
+		case 'R':
+			a.resumeService()
+			return true
+		case 'X':
+			a.dropService()
+			return true
+		case 'N':
+			a.scalePool()
+			return true
+		case '.':
+			a.repeatLastMutation()
+			return true
+		case '*':
+			a.toggleFavorite()
+			return true
+		case 'u':
+			a.toggleUTC()
+			return true
+		case 'f':
+			a.filterBySelectedPool()
+			return true
+		case 'P':
+			a.togglePause()
+			return true
+		case '#':
+			a.toggleSummaryTotal()
+			return true
+		case 'y':
+			a.yankRow()
+			return true
+		case 'Y':
+			a.yankName()
+			return true
+		case 'J':
+			a.yankVisibleJSON()
+			return true
+		case 'E':
+			a.copyErrorDetails()
+			return true
+		case 'w':
+			a.toggleWrap(wrapDebug)
+			return true
 		case '?':
 			a.toggleHelp()
 			return true
@@ -415,7 +974,25 @@ func (a *App) handleKey(event *tcell.EventKey) bool {
 	return false
 }
 
+// openSelected acts on the selected row: it toggles a group, drills into a
+// child view, or describes the row.
+func (a *App) openSelected() {
+	if a.toggleSelectedGroup() || a.drillDown() {
+		return
+	}
+	if a.view == viewServices {
+		a.openDescribe()
+		return
+	}
+	a.openDetail()
+}
+
+// move shifts the selection by delta rows. Like page and selectRow it does
+// nothing when no row passes the filter, so the header is never selected.
 func (a *App) move(delta int) {
+	if a.table.Len() == 0 {
+		return
+	}
 	row, col := a.table.GetSelection()
 	newRow := row + delta
 	if newRow < 1 {
@@ -430,7 +1007,7 @@ func (a *App) move(delta int) {
 
 func (a *App) page(direction int) {
 	total := a.table.GetRowCount()
-	if total <= 1 {
+	if total <= 1 || a.table.Len() == 0 {
 		return
 	}
 	row, col := a.table.GetSelection()
@@ -447,6 +1024,9 @@ func (a *App) page(direction int) {
 }
 
 func (a *App) selectRow(row int) {
+	if a.table.Len() == 0 {
+		return
+	}
 	if row < 1 {
 		row = 1
 	}
@@ -466,7 +1046,7 @@ func (a *App) activateInput(mode inputMode, label string) {
 	if a.bottomPages != nil {
 		a.bottomPages.SwitchToPage("input")
 	}
-	if mode == inputCommand {
+	if mode == inputCommand || mode == inputConfirmName {
 		a.footer.SetHints([]string{"enter Run", "esc Cancel"})
 		return
 	}
@@ -478,14 +1058,23 @@ func (a *App) completeInput(key tcell.Key) {
 	switch a.inputMode {
 	case inputFilter:
 		if key == tcell.KeyEsc {
-			a.filterField.SetText("")
-			a.table.SetFilter("")
+			// Esc in the describe view clears only its key filter.
+			if a.describeVisible {
+				a.describeView.SetFilter("")
+			} else {
+				a.filterField.SetText("")
+				a.table.SetFilter("")
+			}
 		}
 		if key == tcell.KeyEnter || key == tcell.KeyEsc {
 			a.filterField.SetDisabled(true)
 			a.filterField.SetLabel("")
 			a.inputMode = inputNone
-			a.app.SetFocus(a.table)
+			if a.describeVisible {
+				a.app.SetFocus(a.describeView)
+			} else {
+				a.app.SetFocus(a.table)
+			}
 			a.footer.SetHints(a.defaultHints)
 			if a.bottomPages != nil {
 				a.bottomPages.SwitchToPage("footer")
@@ -497,21 +1086,46 @@ func (a *App) completeInput(key tcell.Key) {
 			a.runCommand(text)
 		}
 		if key == tcell.KeyEnter || key == tcell.KeyEsc {
-			a.filterField.SetText("")
-			a.filterField.SetDisabled(true)
-			a.filterField.SetLabel("")
-			a.inputMode = inputNone
-			a.app.SetFocus(a.table)
-			a.footer.SetHints(a.defaultHints)
-			if a.bottomPages != nil {
-				a.bottomPages.SwitchToPage("footer")
-			}
-			a.updateFooterStatus()
+			a.closeInput()
+		}
+	case inputConfirmName:
+		if key != tcell.KeyEnter && key != tcell.KeyEsc {
+			return
+		}
+		pending := a.typedConfirm
+		a.typedConfirm = nil
+		a.closeInput()
+		if key != tcell.KeyEnter || pending == nil {
+			return
+		}
+		if text != pending.target {
+			a.showError(fmt.Sprintf("Typed name %q does not match %s; nothing was changed", text, pending.target))
+			return
 		}
+		pending.onConfirm()
 	default:
 	}
 }
 
+// closeInput clears and hides the input bar and returns focus to the table.
+func (a *App) closeInput() {
+	a.filterField.SetText("")
+	a.filterField.SetDisabled(true)
+	a.filterField.SetLabel("")
+	a.inputMode = inputNone
+	if a.helpVisible {
+		// :help opened the overlay; keep it focused so it can scroll.
+		a.app.SetFocus(a.pages.GetPage(helpPage))
+	} else {
+		a.app.SetFocus(a.table)
+	}
+	a.footer.SetHints(a.defaultHints)
+	if a.bottomPages != nil {
+		a.bottomPages.SwitchToPage("footer")
+	}
+	a.updateFooterStatus()
+}
+
 func (a *App) runCommand(cmd string) {
 	if cmd == "" {
 		return
@@ -522,19 +1136,48 @@ func (a *App) runCommand(cmd string) {
 	}
 	switch strings.ToLower(fields[0]) {
 	case "svc", "service", "services":
+		if !a.setAllSchemas(len(fields) > 1 && strings.EqualFold(fields[1], "all")) {
+			return
+		}
 		a.setView(viewServices)
 	case "pool", "pools", "cp":
 		a.setView(viewPools)
 	case "repo", "repos", "image", "images":
 		a.setView(viewRepos)
 	case "inst", "instances":
-		a.openInstancesView()
+		a.openServiceSubview(viewInstances)
+	case "ev", "events":
+		a.openServiceSubview(viewEvents)
+	case "ep", "endpoints":
+		a.openServiceSubview(viewEndpoints)
+	case "tag", "tags":
+		a.openImages()
+	case "db", "dbs", "database", "databases":
+		a.setView(viewDatabases)
+	case "sch", "schemas":
+		a.setView(viewSchemas)
+	case "job", "jobs":
+		a.setView(viewJobs)
+	case "sql":
+		a.openSQL(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(cmd), fields[0])))
 	case "ns", "namespace", "schema":
 		if len(fields) < 2 {
 			a.showError("Usage: :ns <schema>")
 			return
 		}
 		a.setSchema(fields[1])
+	case "group", "grouped":
+		a.toggleGrouped()
+	case "ctx", "context", "contexts":
+		if len(fields) < 2 {
+			a.openContexts()
+			return
+		}
+		a.selectContext(fields[1])
+	case "spec", "manifest":
+		a.openSpec()
+	case "dsn":
+		a.showDSN()
 	case "help", "?":
 		a.toggleHelp()
 	default:
@@ -543,20 +1186,42 @@ func (a *App) runCommand(cmd string) {
 }
 
 func (a *App) setView(view viewKind) {
-	if view == viewInstances && a.activeService == "" {
-		a.showError("Select a service first to view instances")
+	if isServiceSubview(view) && a.activeService == "" {
+		a.showError(fmt.Sprintf("Select a service first to view %s", strings.ToLower(string(view))))
 		return
 	}
 	a.view = view
+	a.resetRefresh()
 	a.header.SetView(string(view))
 	title := fmt.Sprintf(" %s ", view)
-	if view == viewInstances && a.activeService != "" {
+	if isServiceSubview(view) && a.activeService != "" {
 		title = fmt.Sprintf(" %s (%s) ", view, a.activeService)
 	}
+	if view == viewImages {
+		title = fmt.Sprintf(" %s (%s) ", view, a.activeRepo)
+	}
+	if view == viewSQL {
+		title = fmt.Sprintf(" %s (%s) ", view, truncateCell(strings.Join(strings.Fields(a.sqlStatement), " "), 60))
+	}
 	a.table.SetTitle(title).SetTitleAlign(tview.AlignLeft)
 	a.table.SetFilter("")
 	a.filterField.SetText("")
-	go a.fetchCurrentView(context.Background())
+	go a.fetchCurrentView(a.rootContext())
+}
+
+// resetRefresh invalidates fetches of the previous view, cancelling one still
+// running, and restarts the refresh interval and retry backoff so the new view
+// loads on its own schedule.
+func (a *App) resetRefresh() {
+	a.refreshMu.Lock()
+	a.fetchGen++
+	a.refreshMu.Unlock()
+	a.cancelFetch()
+	a.retry.reset()
+	select {
+	case a.refreshReset <- struct{}{}:
+	default:
+	}
 }
 
 func (a *App) setSchema(schema string) {
@@ -564,23 +1229,120 @@ func (a *App) setSchema(schema string) {
 		return
 	}
 	a.cfg.Schema = schema
+	a.spcs.SetAllSchemas(false)
 	a.spcs.SetSchema(schema)
-	a.header.Refresh()
-	a.fetchCurrentView(context.Background())
+	a.header.SetNamespace(a.cfg.Database, a.cfg.Schema)
+	a.fetchCurrentView(a.rootContext())
+}
+
+// allSchemasLabel stands in for the schema in the header while services are
+// listed across the whole database.
+const allSchemasLabel = "*"
+
+// setAllSchemas switches the services view between the active schema and
+// every schema of the active database, and reports whether it could.
+func (a *App) setAllSchemas(all bool) bool {
+	if all && a.cfg.Database == "" {
+		a.showError("Select a database first to list services in all schemas")
+		return false
+	}
+	if all == a.spcs.AllSchemas() {
+		return true
+	}
+	a.spcs.SetAllSchemas(all)
+	a.header.SetNamespace(a.cfg.Database, a.schemaLabel())
+	return true
+}
+
+// schemaLabel names the scope services are listed in.
+func (a *App) schemaLabel() string {
+	if a.spcs.AllSchemas() {
+		return allSchemasLabel
+	}
+	return a.cfg.Schema
+}
+
+// drillDown navigates database → schema → services, updating the active
+// namespace, or from a repository to its images, and reports whether the
+// current view supports drilling.
+func (a *App) drillDown() bool {
+	if a.view == viewRepos {
+		a.openImages()
+		return true
+	}
+	if a.view != viewDatabases && a.view != viewSchemas {
+		return false
+	}
+	row, ok := a.table.SelectedRow()
+	if !ok || len(row.Cells) == 0 || row.Cells[0] == "" {
+		return true
+	}
+	if a.view == viewDatabases {
+		a.cfg.Database = row.Cells[0]
+		a.spcs.SetDatabase(row.Cells[0])
+		a.header.SetNamespace(a.cfg.Database, a.schemaLabel())
+		a.setView(viewSchemas)
+		return true
+	}
+	a.cfg.Schema = row.Cells[0]
+	a.spcs.SetAllSchemas(false)
+	a.spcs.SetSchema(row.Cells[0])
+	a.header.SetNamespace(a.cfg.Database, a.cfg.Schema)
+	a.setView(viewServices)
+	return true
+}
+
+// isServiceSubview reports whether view drills into the active service.
+func isServiceSubview(view viewKind) bool {
+	return view == viewInstances || view == viewEvents || view == viewEndpoints
+}
+
+// openImages lists the tagged images of the selected repository.
+func (a *App) openImages() {
+	if a.view != viewRepos {
+		a.showError("Images view requires Repos selection")
+		return
+	}
+	row, ok := a.table.SelectedRow()
+	if !ok || len(row.Cells) == 0 || row.Cells[0] == "" {
+		a.showError("Select a repository first to view images")
+		return
+	}
+	a.activeRepo = row.Cells[0]
+	a.setView(viewImages)
+}
+
+// openSQL runs a read-only statement in the SQL view. Without a statement it
+// reopens the last one.
+func (a *App) openSQL(statement string) {
+	if statement == "" {
+		statement = a.sqlStatement
+	}
+	if statement == "" {
+		a.showError("Usage: :sql <statement>")
+		return
+	}
+	if !snowflake.IsReadOnly(statement) {
+		a.showError("Only a single read-only statement (SELECT, SHOW, DESCRIBE, …) can run in :sql")
+		return
+	}
+	a.sqlStatement = statement
+	a.setView(viewSQL)
 }
 
-func (a *App) openInstancesView() {
+func (a *App) openServiceSubview(view viewKind) {
 	if a.view != viewServices {
-		a.showError("Instances view requires Services selection")
+		a.showError(fmt.Sprintf("%s view requires Services selection", view))
 		return
 	}
 	row, ok := a.table.SelectedRow()
-	if !ok || len(row.Cells) < 2 {
-		a.showError("Select a service first to view instances")
+	if !ok || len(row.Cells) < 2 || row.Cells[1] == "" {
+		a.showError(fmt.Sprintf("Select a service first to view %s", strings.ToLower(string(view))))
 		return
 	}
 	a.activeService = row.Cells[1]
-	a.setView(viewInstances)
+	a.activeSchema = row.Cells[0]
+	a.setView(view)
 }
 
 func (a *App) openDetail() {
@@ -588,11 +1350,55 @@ func (a *App) openDetail() {
 	if !ok {
 		return
 	}
-	content := a.buildDetail(row)
-	a.detailView.SetText(content)
+	gen := a.showDetail(" Details (Esc to close, w to wrap) ", "Loading…")
+	switch a.view {
+	case viewServices:
+	case viewPools:
+		a.openPoolDetail(gen, row)
+		return
+	default:
+		a.detailView.SetText(a.buildDetail(row))
+		return
+	}
+	name := ""
+	if len(row.Cells) > 1 {
+		name = row.Cells[1]
+	}
+	if name == "" {
+		a.detailView.SetText("No service selected.")
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(a.rootContext(), a.cfg.Timeout())
+		defer cancel()
+		detail := a.loadServiceDetail(ctx, row.Cells[0], name)
+		a.safeUpdate(func() {
+			if !a.detailCurrent(gen) {
+				return
+			}
+			a.detailView.SetText(a.formatServiceDetail(row, detail))
+			a.detailView.ScrollToBeginning()
+		})
+	}()
+}
+
+// showDetail opens the detail pane with a title and placeholder text. It
+// returns the pane generation a background load must still match when its
+// result arrives; see detailCurrent.
+func (a *App) showDetail(title, text string) uint64 {
+	a.detailGen++
+	a.detailView.SetTitle(title)
+	a.detailView.SetText(text)
 	a.detailVisible = true
 	a.pages.ShowPage("detail")
 	a.app.SetFocus(a.detailView)
+	return a.detailGen
+}
+
+// detailCurrent reports whether the pane opened as gen is still showing, so
+// a slow load does not overwrite a pane since closed or reopened.
+func (a *App) detailCurrent(gen uint64) bool {
+	return a.detailVisible && gen == a.detailGen
 }
 
 func (a *App) closeDetail() {
@@ -601,46 +1407,138 @@ func (a *App) closeDetail() {
 	a.app.SetFocus(a.table)
 }
 
-func (a *App) buildDetail(row TableRow) string {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// serviceDetail holds what the service detail pane shows. Each part is
+// fetched independently, so one failing still leaves the rest visible.
+type serviceDetail struct {
+	attrs     []snowflake.Attribute
+	err       error
+	spec      string
+	specErr   error
+	statuses  []models.ContainerStatus
+	statusErr error
+	instances []models.ServiceInstance
+	instErr   error
+}
 
-	switch a.view {
-	case viewServices:
-		name := ""
-		if len(row.Cells) > 1 {
-			name = row.Cells[1]
-		}
-		if name == "" {
-			return "No service selected."
-		}
-		descr, err := a.spcs.DescribeService(ctx, name)
-		if err != nil {
-			return fmt.Sprintf("Describe service failed: %v", err)
-		}
-		instances, instErr := a.spcs.ListServiceInstances(ctx, name)
-		var b strings.Builder
-		b.WriteString(fmt.Sprintf("Service: %s\n\n", name))
-		b.WriteString(formatKeyValues(descr))
-		b.WriteString("\n\nInstances:\n")
-		if instErr != nil {
-			b.WriteString(fmt.Sprintf("  Error: %v\n", instErr))
-			return b.String()
+// loadServiceDetail runs the detail queries for service name in namespace. It
+// does not touch the UI, so it is safe to call off the UI goroutine.
+func (a *App) loadServiceDetail(ctx context.Context, namespace, name string) serviceDetail {
+	spcs := a.spcsIn(namespace)
+	var d serviceDetail
+	d.attrs, d.err = spcs.DescribeServiceAttributes(ctx, name)
+	if d.err != nil {
+		return d
+	}
+	// Reuse the spec when the describe already returned it.
+	for _, attr := range d.attrs {
+		if attr.Name == "spec" && strings.TrimSpace(attr.Value) != "" {
+			d.spec = attr.Value
 		}
-		if len(instances) == 0 {
-			b.WriteString("  (none)\n")
-			return b.String()
+	}
+	// The remaining queries are independent, so they run side by side and
+	// the pane waits for the slowest rather than for their sum.
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		d.instances, d.instErr = spcs.ListServiceInstances(ctx, name)
+	}()
+	go func() {
+		defer wg.Done()
+		d.statuses, d.statusErr = spcs.GetServiceStatus(ctx, name)
+	}()
+	if d.spec == "" {
+		d.spec, d.specErr = spcs.GetServiceSpec(ctx, name)
+	}
+	wg.Wait()
+	return d
+}
+
+// formatServiceDetail renders a loaded serviceDetail for the selected row.
+func (a *App) formatServiceDetail(row TableRow, d serviceDetail) string {
+	name := row.Cells[1]
+	if d.err != nil {
+		return fmt.Sprintf("Describe service failed: %s", withHint(d.err))
+	}
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Service: %s\n", name))
+	if svc, ok := row.Model.(models.Service); ok {
+		if svc.Owner != "" {
+			b.WriteString(fmt.Sprintf("Owner: %s\n", svc.Owner))
 		}
-		for _, inst := range instances {
-			b.WriteString(fmt.Sprintf("  %s  %s  %s  %s\n", inst.Name, strings.ToUpper(inst.Status), inst.Node, inst.Age))
+		if svc.Comment != "" {
+			b.WriteString(fmt.Sprintf("Comment: %s\n", svc.Comment))
 		}
+	}
+	b.WriteString("\n")
+	b.WriteString(formatAttributes(a.localizeTimestamps(d.attrs), "spec"))
+	b.WriteString("\n\nSpec:\n")
+	if d.specErr != nil {
+		b.WriteString(fmt.Sprintf("  Error: %v\n", d.specErr))
+	} else if spec, err := models.ParseServiceSpec(d.spec, a.cfg.Database, row.Cells[0]); err != nil {
+		b.WriteString(fmt.Sprintf("  Error: %v\n", err))
+	} else {
+		b.WriteString(formatSpecSummary(spec))
+	}
+	b.WriteString("\nContainers:\n")
+	if d.statusErr != nil {
+		b.WriteString(fmt.Sprintf("  Error: %v\n", d.statusErr))
+	} else {
+		b.WriteString(formatContainerStatuses(d.statuses))
+	}
+	b.WriteString("\nInstances:\n")
+	if d.instErr != nil {
+		b.WriteString(fmt.Sprintf("  Error: %v\n", d.instErr))
 		return b.String()
-	case viewPools:
-		return formatKeyValues(mapFromRow(row, a.table.Headers()))
-	case viewRepos:
+	}
+	if len(d.instances) == 0 {
+		b.WriteString("  (none)\n")
+		return b.String()
+	}
+	for _, inst := range d.instances {
+		b.WriteString(fmt.Sprintf("  %s  %s  %s  %s\n", inst.Name, strings.ToUpper(inst.Status), inst.Node, inst.Age))
+	}
+	return b.String()
+}
+
+// openPoolDetail describes the selected compute pool in the background and
+// fills the detail pane opened as gen, unless it has moved on since.
+func (a *App) openPoolDetail(gen uint64, row TableRow) {
+	if len(row.Cells) == 0 || row.Cells[0] == "" {
+		a.detailView.SetText("No compute pool selected.")
+		return
+	}
+	name := row.Cells[0]
+	go func() {
+		ctx, cancel := context.WithTimeout(a.rootContext(), a.cfg.Timeout())
+		defer cancel()
+		detail, err := a.spcs.GetComputePoolDetail(ctx, name)
+		a.safeUpdate(func() {
+			if !a.detailCurrent(gen) {
+				return
+			}
+			if err != nil {
+				a.detailView.SetText(fmt.Sprintf("Describe compute pool failed: %s", withHint(err)))
+				return
+			}
+			a.detailView.SetText(a.formatPoolDetail(detail))
+			a.detailView.ScrollToBeginning()
+		})
+	}()
+}
+
+// buildDetail renders the detail pane for views whose rows already hold
+// everything shown; services and compute pools load in the background.
+func (a *App) buildDetail(row TableRow) string {
+	switch a.view {
+	case viewRepos, viewImages:
 		return formatKeyValues(mapFromRow(row, a.table.Headers()))
 	case viewInstances:
 		return formatKeyValues(mapFromRow(row, a.table.Headers()))
+	case viewEvents:
+		return formatKeyValues(mapFromRow(row, a.table.Headers()))
+	case viewDatabases, viewSchemas, viewJobs, viewSQL:
+		return formatKeyValues(mapFromRow(row, a.table.Headers()))
 	default:
 		return "No details available."
 	}
@@ -653,37 +1551,32 @@ func (a *App) loadViewData(ctx context.Context) (viewData, error) {
 		if err != nil {
 			return viewData{}, err
 		}
-		headers := []string{"NAMESPACE", "NAME", "STATUS", "POOL", "AGE"}
-		rows := make([]TableRow, 0, len(services))
-		for _, s := range services {
-			age := s.Age
-			if age == "" && !s.CreatedAt.IsZero() {
-				age = models.HumanizeAge(s.CreatedAt)
-			}
-			rows = append(rows, TableRow{Cells: []string{s.Namespace, s.Name, strings.ToUpper(s.Status), s.ComputePool, age}})
-		}
+		headers := serviceHeaders(a.wide)
+		rows := serviceRows(services, a.wide)
+		data := viewData{headers: headers, rows: rows, statusColumn: 2, services: services}
 		if len(rows) == 0 {
-			return viewData{headers: headers, rows: rows, statusColumn: 2, warning: fmt.Sprintf("No items found in %s", a.cfg.Schema)}, nil
+			data.warning = fmt.Sprintf("No items found in %s", a.schemaLabel())
 		}
-		return viewData{headers: headers, rows: rows, statusColumn: 2}, nil
+		return data, nil
 	case viewPools:
 		pools, err := a.spcs.ListComputePools(ctx)
 		if err != nil {
 			return viewData{}, err
 		}
-		headers := []string{"NAME", "STATE", "MIN", "MAX", "FAMILY", "AGE"}
+		headers := []string{"NAME", "STATE", "MIN", "MAX", "ACTIVE", "IDLE", "UTIL", "FAMILY", "AUTO_RESUME", "AGE"}
+		formats := map[int]ColumnFormat{6: FormatPercent, 8: FormatBool}
 		rows := make([]TableRow, 0, len(pools))
 		for _, p := range pools {
 			age := p.Age
 			if age == "" && !p.CreatedAt.IsZero() {
-				age = models.HumanizeAge(p.CreatedAt)
+				age = models.HumanizeAgeWith(a.clock, p.CreatedAt)
 			}
-			rows = append(rows, TableRow{Cells: []string{p.Name, strings.ToUpper(p.State), p.MinNodes, p.MaxNodes, p.InstanceFamily, age}})
+			rows = append(rows, TableRow{Cells: []string{p.Name, strings.ToUpper(p.State), p.MinNodes, p.MaxNodes, p.ActiveNodes, p.IdleNodes, utilizationCell(p), p.InstanceFamily, strconv.FormatBool(p.AutoResume), age}, Model: p})
 		}
 		if len(rows) == 0 {
-			return viewData{headers: headers, rows: rows, statusColumn: 1, warning: "No items found in compute pools"}, nil
+			return viewData{headers: headers, rows: rows, statusColumn: 1, warning: "No items found in compute pools", pools: pools, formats: formats}, nil
 		}
-		return viewData{headers: headers, rows: rows, statusColumn: 1}, nil
+		return viewData{headers: headers, rows: rows, statusColumn: 1, pools: pools, formats: formats}, nil
 	case viewRepos:
 		repos, err := a.spcs.ListImageRepositories(ctx)
 		if err != nil {
@@ -694,20 +1587,38 @@ func (a *App) loadViewData(ctx context.Context) (viewData, error) {
 		for _, r := range repos {
 			age := r.Age
 			if age == "" && !r.CreatedAt.IsZero() {
-				age = models.HumanizeAge(r.CreatedAt)
+				age = models.HumanizeAgeWith(a.clock, r.CreatedAt)
 			}
-			rows = append(rows, TableRow{Cells: []string{r.Name, r.RepositoryURL, r.Owner, age}})
+			rows = append(rows, TableRow{Cells: []string{r.Name, r.RepositoryURL, r.Owner, age}, Model: r})
 		}
 		if len(rows) == 0 {
 			return viewData{headers: headers, rows: rows, statusColumn: -1, warning: fmt.Sprintf("No items found in %s.%s", a.cfg.Database, a.cfg.Schema)}, nil
 		}
 		return viewData{headers: headers, rows: rows, statusColumn: -1}, nil
+	case viewImages:
+		headers := []string{"IMAGE", "TAG", "DIGEST", "PATH", "AGE"}
+		images, err := a.spcs.ListImageTags(ctx, a.activeRepo)
+		if err != nil {
+			return viewData{}, err
+		}
+		rows := make([]TableRow, 0, len(images))
+		for _, img := range images {
+			age := img.Age
+			if age == "" && !img.CreatedAt.IsZero() {
+				age = models.HumanizeAgeWith(a.clock, img.CreatedAt)
+			}
+			rows = append(rows, TableRow{Cells: []string{img.Image, img.Tag, img.Digest, img.Path, age}, Model: img})
+		}
+		if len(rows) == 0 {
+			return viewData{headers: headers, rows: rows, statusColumn: -1, warning: fmt.Sprintf("No images found in %s", a.activeRepo)}, nil
+		}
+		return viewData{headers: headers, rows: rows, statusColumn: -1}, nil
 	case viewInstances:
 		if a.activeService == "" {
 			headers := []string{"INSTANCE", "STATUS", "NODE", "AGE"}
 			return viewData{headers: headers, rows: nil, statusColumn: -1, warning: "Select a service to view instances"}, nil
 		}
-		instances, err := a.spcs.ListServiceInstances(ctx, a.activeService)
+		instances, err := a.spcsIn(a.activeSchema).ListServiceInstances(ctx, a.activeService)
 		if err != nil {
 			return viewData{}, err
 		}
@@ -716,19 +1627,369 @@ func (a *App) loadViewData(ctx context.Context) (viewData, error) {
 		for _, inst := range instances {
 			age := inst.Age
 			if age == "" && !inst.CreatedAt.IsZero() {
-				age = models.HumanizeAge(inst.CreatedAt)
+				age = models.HumanizeAgeWith(a.clock, inst.CreatedAt)
 			}
-			rows = append(rows, TableRow{Cells: []string{inst.Name, strings.ToUpper(inst.Status), inst.Node, age}})
+			rows = append(rows, TableRow{Cells: []string{inst.Name, strings.ToUpper(inst.Status), inst.Node, age}, Model: inst})
 		}
 		if len(rows) == 0 {
 			return viewData{headers: headers, rows: rows, statusColumn: 1, warning: fmt.Sprintf("No instances found for %s", a.activeService)}, nil
 		}
 		return viewData{headers: headers, rows: rows, statusColumn: 1}, nil
+	case viewJobs:
+		jobs, err := a.spcs.ListJobs(ctx)
+		if err != nil {
+			return viewData{}, err
+		}
+		headers := []string{"NAMESPACE", "NAME", "STATUS", "POOL", "COMPLETED", "AGE"}
+		rows := make([]TableRow, 0, len(jobs))
+		for _, j := range jobs {
+			rows = append(rows, TableRow{Cells: []string{j.Namespace, j.Name, strings.ToUpper(j.Status), j.ComputePool, a.formatTime(j.CompletedAt), j.Age}, Model: j})
+		}
+		if len(rows) == 0 {
+			return viewData{headers: headers, rows: rows, statusColumn: 2, warning: fmt.Sprintf("No jobs found in %s", a.cfg.Schema)}, nil
+		}
+		return viewData{headers: headers, rows: rows, statusColumn: 2}, nil
+	case viewSQL:
+		result, err := a.spcs.RunQuery(ctx, a.sqlStatement)
+		if err != nil {
+			return viewData{}, err
+		}
+		rows := make([]TableRow, 0, len(result.Rows))
+		for _, values := range result.Rows {
+			rows = append(rows, TableRow{Cells: values})
+		}
+		data := viewData{headers: result.Columns, rows: rows, statusColumn: -1}
+		if len(rows) == 0 {
+			data.warning = "No items returned by the query"
+		} else if result.Truncated {
+			data.warning = fmt.Sprintf("Showing the first %d rows; add a LIMIT to see the rest", len(rows))
+		}
+		return data, nil
+	case viewDatabases:
+		databases, err := a.spcs.ListDatabases(ctx)
+		if err != nil {
+			return viewData{}, err
+		}
+		headers := []string{"NAME", "OWNER", "CREATED", "AGE"}
+		rows := make([]TableRow, 0, len(databases))
+		for _, d := range databases {
+			rows = append(rows, TableRow{Cells: []string{d.Name, d.Owner, a.formatTime(d.CreatedAt), d.Age}, Model: d})
+		}
+		if len(rows) == 0 {
+			return viewData{headers: headers, rows: rows, statusColumn: -1, warning: "No items found in databases"}, nil
+		}
+		return viewData{headers: headers, rows: rows, statusColumn: -1}, nil
+	case viewSchemas:
+		headers := []string{"NAME", "DATABASE", "OWNER", "CREATED", "AGE"}
+		if a.cfg.Database == "" {
+			return viewData{headers: headers, statusColumn: -1, warning: "Select a database first (d)"}, nil
+		}
+		schemas, err := a.spcs.ListSchemas(ctx, a.cfg.Database)
+		if err != nil {
+			return viewData{}, err
+		}
+		rows := make([]TableRow, 0, len(schemas))
+		for _, sc := range schemas {
+			rows = append(rows, TableRow{Cells: []string{sc.Name, sc.Database, sc.Owner, a.formatTime(sc.CreatedAt), sc.Age}, Model: sc})
+		}
+		if len(rows) == 0 {
+			return viewData{headers: headers, rows: rows, statusColumn: -1, warning: fmt.Sprintf("No items found in %s", a.cfg.Database)}, nil
+		}
+		return viewData{headers: headers, rows: rows, statusColumn: -1}, nil
+	case viewEndpoints:
+		headers := []string{"NAME", "PORT", "PROTOCOL", "PUBLIC", "INGRESS_URL"}
+		formats := map[int]ColumnFormat{3: FormatBool}
+		endpoints, err := a.spcsIn(a.activeSchema).ListServiceEndpoints(ctx, a.activeService)
+		if err != nil {
+			return viewData{}, err
+		}
+		if a.prober != nil {
+			headers = append(headers, "REACHABLE")
+			formats[reachableColumn] = FormatReachability
+			a.probeEndpoints(endpoints)
+		}
+		rows := make([]TableRow, 0, len(endpoints))
+		for _, ep := range endpoints {
+			cells := []string{ep.Name, ep.Port, ep.Protocol, strconv.FormatBool(ep.Public), ep.IngressURL}
+			if a.prober != nil {
+				cells = append(cells, a.prober.label(ep))
+			}
+			rows = append(rows, TableRow{Cells: cells, Model: ep})
+		}
+		if len(rows) == 0 {
+			return viewData{headers: headers, rows: rows, statusColumn: -1, warning: fmt.Sprintf("No endpoints found for %s", a.activeService), formats: formats}, nil
+		}
+		return viewData{headers: headers, rows: rows, statusColumn: -1, formats: formats}, nil
+	case viewEvents:
+		headers := []string{"TIME", "SEVERITY", "STATUS", "CONTAINER", "INSTANCE", "MESSAGE"}
+		events, err := a.spcsIn(a.activeSchema).GetServiceEvents(ctx, a.activeService)
+		if err != nil {
+			return viewData{headers: headers, statusColumn: 1, warning: fmt.Sprintf("Events unavailable for %s: %v", a.activeService, err)}, nil
+		}
+		rows := make([]TableRow, 0, len(events))
+		for _, ev := range events {
+			rows = append(rows, TableRow{Cells: []string{a.formatTime(ev.Time), strings.ToUpper(ev.Severity), strings.ToUpper(ev.Status), ev.Container, ev.Instance, ev.Message}, Model: ev})
+		}
+		if len(rows) == 0 {
+			return viewData{headers: headers, rows: rows, statusColumn: 1, warning: fmt.Sprintf("No events found for %s", a.activeService)}, nil
+		}
+		return viewData{headers: headers, rows: rows, statusColumn: 1}, nil
 	default:
 		return viewData{}, nil
 	}
 }
 
+// displayRows swaps in the grouped rendering when it is enabled for services.
+func (a *App) displayRows(rows []TableRow) []TableRow {
+	if a.view == viewServices {
+		if a.grouped {
+			rows = groupServiceRows(a.services, a.collapsed, a.wide)
+		} else {
+			key := config.StateKey(a.cfg)
+			for i := range rows {
+				if len(rows[i].Cells) > 1 {
+					rows[i].Pinned = a.state.IsFavorite(key, a.serviceFQN(rows[i].Cells[0], rows[i].Cells[1]))
+				}
+			}
+		}
+	}
+	return a.markPending(rows)
+}
+
+func (a *App) serviceFQN(schema, name string) string {
+	return fmt.Sprintf("%s.%s.%s", a.cfg.Database, schema, name)
+}
+
+// toggleFavorite pins or unpins the selected service and persists the choice.
+func (a *App) toggleFavorite() {
+	if a.view != viewServices {
+		a.showError("Favorites are only available in the Services view")
+		return
+	}
+	row, ok := a.table.SelectedRow()
+	if !ok || row.Group != "" || len(row.Cells) < 2 {
+		return
+	}
+	a.state.ToggleFavorite(config.StateKey(a.cfg), a.serviceFQN(row.Cells[0], row.Cells[1]))
+	if err := config.SaveState(a.state); err != nil {
+		a.showError(fmt.Sprintf("Save favorites failed: %v", err))
+	}
+	a.table.SetData(a.table.Headers(), a.displayRows(serviceRows(a.services, a.wide)))
+	a.updateFooterStatus()
+}
+
+// SetProbeEndpoints adds a REACHABLE column to the endpoints view that
+// probes each public ingress URL over HTTP, as --probe-endpoints does.
+func (a *App) SetProbeEndpoints(enabled bool) {
+	a.prober = nil
+	if enabled {
+		a.prober = newEndpointProber(probeTimeout)
+	}
+}
+
+// reachableColumn is the index of REACHABLE in the endpoints view.
+const reachableColumn = 5
+
+// probeEndpoints checks endpoints in the background and fills in their
+// REACHABLE cells once the probes finish.
+func (a *App) probeEndpoints(endpoints []models.Endpoint) {
+	a.prober.probe(a.rootContext(), endpoints, func() {
+		a.safeUpdate(a.refreshReachability)
+	})
+}
+
+// refreshReachability rewrites the REACHABLE cells of the loaded endpoint
+// rows from the latest probe results. It must run on the UI goroutine.
+func (a *App) refreshReachability() {
+	if a.view != viewEndpoints || a.prober == nil {
+		return
+	}
+	for i, row := range a.rows {
+		ep, ok := row.Model.(models.Endpoint)
+		if !ok || reachableColumn >= len(row.Cells) {
+			continue
+		}
+		cells := append([]string(nil), row.Cells...)
+		cells[reachableColumn] = a.prober.label(ep)
+		a.rows[i].Cells = cells
+	}
+	a.redraw()
+}
+
+// SetWide starts the Services view with the wide columns, as -o wide does.
+func (a *App) SetWide(wide bool) {
+	a.wide = wide
+}
+
+// toggleWide switches the Services table between its default and wide
+// columns.
+func (a *App) toggleWide() {
+	if a.view != viewServices {
+		a.showError("Wide columns are only available in the Services view")
+		return
+	}
+	a.wide = !a.wide
+	a.table.SetData(serviceHeaders(a.wide), a.displayRows(serviceRows(a.services, a.wide)))
+	a.updateFooterStatus()
+}
+
+func (a *App) toggleGrouped() {
+	if a.view != viewServices {
+		a.showError("Grouping is only available in the Services view")
+		return
+	}
+	a.grouped = !a.grouped
+	a.table.SetData(a.table.Headers(), a.displayRows(serviceRows(a.services, a.wide)))
+	a.updateFooterStatus()
+}
+
+// toggleSelectedGroup expands or collapses the selected group header and
+// reports whether the selection was a group header.
+func (a *App) toggleSelectedGroup() bool {
+	row, ok := a.table.SelectedRow()
+	if !ok || row.Group == "" {
+		return false
+	}
+	a.collapsed[row.Group] = !a.collapsed[row.Group]
+	a.table.SetData(a.table.Headers(), a.displayRows(nil))
+	a.table.SelectGroup(row.Group)
+	a.updateFooterStatus()
+	return true
+}
+
+// showDSN writes the redacted connection DSN into the debug pane.
+func (a *App) showDSN() {
+	if a.debugView == nil {
+		a.showError("DSN preview requires --debug")
+		return
+	}
+	dsn, err := snowflake.RedactedDSN(a.cfg)
+	if err != nil {
+		a.showError(fmt.Sprintf("Build DSN failed: %v", err))
+		return
+	}
+	fmt.Fprintf(a.debugView, "DSN: %s\n", dsn)
+}
+
+// filterBySelectedPool narrows services to the selected row's compute pool,
+// or clears that filter when it is already active.
+func (a *App) filterBySelectedPool() {
+	if a.view != viewServices {
+		a.showError("Pool filter is only available in the Services view")
+		return
+	}
+	row, ok := a.table.SelectedRow()
+	if !ok || row.Group != "" || len(row.Cells) < 4 || row.Cells[3] == "" {
+		a.showError("Select a service with a compute pool first")
+		return
+	}
+	filter := "pool:" + row.Cells[3]
+	if a.table.Filter() == filter {
+		filter = ""
+	}
+	a.filterField.SetText(filter)
+	a.table.SetFilter(filter)
+	a.updateFooterStatus()
+}
+
+// toggleUTC flips clocks and timestamps between UTC and local time.
+func (a *App) toggleUTC() {
+	a.state.UTC = !a.state.UTC
+	a.header.SetUTC(a.state.UTC)
+	if err := config.SaveState(a.state); err != nil {
+		a.showError(fmt.Sprintf("Save preferences failed: %v", err))
+	}
+	a.updateFooterStatus()
+}
+
+// formatTime renders ts in the active time zone.
+func (a *App) formatTime(ts time.Time) string {
+	if ts.IsZero() {
+		return ""
+	}
+	if a.state.UTC {
+		ts = ts.UTC()
+	} else {
+		ts = ts.Local()
+	}
+	return ts.Format("2006-01-02 15:04:05 MST")
+}
+
+// localizeTimestamps rewrites *_on timestamp values in the active time zone.
+func (a *App) localizeTimestamps(attrs []snowflake.Attribute) []snowflake.Attribute {
+	out := make([]snowflake.Attribute, len(attrs))
+	for i, attr := range attrs {
+		out[i] = attr
+		if !strings.HasSuffix(attr.Name, "_on") {
+			continue
+		}
+		if ts := a.spcs.ParseTime(attr.Value); !ts.IsZero() {
+			out[i].Value = a.formatTime(ts)
+		}
+	}
+	return out
+}
+
+// formatContainerStatuses lists each container's readiness with the message
+// that explains a PENDING or FAILED state.
+func formatContainerStatuses(statuses []models.ContainerStatus) string {
+	if len(statuses) == 0 {
+		return "  (none reported)\n"
+	}
+	var b strings.Builder
+	for _, st := range statuses {
+		b.WriteString(fmt.Sprintf("  instance %s  %s  %s  restarts %d\n", st.InstanceID, st.ContainerName, st.Status, st.RestartCount))
+		if st.Message != "" {
+			b.WriteString(fmt.Sprintf("    %s\n", st.Message))
+		}
+	}
+	return b.String()
+}
+
+// formatPoolDetail renders a compute pool's node counts, workload, and
+// lifecycle for the details pane.
+func (a *App) formatPoolDetail(d models.ComputePoolDetail) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Compute pool: %s\n\n", d.Name))
+	b.WriteString(fmt.Sprintf("state: %s\n", strings.ToUpper(d.State)))
+	if d.StatusMessage != "" {
+		b.WriteString(fmt.Sprintf("status_message: %s\n", d.StatusMessage))
+	}
+	b.WriteString(fmt.Sprintf("instance_family: %s\n", d.InstanceFamily))
+	b.WriteString("\nNodes:\n")
+	b.WriteString(fmt.Sprintf("  min %d  max %d  target %d\n", d.MinNodes, d.MaxNodes, d.TargetNodes))
+	b.WriteString(fmt.Sprintf("  active %d  idle %d  pending %d\n", d.ActiveNodes, d.IdleNodes, d.PendingNodes()))
+	b.WriteString("\nWorkload:\n")
+	b.WriteString(fmt.Sprintf("  services %d  jobs %d\n", d.NumServices, d.NumJobs))
+	if d.Application != "" {
+		b.WriteString(fmt.Sprintf("  application %s\n", d.Application))
+	}
+	b.WriteString("\nLifecycle:\n")
+	suspend := "never"
+	if d.AutoSuspendSecs > 0 {
+		suspend = fmt.Sprintf("after %s idle", models.FormatAge(time.Duration(d.AutoSuspendSecs)*time.Second))
+	}
+	b.WriteString(fmt.Sprintf("  auto_suspend %s  auto_resume %t\n", suspend, d.AutoResume))
+	for _, ts := range []struct {
+		label string
+		at    time.Time
+	}{{"created_on", d.CreatedOn}, {"resumed_on", d.ResumedOn}, {"updated_on", d.UpdatedOn}} {
+		if !ts.at.IsZero() {
+			b.WriteString(fmt.Sprintf("  %s %s\n", ts.label, a.formatTime(ts.at)))
+		}
+	}
+	if d.Owner != "" || d.Comment != "" {
+		b.WriteString("\n")
+	}
+	if d.Owner != "" {
+		b.WriteString(fmt.Sprintf("owner: %s\n", d.Owner))
+	}
+	if d.Comment != "" {
+		b.WriteString(fmt.Sprintf("comment: %s\n", d.Comment))
+	}
+	return b.String()
+}
+
 func mapFromRow(row TableRow, headers []string) map[string]string {
 	out := make(map[string]string, len(headers))
 	for i, h := range headers {
@@ -739,6 +2000,22 @@ func mapFromRow(row TableRow, headers []string) map[string]string {
 	return out
 }
 
+// formatAttributes lists attrs in their column order, skipping empty values
+// and the named columns.
+func formatAttributes(attrs []snowflake.Attribute, skip ...string) string {
+	var b strings.Builder
+	for _, attr := range attrs {
+		if strings.TrimSpace(attr.Value) == "" || slices.Contains(skip, attr.Name) {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("%s: %s\n", attr.Name, attr.Value))
+	}
+	if b.Len() == 0 {
+		return "(no details)"
+	}
+	return b.String()
+}
+
 func formatKeyValues(values map[string]string) string {
 	if len(values) == 0 {
 		return "(no details)"
@@ -759,102 +2036,91 @@ func formatKeyValues(values map[string]string) string {
 	return b.String()
 }
 
-func (a *App) toggleHelp() {
-	if a.helpVisible {
-		a.showError("")
-		a.helpVisible = false
-		return
-	}
-	a.helpVisible = true
-	help := "j/k/↓/↑ move  g/G top/bottom  / filter  : cmd  s/p/r views  i instances  b back  enter details  esc clear  ctrl+r refresh  q quit"
-	a.showError(help)
-}
-
 func (a *App) updateFooterStatus() {
 	filterText := a.filterField.GetText()
 	parts := []string{a.table.SelectionInfo()}
+	if a.state.UTC {
+		parts = append(parts, "[UTC]")
+	}
+	if a.isPaused() {
+		parts = append(parts, "[PAUSED]")
+	}
+	if wait := a.retry.remaining(a.clock.Now()); wait > 0 {
+		parts = append(parts, fmt.Sprintf("retrying in %ds…", int(wait.Round(time.Second)/time.Second)))
+	}
+	if stale := a.staleness(); stale != "" {
+		parts = append(parts, stale)
+	}
 	if a.inputMode == inputFilter && strings.TrimSpace(filterText) != "" {
-		parts = append(parts, fmt.Sprintf("filter: %s", filterText))
+		parts = append(parts, a.filterStatus(filterText))
+	} else if pool, ok := strings.CutPrefix(a.table.Filter(), "pool:"); ok {
+		parts = append(parts, fmt.Sprintf("[pool: %s]", pool))
 	}
-	a.footer.SetStatus(strings.Join(parts, "  "))
-}
-
-// DebugWriter streams logs into the debug pane when enabled.
-func (a *App) DebugWriter() io.Writer {
-	if a.debugView == nil {
-		return nil
+	if summary := a.serviceSummary(); summary != "" {
+		parts = append(parts, summary)
 	}
-	return &textViewWriter{app: a.app, view: a.debugView}
+	if slow := a.slowQueryWarning(); slow != "" {
+		parts = append(parts, slow)
+	}
+	a.footer.SetStatus(strings.Join(parts, "  "))
 }
 
-// PrintTable renders a k9s-like table to stdout for the CLI list command.
-func PrintTable(services []models.Service) {
-	headers := []string{"NAMESPACE", "NAME", "STATUS", "POOL", "AGE"}
-	rows := make([][]string, 0, len(services))
-	for _, s := range services {
-		status := strings.ToUpper(s.Status)
-		age := s.Age
-		if age == "" && !s.CreatedAt.IsZero() {
-			age = models.HumanizeAge(s.CreatedAt)
-		}
-		rows = append(rows, []string{s.Namespace, s.Name, status, s.ComputePool, age})
-	}
+// slowQueryMaxLen caps how much of a slow statement the footer repeats.
+const slowQueryMaxLen = 40
 
-	widths := make([]int, len(headers))
-	for i, h := range headers {
-		widths[i] = len(h)
+// slowQueryWarning flags the last query when it took longer than
+// slow_query_threshold, e.g. "slow query: SHOW SERVICES took 4.2s".
+func (a *App) slowQueryWarning() string {
+	if a.spcs == nil {
+		return ""
 	}
-	for _, row := range rows {
-		for i, v := range row {
-			if l := len(v); l > widths[i] {
-				widths[i] = l
-			}
-		}
-	}
-
-	drawLine := func(left, mid, right string) {
-		fmt.Print(left)
-		for i, w := range widths {
-			fmt.Print(strings.Repeat("─", w+2))
-			if i < len(widths)-1 {
-				fmt.Print(mid)
-			}
-		}
-		fmt.Println(right)
+	timing, ok := a.spcs.LastQuery()
+	if !ok || !timing.Slow {
+		return ""
 	}
+	timing.Query = truncateCell(strings.Join(strings.Fields(timing.Query), " "), slowQueryMaxLen)
+	return "[yellow]" + tview.Escape(timing.String()) + "[-]"
+}
 
-	drawLine("┌", "┬", "┐")
-	fmt.Print("│")
-	for i, h := range headers {
-		fmt.Printf(" %-*s ", widths[i], h)
-		fmt.Print("│")
-	}
-	fmt.Println()
-	drawLine("├", "┼", "┤")
-	for _, row := range rows {
-		fmt.Print("│")
-		for i, v := range row {
-			fmt.Printf(" %-*s ", widths[i], v)
-			fmt.Print("│")
-		}
-		fmt.Println()
+// filterStatus describes the filter being typed, noting any fallback.
+func (a *App) filterStatus(text string) string {
+	if note := a.table.FilterNote(); note != "" {
+		return fmt.Sprintf("filter: %s (%s)", text, note)
 	}
-	drawLine("└", "┴", "┘")
+	return fmt.Sprintf("filter: %s", text)
 }
 
-func defaultKeyHints() []string {
-	return []string{"j/k/↓/↑ Move", "g/G Top/Bottom", "ctrl+d/ctrl+u Page", "s/p/r Views", "i Instances", "b Back", "enter Details", "/ Filter", ": Cmd", "ctrl+r Refresh", "q Quit"}
+// DebugWriter streams logs into the debug pane when enabled.
+func (a *App) DebugWriter() io.Writer {
+	if a.debugView == nil {
+		return nil
+	}
+	return &textViewWriter{app: a, view: a.debugView}
 }
 
 type textViewWriter struct {
-	app  *tview.Application
+	app  *App
 	view *tview.TextView
 }
 
 func (w *textViewWriter) Write(p []byte) (int, error) {
-	msg := string(p)
-	w.app.QueueUpdateDraw(func() {
+	msg := debugLine(string(p))
+	w.app.safeUpdate(func() {
 		fmt.Fprint(w.view, msg)
 	})
 	return len(p), nil
 }
+
+// debugLine renders a log_format json line as time, level, and message so
+// the debug pane stays readable; text lines pass through unchanged.
+func debugLine(msg string) string {
+	entry, ok := snowflake.ParseLogEntry(strings.TrimSpace(msg))
+	if !ok {
+		return msg
+	}
+	line := entry.Time.Local().Format("15:04:05") + " "
+	if entry.Level != snowflake.LevelDebug {
+		line += strings.ToUpper(entry.Level) + " "
+	}
+	return tview.Escape(line+entry.String()) + "\n"
+}
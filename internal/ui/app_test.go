@@ -0,0 +1,668 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/marcelinojackson-org/snow9s/internal/config"
+	"github.com/marcelinojackson-org/snow9s/internal/snowflake"
+	"github.com/marcelinojackson-org/snow9s/pkg/models"
+)
+
+func newTestApp(t *testing.T) *App {
+	t.Helper()
+	t.Setenv("SNOW9S_CONFIG", filepath.Join(t.TempDir(), "config.yaml"))
+	return NewApp(config.Config{Database: "DB", Schema: "PUBLIC"}, nil, false)
+}
+
+// newTestAppWithDB is newTestApp backed by a sqlmock, so tests can expect
+// the queries the app issues.
+func newTestAppWithDB(t *testing.T) (*App, sqlmock.Sqlmock) {
+	t.Helper()
+	t.Setenv("SNOW9S_CONFIG", filepath.Join(t.TempDir(), "config.yaml"))
+	return newMockApp(t, config.Config{Database: "DB", Schema: "PUBLIC"})
+}
+
+// newMockApp builds an app for cfg on a sqlmock that is closed when the test
+// ends, keeping whatever config path the test has set.
+func newMockApp(t *testing.T, cfg config.Config) (*App, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewApp(cfg, snowflake.NewSPCS(db, cfg), false), mock
+}
+
+func TestSafeUpdateDropsAfterStop(t *testing.T) {
+	app := newTestApp(t)
+	app.stop()
+	called := false
+	// More updates than tview's queue holds; none may block once stopped.
+	for i := 0; i < 200; i++ {
+		app.safeUpdate(func() { called = true })
+	}
+	if called {
+		t.Fatalf("update ran after stop")
+	}
+}
+
+func TestDebugLineRendersJSON(t *testing.T) {
+	line := `{"timestamp":"2026-01-02T03:04:05Z","level":"error","msg":"query","query":"SHOW SERVICES","duration_ms":42,"error":"boom"}` + "\n"
+	got := debugLine(line)
+	if !strings.Contains(got, "ERROR SQL: SHOW SERVICES (42ms): boom") || strings.Contains(got, "{") {
+		t.Fatalf("expected a readable line, got %q", got)
+	}
+	if text := "snow9s SQL: SHOW SERVICES\n"; debugLine(text) != text {
+		t.Fatalf("text lines should pass through, got %q", debugLine(text))
+	}
+}
+
+// timedQueryable is a Queryable that reports a fixed last-query timing.
+type timedQueryable struct {
+	snowflake.Queryable
+	timing snowflake.QueryTiming
+}
+
+func (q timedQueryable) LastQuery() (snowflake.QueryTiming, bool) {
+	return q.timing, true
+}
+
+func TestSlowQueryWarning(t *testing.T) {
+	t.Setenv("SNOW9S_CONFIG", filepath.Join(t.TempDir(), "config.yaml"))
+	cfg := config.Config{Database: "DB", Schema: "PUBLIC"}
+	client := timedQueryable{timing: snowflake.QueryTiming{Query: "SHOW SERVICES IN SCHEMA DB.PUBLIC", Elapsed: 4200 * time.Millisecond, Slow: true}}
+	app := NewApp(cfg, snowflake.NewSPCS(client, cfg), false)
+	if got := app.slowQueryWarning(); !strings.Contains(got, "slow query: SHOW SERVICES IN SCHEMA DB.PUBLIC took 4.2s") {
+		t.Fatalf("expected slow query warning, got %q", got)
+	}
+	client.timing.Slow = false
+	app = NewApp(cfg, snowflake.NewSPCS(client, cfg), false)
+	if got := app.slowQueryWarning(); got != "" {
+		t.Fatalf("fast queries should not warn, got %q", got)
+	}
+}
+
+func TestStalenessUsesClock(t *testing.T) {
+	app := newTestApp(t)
+	clock := models.NewFakeClock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	app.clock = clock
+	if got := app.staleness(); got != "" {
+		t.Fatalf("expected no staleness before first load got %q", got)
+	}
+	app.lastRefresh = clock.Now()
+	clock.Advance(staleAfter)
+	if got := app.staleness(); got != "" {
+		t.Fatalf("expected fresh data at threshold got %q", got)
+	}
+	clock.Advance(30 * time.Second)
+	if got := app.staleness(); got != "[stale 45s]" {
+		t.Fatalf("expected [stale 45s] got %q", got)
+	}
+}
+
+func TestToggleWrapPersists(t *testing.T) {
+	t.Setenv("SNOW9S_CONFIG", filepath.Join(t.TempDir(), "config.yaml"))
+	app := NewApp(config.Config{Database: "DB", Schema: "PUBLIC"}, nil, true)
+	app.toggleWrap(wrapDebug)
+	st, err := config.LoadState(config.Config{})
+	if err != nil {
+		t.Fatalf("load state: %v", err)
+	}
+	if !st.NoWrap[wrapDebug] || st.NoWrap[wrapDetail] {
+		t.Fatalf("unexpected wrap state %+v", st.NoWrap)
+	}
+	app.toggleWrap(wrapDebug)
+	if app.state.NoWrap[wrapDebug] {
+		t.Fatalf("second toggle should re-enable wrapping")
+	}
+}
+
+func TestToggleUTC(t *testing.T) {
+	app := newTestApp(t)
+	attrs := []snowflake.Attribute{
+		{Name: "created_on", Value: "2024-03-01 12:30:00.000 +0100"},
+		{Name: "comment", Value: "2024-03-01 12:30:00.000 +0100"},
+	}
+	created := time.Date(2024, 3, 1, 11, 30, 0, 0, time.UTC)
+
+	app.toggleUTC()
+	st, err := config.LoadState(config.Config{})
+	if err != nil {
+		t.Fatalf("load state: %v", err)
+	}
+	if !st.UTC || !app.header.utc {
+		t.Fatalf("toggle did not switch to UTC")
+	}
+	if !strings.Contains(app.header.render(), " UTC ") {
+		t.Fatalf("header clock not in UTC: %q", app.header.render())
+	}
+	if !strings.Contains(app.footer.status, "[UTC]") {
+		t.Fatalf("footer does not mark UTC")
+	}
+	got := app.localizeTimestamps(attrs)
+	if got[0].Value != "2024-03-01 11:30:00 UTC" || got[1].Value != attrs[1].Value {
+		t.Fatalf("unexpected UTC timestamps %+v", got)
+	}
+
+	app.toggleUTC()
+	if app.state.UTC || app.header.utc {
+		t.Fatalf("second toggle should return to local time")
+	}
+	if got := app.localizeTimestamps(attrs); got[0].Value != created.Local().Format("2006-01-02 15:04:05 MST") {
+		t.Fatalf("unexpected local timestamp %q", got[0].Value)
+	}
+}
+
+func TestRefreshDuringMutationKeepsPendingMarker(t *testing.T) {
+	app := newTestApp(t)
+	app.view = viewPools
+	headers := []string{"NAME", "STATE"}
+	data := func(state string) viewData {
+		return viewData{headers: headers, rows: []TableRow{{Cells: []string{"pool1", state}}}, statusColumn: 1}
+	}
+	status := func() string { return strings.TrimSpace(app.table.GetCell(1, 1).Text) }
+
+	gen, _ := app.fetchGeneration()
+	if !app.applyViewData(data("ACTIVE"), nil, gen) || status() != "ACTIVE" {
+		t.Fatalf("initial load not applied, status %q", status())
+	}
+
+	// A refresh starts, then the user suspends before it returns.
+	staleGen, _ := app.fetchGeneration()
+	app.beginMutation("pool1", "SUSPENDING")
+	if status() != "SUSPENDING" {
+		t.Fatalf("expected optimistic marker got %q", status())
+	}
+	if app.applyViewData(data("ACTIVE"), nil, staleGen) || status() != "SUSPENDING" {
+		t.Fatalf("stale refresh overwrote pending marker, status %q", status())
+	}
+	if _, busy := app.fetchGeneration(); !busy {
+		t.Fatalf("refresh should pause while a mutation is in flight")
+	}
+
+	app.endMutation("pool1")
+	gen, busy := app.fetchGeneration()
+	if busy || gen == staleGen {
+		t.Fatalf("expected new idle generation after mutation")
+	}
+	if !app.applyViewData(data("SUSPENDED"), nil, gen) || status() != "SUSPENDED" {
+		t.Fatalf("post-mutation refresh not applied, status %q", status())
+	}
+}
+
+func TestCancelFetch(t *testing.T) {
+	app := newTestApp(t)
+	if app.cancelFetch() {
+		t.Fatalf("nothing in flight, cancel should report false")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	app.fetchCancel = cancel
+	esc := tcell.NewEventKey(tcell.KeyEsc, 0, tcell.ModNone)
+	if app.handleKey(esc) || ctx.Err() != nil {
+		t.Fatalf("esc must fall through and leave an automatic refresh running")
+	}
+	app.fetchManual = true
+	if !app.handleKey(esc) || ctx.Err() == nil {
+		t.Fatalf("expected the fetch the user started to be cancelled")
+	}
+	ctx, cancel = context.WithCancel(context.Background())
+	app.fetchCancel = cancel
+	if !app.cancelFetch() || ctx.Err() == nil {
+		t.Fatalf("expected in-flight fetch to be cancelled")
+	}
+	gen, _ := app.fetchGeneration()
+	app.applyViewData(viewData{}, fmt.Errorf("query services: %w", context.Canceled), gen)
+	if app.retry.pending() || app.lastError != nil {
+		t.Fatalf("a cancelled fetch must not count as a failure")
+	}
+}
+
+func TestOverlappingFetchesClaimLoadingOnce(t *testing.T) {
+	app := newTestApp(t)
+	app.stopped.Store(true)
+	if app.rootContext() == nil {
+		t.Fatal("root context must default before Run")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	app.ctx = ctx
+	cancel()
+	if app.rootContext().Err() == nil {
+		t.Fatal("key handlers should inherit the app's cancellation")
+	}
+
+	if !app.claimLoading() {
+		t.Fatal("first fetch should claim loading")
+	}
+	if app.claimLoading() {
+		t.Fatal("an overlapping fetch must not claim loading")
+	}
+	app.setLoading(false)
+	if !app.claimLoading() {
+		t.Fatal("loading should be claimable once the fetch finishes")
+	}
+	app.setLoading(false)
+}
+
+func TestSpinnerRunsOnce(t *testing.T) {
+	app := newTestApp(t)
+	app.stopped.Store(true)
+	app.clock = models.NewFakeClock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	if !app.claimLoading() {
+		t.Fatal("first fetch should claim loading")
+	}
+	app.setLoading(false)
+	// The spinner may not have noticed the end yet; a new fetch reuses it.
+	app.claimLoading()
+	if app.startSpinner() {
+		t.Fatal("a second spinner started while one is running")
+	}
+	app.setLoading(false)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		app.refreshMu.Lock()
+		spinning := app.spinning
+		app.refreshMu.Unlock()
+		if !spinning {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("spinner did not exit after loading ended")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSpinnerHints(t *testing.T) {
+	hints := spinnerHints(1, 3*time.Second, "SHOW SERVICES", true)
+	if len(hints) != 2 || hints[0] != "⠙ running SHOW SERVICES… (3s)" || hints[1] != "esc Cancel" {
+		t.Fatalf("unexpected hints: %q", hints)
+	}
+	if hints := spinnerHints(1, 3*time.Second, "SHOW SERVICES", false); len(hints) != 1 {
+		t.Fatalf("automatic refreshes cannot be cancelled, got %q", hints)
+	}
+}
+
+func TestCreatedColumn(t *testing.T) {
+	created := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	svc := models.Service{CreatedAt: created, Age: "2h"}
+	if got := createdColumn(svc.CreatedAt, svc.Age, false); got != "2h" {
+		t.Fatalf("expected humanized age got %q", got)
+	}
+	if got := createdColumn(svc.CreatedAt, svc.Age, true); got != "2024-03-01T12:30:00Z" {
+		t.Fatalf("expected RFC3339 timestamp got %q", got)
+	}
+	if got := createdColumn(time.Time{}, "", true); got != "" {
+		t.Fatalf("expected empty column for unknown creation time got %q", got)
+	}
+}
+
+func TestRunCommandSwitchesViewAndDropsStaleFetch(t *testing.T) {
+	app, _ := newTestAppWithDB(t)
+
+	gen, _ := app.fetchGeneration()
+	app.runCommand("pools")
+	if app.view != viewPools || app.header.viewTag != string(viewPools) {
+		t.Fatalf("expected pools view got %q (header %q)", app.view, app.header.viewTag)
+	}
+	services := viewData{headers: []string{"NAMESPACE", "NAME"}, rows: []TableRow{{Cells: []string{"PUBLIC", "svc1"}}}}
+	if app.applyViewData(services, nil, gen) {
+		t.Fatalf("a services fetch started before the switch must not render in the pools view")
+	}
+
+	// The app is not running; drop the error update instead of blocking on it.
+	app.stopped.Store(true)
+	app.runCommand("bogus")
+	if app.view != viewPools {
+		t.Fatalf("unknown command changed the view to %q", app.view)
+	}
+}
+
+func TestServicesAllSchemas(t *testing.T) {
+	app, mock := newTestAppWithDB(t)
+	app.stopped.Store(true)
+
+	// Switch scope directly rather than through runCommand's background fetch.
+	if !app.setAllSchemas(true) || app.header.cfg.Schema != allSchemasLabel {
+		t.Fatalf("expected all schemas scope, header shows %q", app.header.cfg.Schema)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(`SHOW SERVICES IN DATABASE "DB"`)).WillReturnRows(
+		sqlmock.NewRows([]string{"name", "schema_name", "status"}).
+			AddRow("api", "PUBLIC", "RUNNING").
+			AddRow("api", "STAGING", "SUSPENDED"))
+	data, err := app.loadViewData(context.Background())
+	if err != nil {
+		t.Fatalf("loadViewData: %v", err)
+	}
+	if len(data.rows) != 2 || data.rows[1].Cells[0] != "STAGING" {
+		t.Fatalf("expected the namespace column to show each schema: %+v", data.rows)
+	}
+
+	// Actions address the selected row's schema, not the first service of
+	// that name.
+	app.cfg.SkipConfirmations = true
+	app.table.SetData(data.headers, data.rows)
+	app.table.Select(2, 0)
+	mock.ExpectQuery(regexp.QuoteMeta(`ALTER SERVICE "DB"."STAGING"."api" RESUME`)).
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("Statement executed successfully."))
+	app.resumeService()
+	deadline := time.Now().Add(2 * time.Second)
+	for mock.ExpectationsWereMet() != nil && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expected the STAGING service to be resumed: %v", err)
+	}
+
+	app.setAllSchemas(false)
+	if app.spcs.AllSchemas() || app.header.cfg.Schema != "PUBLIC" {
+		t.Fatalf("expected the active schema again, header shows %q", app.header.cfg.Schema)
+	}
+
+	app.cfg.Database = ""
+	app.runCommand("services all")
+	if app.spcs.AllSchemas() {
+		t.Fatalf(":services all without a database must not change the scope")
+	}
+}
+
+func TestSQLCommand(t *testing.T) {
+	app, mock := newTestAppWithDB(t)
+	app.stopped.Store(true)
+
+	for _, stmt := range []string{"DROP SERVICE api", "SELECT 1; DROP SERVICE api"} {
+		app.runCommand("sql " + stmt)
+		if app.view != viewServices || app.sqlStatement != "" {
+			t.Fatalf("%q must not open the SQL view: view %q statement %q", stmt, app.view, app.sqlStatement)
+		}
+	}
+	app.runCommand("sql")
+	if app.view != viewServices {
+		t.Fatalf(":sql without a statement or history changed the view to %q", app.view)
+	}
+
+	// Load directly rather than through runCommand's background fetch.
+	app.view = viewSQL
+	app.sqlStatement = "SELECT name, status FROM t"
+	mock.ExpectQuery(`SELECT name, status FROM t`).WillReturnRows(
+		sqlmock.NewRows([]string{"NAME", "STATUS"}).AddRow("api", "RUNNING"))
+	data, err := app.loadViewData(context.Background())
+	if err != nil {
+		t.Fatalf("loadViewData: %v", err)
+	}
+	if strings.Join(data.headers, ",") != "NAME,STATUS" || len(data.rows) != 1 || data.rows[0].Cells[0] != "api" {
+		t.Fatalf("unexpected SQL view data: %+v", data)
+	}
+	if app.refreshDue(time.Now(), time.Time{}) {
+		t.Fatal("the SQL view must not refresh automatically")
+	}
+
+	// A failed statement must not be retried on the backoff schedule either.
+	app.retry.fail(time.Now().Add(-time.Hour))
+	if app.refreshDue(time.Now(), time.Time{}) {
+		t.Fatal("a failed SQL statement must only rerun on Ctrl+r")
+	}
+}
+
+func TestPauseStopsAutomaticRefresh(t *testing.T) {
+	app := newTestApp(t)
+	now := time.Now()
+	last := now.Add(-2 * refreshInterval)
+	if !app.refreshDue(now, last) {
+		t.Fatalf("expected a refresh to be due")
+	}
+	app.togglePause()
+	if app.refreshDue(now, last) {
+		t.Fatalf("paused loop must not refresh")
+	}
+	if !strings.Contains(app.footer.status, "[PAUSED]") {
+		t.Fatalf("footer missing pause indicator: %q", app.footer.status)
+	}
+	app.togglePause()
+	if !app.refreshDue(now, last) || strings.Contains(app.footer.status, "[PAUSED]") {
+		t.Fatalf("resume should restore automatic refresh")
+	}
+}
+
+func TestRemoveRow(t *testing.T) {
+	app := newTestApp(t)
+	app.rows = []TableRow{
+		{Cells: []string{"PUBLIC", "api", "RUNNING", "pool", "1d"}},
+		{Cells: []string{"PUBLIC", "worker", "RUNNING", "pool", "1d"}},
+	}
+	app.removeRow("api")
+	if len(app.rows) != 1 || app.rows[0].Cells[1] != "worker" {
+		t.Fatalf("unexpected rows after removal: %+v", app.rows)
+	}
+}
+
+func TestNavigationOnEmptyFilterResult(t *testing.T) {
+	app := newTestApp(t)
+	app.stopped.Store(true)
+	app.pages = tview.NewPages().AddPage("main", app.table, true, true)
+	app.table.SetData([]string{"NAMESPACE", "NAME"}, []TableRow{{Cells: []string{"PUBLIC", "api"}}})
+	app.table.SetFilter("nomatch")
+
+	app.move(1)
+	app.move(-1)
+	app.page(1)
+	app.selectRow(1)
+	for _, r := range []rune{'j', 'k', 'g', 'G'} {
+		app.handleKey(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+	}
+	app.handleKey(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+	if row, _ := app.table.GetSelection(); row == 0 {
+		t.Fatal("navigation on an empty table selected the header")
+	}
+	if app.detailVisible || app.describeVisible {
+		t.Fatal("enter on an empty table must not open anything")
+	}
+}
+
+func TestEnterOnRepoOpensImages(t *testing.T) {
+	app, _ := newTestAppWithDB(t)
+	app.stopped.Store(true)
+	app.view = viewRepos
+	app.table.SetData([]string{"NAME", "REPO_URL", "OWNER", "AGE"}, []TableRow{{Cells: []string{"REPO1", "url", "SYSADMIN", "1d"}}})
+
+	app.handleKey(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+	if app.view != viewImages || app.activeRepo != "REPO1" {
+		t.Fatalf("expected images of REPO1, got view %q repo %q", app.view, app.activeRepo)
+	}
+	app.handleKey(tcell.NewEventKey(tcell.KeyRune, 'b', tcell.ModNone))
+	if app.view != viewRepos {
+		t.Fatalf("b should return to repos, got %q", app.view)
+	}
+}
+
+func TestEscKeepsAppliedFilter(t *testing.T) {
+	app := newTestApp(t)
+	app.stopped.Store(true)
+	app.pages = tview.NewPages().AddPage("main", app.table, true, true)
+	app.table.SetData([]string{"NAMESPACE", "NAME"}, []TableRow{{Cells: []string{"PUBLIC", "api"}}, {Cells: []string{"PUBLIC", "worker"}}})
+
+	app.handleKey(tcell.NewEventKey(tcell.KeyRune, '/', tcell.ModNone))
+	app.filterField.SetText("api")
+	app.completeInput(tcell.KeyEnter)
+
+	app.toggleHelp()
+	app.handleKey(tcell.NewEventKey(tcell.KeyEsc, 0, tcell.ModNone))
+	if app.helpVisible || app.table.Filter() != "api" {
+		t.Fatalf("esc on help should only close it: help=%v filter=%q", app.helpVisible, app.table.Filter())
+	}
+	app.handleKey(tcell.NewEventKey(tcell.KeyEsc, 0, tcell.ModNone))
+	if app.table.Filter() != "api" {
+		t.Fatalf("esc on the table cleared the filter")
+	}
+
+	app.handleKey(tcell.NewEventKey(tcell.KeyRune, '/', tcell.ModNone))
+	app.filterField.SetText("work")
+	app.handleKey(tcell.NewEventKey(tcell.KeyEsc, 0, tcell.ModNone))
+	if app.table.Filter() != "" || app.inputMode != inputNone {
+		t.Fatalf("esc in the filter field should clear it, got %q", app.table.Filter())
+	}
+}
+
+func TestStatusFilterIsClientSide(t *testing.T) {
+	app, mock := newTestAppWithDB(t)
+
+	mock.ExpectQuery("SHOW SERVICES").WillReturnRows(
+		sqlmock.NewRows([]string{"name", "status"}).AddRow("api", "RUNNING").AddRow("job", "FAILED"))
+	app.table.SetFilter("status:failed")
+	data, err := app.loadViewData(context.Background())
+	if err != nil {
+		t.Fatalf("loadViewData: %v", err)
+	}
+	if len(data.services) != 2 {
+		t.Fatalf("the query should list every service, got %+v", data.services)
+	}
+	app.table.SetData(data.headers, data.rows)
+	if app.table.Len() != 1 {
+		t.Fatalf("expected the filter to show only the failed service, got %d rows", app.table.Len())
+	}
+	app.recordCounts(data)
+	if app.counts.runningServices != 1 {
+		t.Fatalf("the running count comes from the full list, got %d", app.counts.runningServices)
+	}
+}
+
+func TestDemoDataRenders(t *testing.T) {
+	t.Setenv("SNOW9S_CONFIG", filepath.Join(t.TempDir(), "config.yaml"))
+	cfg := snowflake.DemoConfig(config.Config{})
+	client := snowflake.NewDemoClient(cfg, nil)
+	defer client.Close()
+	app := NewApp(cfg, snowflake.NewSPCS(client, cfg), false)
+
+	data, err := app.loadViewData(context.Background())
+	if err != nil {
+		t.Fatalf("loadViewData: %v", err)
+	}
+	if len(data.rows) != 5 || data.warning != "" {
+		t.Fatalf("expected the 5 demo services, got %d rows (%q)", len(data.rows), data.warning)
+	}
+	if cells := data.rows[0].Cells; cells[1] != "API_GATEWAY" || cells[2] != "RUNNING" || cells[3] != "CPU_POOL" {
+		t.Fatalf("unexpected first row %v", cells)
+	}
+	app.view = viewPools
+	if data, err = app.loadViewData(context.Background()); err != nil || len(data.rows) != 3 {
+		t.Fatalf("expected the 3 demo pools, got %+v: %v", data.rows, err)
+	}
+}
+
+func TestErrorClearsAfterTimeout(t *testing.T) {
+	app := newTestApp(t)
+	clock := models.NewFakeClock(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	app.clock = clock
+	app.errorTimeout = 10 * time.Second
+
+	app.setError("Refresh failed: timeout")
+	clock.Advance(8 * time.Second)
+	app.setError("Refresh failed: network")
+	clock.Advance(8 * time.Second)
+	app.clearExpiredError()
+	if got := app.errorView.GetText(false); got != "Refresh failed: network" {
+		t.Fatalf("a new error must restart the countdown, got %q", got)
+	}
+	clock.Advance(2 * time.Second)
+	app.clearExpiredError()
+	if got := app.errorView.GetText(false); got != "" {
+		t.Fatalf("expected the error to clear, got %q", got)
+	}
+
+	app.setError("No items found in PUBLIC")
+	clock.Advance(time.Hour)
+	if app.errorExpired(clock.Now()) {
+		t.Fatalf("notices must not expire")
+	}
+
+	app.errorTimeout = 0
+	app.setError("Refresh failed: timeout")
+	clock.Advance(time.Hour)
+	if app.errorExpired(clock.Now()) {
+		t.Fatalf("a zero timeout keeps errors")
+	}
+}
+
+func TestMouseMovesAndOpensSelection(t *testing.T) {
+	app, _ := newTestAppWithDB(t)
+	app.stopped.Store(true)
+	app.view = viewRepos
+	app.table.SetRect(0, 0, 80, 10)
+	app.table.SetData([]string{"NAME", "REPO_URL", "OWNER", "AGE"}, []TableRow{
+		{Cells: []string{"REPO1", "url", "SYSADMIN", "1d"}},
+		{Cells: []string{"REPO2", "url", "SYSADMIN", "2d"}},
+	})
+	app.table.Select(1, 0)
+	mouse := func(action tview.MouseAction, y int) {
+		app.handleMouse(action, tcell.NewEventMouse(5, y, tcell.ButtonNone, tcell.ModNone))
+	}
+
+	mouse(tview.MouseScrollDown, 0)
+	mouse(tview.MouseScrollDown, 0)
+	if row, _ := app.table.GetSelection(); row != 2 {
+		t.Fatalf("wheel should stop at the last row, got %d", row)
+	}
+	if !strings.HasPrefix(app.footer.status, "2/2") {
+		t.Fatalf("footer not updated: %q", app.footer.status)
+	}
+	mouse(tview.MouseScrollUp, 0)
+	if row, _ := app.table.GetSelection(); row != 1 {
+		t.Fatalf("wheel up should select row 1, got %d", row)
+	}
+
+	mouse(tview.MouseLeftClick, 0)
+	if row, _ := app.table.GetSelection(); row != 1 {
+		t.Fatalf("clicking the header must not change the selection, got %d", row)
+	}
+	mouse(tview.MouseLeftClick, 2)
+	if row, _ := app.table.GetSelection(); row != 2 || app.view != viewRepos {
+		t.Fatalf("click should select row 2 without opening it, got row %d view %q", row, app.view)
+	}
+	mouse(tview.MouseLeftDoubleClick, 2)
+	if app.view != viewImages || app.activeRepo != "REPO2" {
+		t.Fatalf("double click should open the images of REPO2, got view %q repo %q", app.view, app.activeRepo)
+	}
+}
+
+func TestJobsView(t *testing.T) {
+	app, mock := newTestAppWithDB(t)
+	app.state.UTC = true
+	app.view = viewJobs
+
+	mock.ExpectQuery("SHOW JOB SERVICES").WillReturnRows(
+		sqlmock.NewRows([]string{"name", "status", "created_on", "updated_on"}).
+			AddRow("train", "FAILED", "2024-01-01 00:00:00 +0000", "2024-01-02 00:00:00 +0000"))
+	data, err := app.loadViewData(context.Background())
+	if err != nil {
+		t.Fatalf("loadViewData: %v", err)
+	}
+	if len(data.rows) != 1 || data.statusColumn != 2 {
+		t.Fatalf("unexpected jobs data: %+v", data)
+	}
+	cells := data.rows[0].Cells
+	if cells[1] != "train" || cells[2] != "FAILED" || cells[4] != "2024-01-02 00:00:00 UTC" {
+		t.Fatalf("unexpected job row: %q", cells)
+	}
+	app.table.SetData(data.headers, data.rows)
+	app.table.Select(1, 0)
+	if got := app.selectedName(); got != "train" {
+		t.Fatalf("expected the job name to be the selected name, got %q", got)
+	}
+	if got := app.styles.StatusColor("done"); got != app.styles.StatusRunning {
+		t.Fatalf("done jobs should use the running color")
+	}
+	if got := app.styles.StatusColor(cells[2]); got != app.styles.StatusStopped {
+		t.Fatalf("failed jobs should use the stopped color")
+	}
+}
@@ -0,0 +1,123 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/marcelinojackson-org/snow9s/pkg/models"
+)
+
+// clipboardCommands are tried in order; the first one on PATH wins.
+var clipboardCommands = [][]string{
+	{"pbcopy"},
+	{"wl-copy"},
+	{"xclip", "-selection", "clipboard"},
+	{"xsel", "--clipboard", "--input"},
+	{"clip.exe"},
+}
+
+// copyToClipboard writes text to the system clipboard using the platform
+// clipboard utility.
+func copyToClipboard(text string) error {
+	for _, args := range clipboardCommands {
+		path, err := exec.LookPath(args[0])
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(path, args[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("%s: %w", args[0], err)
+		}
+		return nil
+	}
+	return errNoClipboard
+}
+
+// errNoClipboard reports that no clipboard utility is installed, e.g. on a
+// headless machine.
+var errNoClipboard = errors.New("clipboard unavailable (install pbcopy, wl-copy, xclip or xsel)")
+
+// copyText puts text on the clipboard and reports what was copied in the
+// footer. A missing clipboard is a footer note rather than an error.
+func (a *App) copyText(text, what string) {
+	if err := a.clipboard(text); err != nil {
+		if errors.Is(err, errNoClipboard) {
+			a.footer.SetStatus(fmt.Sprintf("%s  clipboard unavailable", a.table.SelectionInfo()))
+			return
+		}
+		a.showError(fmt.Sprintf("Copy failed: %v", err))
+		return
+	}
+	a.footer.SetStatus(fmt.Sprintf("%s  copied %s", a.table.SelectionInfo(), what))
+}
+
+// yankRow copies the selected row's cells, tab-separated.
+func (a *App) yankRow() {
+	row, ok := a.table.SelectedRow()
+	if !ok || row.Group != "" {
+		a.footer.SetStatus(fmt.Sprintf("%s  nothing to copy", a.table.SelectionInfo()))
+		return
+	}
+	a.copyText(strings.Join(row.Cells, "\t"), "row")
+}
+
+// yankName copies the selected row's resource name.
+func (a *App) yankName() {
+	name := a.selectedName()
+	if name == "" {
+		a.footer.SetStatus(fmt.Sprintf("%s  nothing to copy", a.table.SelectionInfo()))
+		return
+	}
+	a.copyText(name, name)
+}
+
+// yankVisibleJSON copies the records behind the visible, filtered rows to the
+// clipboard as a JSON array.
+func (a *App) yankVisibleJSON() {
+	items := a.table.VisibleModels()
+	if len(items) == 0 {
+		a.footer.SetStatus(fmt.Sprintf("%s  nothing to copy", a.table.SelectionInfo()))
+		return
+	}
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		a.showError(fmt.Sprintf("Encode JSON failed: %v", err))
+		return
+	}
+	a.copyText(string(data), fmt.Sprintf("%d %s as JSON", len(items), strings.ToLower(string(a.view))))
+}
+
+// copyEndpointURL copies the selected endpoint's ingress URL.
+func (a *App) copyEndpointURL() {
+	if a.view != viewEndpoints {
+		return
+	}
+	row, ok := a.table.SelectedRow()
+	ep, isEndpoint := row.Model.(models.Endpoint)
+	if !ok || !isEndpoint {
+		return
+	}
+	url := endpointURL(ep)
+	if url == "" {
+		a.footer.SetStatus(fmt.Sprintf("%s  %s has no public URL", a.table.SelectionInfo(), ep.Name))
+		return
+	}
+	a.copyText(url, url)
+}
+
+// endpointURL returns ep's ingress URL with a scheme, or "" while it has no
+// URL (not public, or still provisioning).
+func endpointURL(ep models.Endpoint) string {
+	url := strings.TrimSpace(ep.IngressURL)
+	if !ep.Public || url == "" || strings.ContainsAny(url, " \t") {
+		return ""
+	}
+	if !strings.Contains(url, "://") {
+		url = "https://" + url
+	}
+	return url
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/marcelinojackson-org/snow9s/pkg/models"
+)
+
+func TestEndpointURL(t *testing.T) {
+	cases := []struct {
+		ep   models.Endpoint
+		want string
+	}{
+		{models.Endpoint{Public: true, IngressURL: "abc-org-acct.snowflakecomputing.app"}, "https://abc-org-acct.snowflakecomputing.app"},
+		{models.Endpoint{Public: true, IngressURL: "https://already.example"}, "https://already.example"},
+		{models.Endpoint{Public: true, IngressURL: "Endpoints provisioning in progress... check back in a few minutes"}, ""},
+		{models.Endpoint{Public: false, IngressURL: "internal.example"}, ""},
+	}
+	for _, c := range cases {
+		if got := endpointURL(c.ep); got != c.want {
+			t.Fatalf("endpointURL(%+v) = %q, want %q", c.ep, got, c.want)
+		}
+	}
+}
+
+func TestYankRowAndName(t *testing.T) {
+	app := newTestApp(t)
+	var copied string
+	app.clipboard = func(text string) error {
+		copied = text
+		return nil
+	}
+	app.table.SetData([]string{"NAMESPACE", "NAME", "STATUS"}, []TableRow{{Cells: []string{"PUBLIC", "api", "RUNNING"}, Model: models.Service{Name: "api"}}})
+	app.table.Select(1, 0)
+
+	app.handleKey(tcell.NewEventKey(tcell.KeyRune, 'y', tcell.ModNone))
+	if copied != "PUBLIC\tapi\tRUNNING" {
+		t.Fatalf("row copy = %q", copied)
+	}
+	app.handleKey(tcell.NewEventKey(tcell.KeyRune, 'Y', tcell.ModNone))
+	if copied != "api" {
+		t.Fatalf("name copy = %q", copied)
+	}
+	app.handleKey(tcell.NewEventKey(tcell.KeyRune, 'J', tcell.ModNone))
+	if !strings.HasPrefix(copied, "[") {
+		t.Fatalf("J should copy visible rows as JSON, got %q", copied)
+	}
+
+	app.clipboard = func(string) error { return errNoClipboard }
+	app.yankName()
+	if !strings.Contains(app.footer.status, "clipboard unavailable") {
+		t.Fatalf("expected footer note, got %q", app.footer.status)
+	}
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// confirmPage names the pages entry holding the confirmation dialog.
+const confirmPage = "confirm"
+
+// ConfirmModal overlays a Yes/No dialog on app's root and calls onConfirm
+// only when it is accepted. Besides the buttons, y accepts and n or Esc
+// cancels. Focus returns to whatever had it before the dialog opened.
+func ConfirmModal(app *App, message string, onConfirm func()) {
+	previous := app.app.GetFocus()
+	done := func(accepted bool) {
+		app.pages.RemovePage(confirmPage)
+		app.modalVisible = false
+		if previous == nil {
+			previous = app.table
+		}
+		app.app.SetFocus(previous)
+		if accepted && onConfirm != nil {
+			onConfirm()
+		}
+	}
+
+	modal := tview.NewModal().
+		SetText(message).
+		AddButtons([]string{"Yes", "No"}).
+		SetDoneFunc(func(index int, label string) {
+			done(label == "Yes")
+		})
+	modal.SetBackgroundColor(app.styles.RowAltBg)
+	modal.SetTextColor(app.styles.PrimaryText)
+	modal.SetButtonBackgroundColor(app.styles.Border)
+	modal.SetButtonTextColor(app.styles.PrimaryText)
+	modal.SetButtonActivatedStyle(tcell.StyleDefault.
+		Background(app.styles.SelectionBg).
+		Foreground(app.styles.SelectionText))
+	modal.SetBorderColor(app.styles.HeaderBg)
+	modal.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEsc:
+			done(false)
+		case event.Key() == tcell.KeyRune && (event.Rune() == 'y' || event.Rune() == 'Y'):
+			done(true)
+		case event.Key() == tcell.KeyRune && (event.Rune() == 'n' || event.Rune() == 'N'):
+			done(false)
+		default:
+			return event
+		}
+		return nil
+	})
+
+	app.modalVisible = true
+	app.pages.AddPage(confirmPage, modal, false, true)
+	app.app.SetFocus(modal)
+}
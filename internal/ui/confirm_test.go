@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/marcelinojackson-org/snow9s/internal/snowflake"
+	"github.com/rivo/tview"
+)
+
+func TestConfirmModalKeys(t *testing.T) {
+	cases := []struct {
+		name string
+		key  *tcell.EventKey
+		want bool
+	}{
+		{"y accepts", tcell.NewEventKey(tcell.KeyRune, 'y', tcell.ModNone), true},
+		{"n cancels", tcell.NewEventKey(tcell.KeyRune, 'n', tcell.ModNone), false},
+		{"esc cancels", tcell.NewEventKey(tcell.KeyEsc, 0, tcell.ModNone), false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			app := newTestApp(t)
+			app.pages = tview.NewPages().AddPage("main", app.table, true, true)
+			app.app.SetFocus(app.table)
+			confirmed := false
+			ConfirmModal(app, "Suspend service api?", func() { confirmed = true })
+
+			modal, ok := app.pages.GetPage(confirmPage).(*tview.Modal)
+			if !ok || !app.modalVisible || !modal.HasFocus() {
+				t.Fatalf("expected focused confirmation modal")
+			}
+			modal.InputHandler()(c.key, func(p tview.Primitive) {})
+
+			if confirmed != c.want {
+				t.Fatalf("confirmed = %v, want %v", confirmed, c.want)
+			}
+			if app.modalVisible || app.pages.HasPage(confirmPage) {
+				t.Fatalf("modal not dismissed")
+			}
+			if app.app.GetFocus() != app.table {
+				t.Fatalf("focus not restored to the table")
+			}
+		})
+	}
+}
+
+func TestTypedNameConfirmation(t *testing.T) {
+	app := newTestApp(t)
+	app.stopped.Store(true) // drop the mismatch error instead of queueing it
+	ran := 0
+	confirmName := func(typed string) {
+		app.typedConfirm = &typedConfirmation{target: "api", onConfirm: func() { ran++ }}
+		app.activateInput(inputConfirmName, "type api to drop: ")
+		app.filterField.SetText(typed)
+		app.completeInput(tcell.KeyEnter)
+	}
+
+	confirmName("ap")
+	if ran != 0 {
+		t.Fatalf("mismatched name must not confirm")
+	}
+	confirmName("api")
+	if ran != 1 {
+		t.Fatalf("typed name did not confirm")
+	}
+	if app.inputMode != inputNone || app.typedConfirm != nil {
+		t.Fatalf("input not closed after confirmation")
+	}
+}
+
+func TestSkipConfirmations(t *testing.T) {
+	app := newTestApp(t)
+	app.stopped.Store(true) // drop the post-mutation refresh
+	app.pages = tview.NewPages().AddPage("main", app.table, true, true)
+	app.cfg.SkipConfirmations = true
+	app.view = viewServices
+	app.table.SetData([]string{"NAMESPACE", "NAME"}, []TableRow{{Cells: []string{"PUBLIC", "api"}}, {Cells: []string{"PUBLIC", "worker"}}})
+	targets := make(chan string, 2)
+	suspend := mutation{name: "suspend", view: viewServices, run: func(ctx context.Context, _ *snowflake.SPCS, target string) error {
+		targets <- target
+		return nil
+	}}
+	waitFor := func(want string) {
+		t.Helper()
+		select {
+		case got := <-targets:
+			if got != want {
+				t.Fatalf("expected %s to run on %s, got %s", suspend.name, want, got)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("%s did not run on %s", suspend.name, want)
+		}
+		if app.modalVisible || app.pages.HasPage(confirmPage) {
+			t.Fatalf("skip_confirmations must not show the Yes/No prompt")
+		}
+	}
+
+	app.serviceAction(suspend)
+	waitFor("api")
+	if app.lastMutation == nil || app.lastMutation.name != "suspend" {
+		t.Fatalf("bypassed mutation not remembered for repeat")
+	}
+
+	app.table.Select(2, 0)
+	app.repeatLastMutation()
+	waitFor("worker")
+
+	app.serviceAction(mutation{name: "drop", view: viewServices, typedConfirm: true, run: suspend.run})
+	if !app.modalVisible {
+		t.Fatalf("typed confirmations must still be asked")
+	}
+}
+
+func TestRepeatAsksForConfirmation(t *testing.T) {
+	app := newTestApp(t)
+	app.pages = tview.NewPages().AddPage("main", app.table, true, true)
+	app.view = viewServices
+	app.table.SetData([]string{"NAMESPACE", "NAME"}, []TableRow{{Cells: []string{"PUBLIC", "api"}}})
+	app.lastMutation = &mutation{name: "resume", view: viewServices, run: func(ctx context.Context, _ *snowflake.SPCS, target string) error {
+		t.Fatalf("repeat ran before it was confirmed")
+		return nil
+	}}
+	app.repeatLastMutation()
+	if !app.modalVisible || !app.pages.HasPage(confirmPage) {
+		t.Fatalf("expected the repeat to be confirmed")
+	}
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/marcelinojackson-org/snow9s/internal/snowflake"
+)
+
+// connState is the health of the Snowflake connection as seen by the UI.
+type connState int
+
+const (
+	connConnected connState = iota
+	connReconnecting
+	connFailed
+)
+
+// connFailedAfter is how many consecutive connection failures turn the status
+// from reconnecting to failed. Retries continue on the backoff either way, so
+// the app recovers on its own once Snowflake answers again.
+const connFailedAfter = 3
+
+// connHealth is the single source of truth for connection health: fetch
+// results drive it, and the header, footer, and error bar read from it.
+type connHealth struct {
+	state    connState
+	failures int
+}
+
+// observe records a fetch outcome and reports whether the state changed.
+// Errors Snowflake returned for a statement prove the session is alive, so
+// only connection errors count as failures. retrying says whether another
+// attempt is scheduled; without one the connection is failed immediately.
+func (h *connHealth) observe(err error, retrying bool) bool {
+	prev := h.state
+	switch {
+	case err == nil || !snowflake.IsConnectionError(err):
+		h.state, h.failures = connConnected, 0
+	default:
+		h.failures++
+		if !retrying || h.failures >= connFailedAfter {
+			h.state = connFailed
+		} else {
+			h.state = connReconnecting
+		}
+	}
+	return h.state != prev
+}
+
+// label renders the footer status cell with tview color tags in the
+// theme's status colors.
+func (h *connHealth) label(styles StyleConfig) string {
+	switch h.state {
+	case connReconnecting:
+		return fmt.Sprintf("[#%06x]◌ reconnecting[-]", styles.StatusStarting.Hex())
+	case connFailed:
+		return fmt.Sprintf("[#%06x::b]✗ disconnected[-::-]", styles.StatusStopped.Hex())
+	default:
+		return fmt.Sprintf("[#%06x]● connected[-]", styles.StatusRunning.Hex())
+	}
+}
+
+// errorMessage phrases a fetch failure of what according to the health state.
+func (h *connHealth) errorMessage(what string, err error) string {
+	switch h.state {
+	case connReconnecting:
+		return fmt.Sprintf("Reconnecting to Snowflake while fetching %s: %s (Ctrl+r to retry now, E to copy)", what, withHint(err))
+	case connFailed:
+		return fmt.Sprintf("Connection to Snowflake failed fetching %s: %s (Ctrl+r to retry, E to copy)", what, withHint(err))
+	default:
+		return fmt.Sprintf("Error fetching %s: %s (Ctrl+r to retry, E to copy)", what, withHint(err))
+	}
+}
+
+// setConnection pushes the current health to the widgets that show it. It
+// must run on the UI goroutine.
+func (a *App) setConnection() {
+	a.footer.SetConnection(a.conn.label(a.styles))
+	a.header.SetOffline(a.conn.state == connFailed)
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/snowflakedb/gosnowflake"
+)
+
+func TestConnHealthTransitions(t *testing.T) {
+	netErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("i/o timeout")}
+	var h connHealth
+	if h.state != connConnected {
+		t.Fatalf("expected connected initially got %v", h.state)
+	}
+	for i := 1; i < connFailedAfter; i++ {
+		if changed := h.observe(netErr, true); changed != (i == 1) || h.state != connReconnecting {
+			t.Fatalf("failure %d: expected reconnecting got %v (changed %v)", i, h.state, changed)
+		}
+	}
+	if !h.observe(netErr, true) || h.state != connFailed {
+		t.Fatalf("expected failed after %d failures got %v", connFailedAfter, h.state)
+	}
+	if !h.observe(nil, false) || h.state != connConnected || h.failures != 0 {
+		t.Fatalf("expected recovery to connected got %v after %d failures", h.state, h.failures)
+	}
+}
+
+func TestConnHealthWithoutRetryFailsImmediately(t *testing.T) {
+	var h connHealth
+	h.observe(&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, false)
+	if h.state != connFailed {
+		t.Fatalf("expected failed without a scheduled retry got %v", h.state)
+	}
+}
+
+func TestConnHealthIgnoresStatementErrors(t *testing.T) {
+	var h connHealth
+	h.observe(&net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset")}, true)
+	stmtErr := &gosnowflake.SnowflakeError{Number: 2003, SQLState: "02000", Message: "does not exist or not authorized"}
+	h.observe(stmtErr, true)
+	if h.state != connConnected {
+		t.Fatalf("statement errors should mark the session alive got %v", h.state)
+	}
+	h.observe(&gosnowflake.SnowflakeError{Number: 390114, Message: "Authentication token has expired"}, true)
+	if h.state != connReconnecting {
+		t.Fatalf("expired token should count as a connection failure got %v", h.state)
+	}
+}
+
+func TestApplyViewDataUpdatesConnectionStatus(t *testing.T) {
+	a := newTestApp(t)
+	gen, _ := a.fetchGeneration()
+	a.applyViewData(viewData{}, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("no route to host")}, gen)
+	if a.conn.state != connFailed || !a.header.offline || !a.reconnect.Load() {
+		t.Fatalf("expected failed connection, offline header, and a pending reconnect got %v offline=%v", a.conn.state, a.header.offline)
+	}
+	a.applyViewData(viewData{}, nil, gen)
+	if a.conn.state != connConnected || a.header.offline || a.reconnect.Load() {
+		t.Fatalf("expected recovery got %v offline=%v", a.conn.state, a.header.offline)
+	}
+}
+
+func TestConnHealthIgnoresNonConnectionErrors(t *testing.T) {
+	var h connHealth
+	for _, err := range []error{
+		context.DeadlineExceeded,
+		errors.New(`sql: Scan error on column index 2, name "created_on"`),
+	} {
+		h.observe(err, true)
+		if h.state != connConnected {
+			t.Fatalf("%v should not count as a connection failure", err)
+		}
+	}
+}
+
+func TestConnHealthLabelUsesTheme(t *testing.T) {
+	styles := solarizedStyles()
+	h := connHealth{state: connFailed}
+	if got := h.label(styles); !strings.Contains(got, "#dc322f") {
+		t.Fatalf("expected the theme's stopped color, got %q", got)
+	}
+	h.state = connConnected
+	if got := h.label(styles); !strings.Contains(got, "#859900") {
+		t.Fatalf("expected the theme's running color, got %q", got)
+	}
+}
+
+func TestErrorMessageIncludesHint(t *testing.T) {
+	var h connHealth
+	err := &gosnowflake.SnowflakeError{Number: 606, Message: "No active warehouse selected in the current session"}
+	msg := h.errorMessage("services", err)
+	if !strings.Contains(msg, "No active warehouse") || !strings.Contains(msg, "check --warehouse") {
+		t.Fatalf("expected raw error and hint, got %q", msg)
+	}
+	if msg := h.errorMessage("services", errors.New("boom")); strings.Contains(msg, "—") {
+		t.Fatalf("unknown errors should not get a hint: %q", msg)
+	}
+}
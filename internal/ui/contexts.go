@@ -0,0 +1,190 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/marcelinojackson-org/snow9s/internal/config"
+	"github.com/marcelinojackson-org/snow9s/internal/snowflake"
+	"github.com/rivo/tview"
+)
+
+// contextPage names the pages entry holding the context picker.
+const contextPage = "contexts"
+
+// ContextSwitch lets the TUI reconnect under another config context.
+type ContextSwitch struct {
+	// Load resolves a context's configuration without connecting.
+	Load func(name string) (config.Config, error)
+	// Connect opens a session for cfg and returns the function closing it.
+	Connect func(ctx context.Context, cfg config.Config) (*snowflake.SPCS, func() error, error)
+	// ConfirmProduction asks before connecting to a production context.
+	ConfirmProduction bool
+}
+
+// EnableContextSwitch turns on ctrl+x and :ctx. closeCurrent releases the
+// connection the app was created with; the app owns it from now on.
+func (a *App) EnableContextSwitch(sw ContextSwitch, closeCurrent func() error) {
+	a.contextSwitch = &sw
+	a.closeSession = closeCurrent
+}
+
+// Close releases the current connection.
+func (a *App) Close() error {
+	if a.closeSession == nil {
+		return nil
+	}
+	closeSession := a.closeSession
+	a.closeSession = nil
+	return closeSession()
+}
+
+// openContexts lists the configured contexts, marking the active one, and
+// switches to the one selected.
+func (a *App) openContexts() {
+	if a.contextSwitch == nil {
+		a.showError("Context switching is not available")
+		return
+	}
+	names, err := config.ListContexts(config.Options{NoConfigDir: a.cfg.NoConfigDir})
+	if err != nil {
+		a.showError(fmt.Sprintf("List contexts: %s", err))
+		return
+	}
+	if len(names) == 0 {
+		a.showError("No contexts defined in the config file")
+		return
+	}
+
+	closeList := func() {
+		a.pages.RemovePage(contextPage)
+		a.modalVisible = false
+		a.app.SetFocus(a.table)
+	}
+	list := tview.NewList().ShowSecondaryText(false)
+	for i, name := range names {
+		label := "  " + name
+		if name == a.cfg.Context {
+			label = "* " + name
+			list.SetCurrentItem(i)
+		}
+		selected := name
+		list.AddItem(tview.Escape(label), "", 0, func() {
+			closeList()
+			a.selectContext(selected)
+		})
+	}
+	list.SetDoneFunc(closeList)
+	list.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyRune && event.Rune() == 'q' {
+			closeList()
+			return nil
+		}
+		return event
+	})
+	list.SetBorder(true).SetTitle(" Contexts ")
+	list.SetBackgroundColor(a.styles.RowAltBg)
+	list.SetBorderColor(a.styles.HeaderBg)
+	list.SetTitleColor(a.styles.PrimaryText)
+	list.SetMainTextColor(a.styles.PrimaryText)
+	list.SetSelectedBackgroundColor(a.styles.SelectionBg)
+	list.SetSelectedTextColor(a.styles.SelectionText)
+
+	a.modalVisible = true
+	a.pages.AddPage(contextPage, centered(list, 40, min(len(names), 15)+2), true, true)
+	a.app.SetFocus(list)
+}
+
+// selectContext loads the named context and switches to it, asking first
+// when it points at production.
+func (a *App) selectContext(name string) {
+	if a.contextSwitch == nil {
+		a.showError("Context switching is not available")
+		return
+	}
+	if name == a.cfg.Context {
+		return
+	}
+	cfg, err := a.contextSwitch.Load(name)
+	if err != nil {
+		a.setError(fmt.Sprintf("Switch to context %s failed: %s", name, err))
+		return
+	}
+	if cfg.Context == a.cfg.Context {
+		return
+	}
+	if a.contextSwitch.ConfirmProduction && cfg.IsProduction() {
+		ConfirmModal(a, fmt.Sprintf("Context %s is PRODUCTION (account %s). Connect?", cfg.Context, cfg.Account), func() {
+			a.switchContext(cfg)
+		})
+		return
+	}
+	a.switchContext(cfg)
+}
+
+// switchContext connects with cfg in the background and, once connected,
+// replaces the current session. Until then the current session keeps serving
+// the views, so a failed connection leaves the app where it was.
+func (a *App) switchContext(cfg config.Config) {
+	if a.switching {
+		return
+	}
+	a.switching = true
+	a.footer.SetStatus(fmt.Sprintf("Connecting to %s…", cfg.Context))
+	connect := a.contextSwitch.Connect
+	go func() {
+		spcs, closeSession, err := connect(a.rootContext(), cfg)
+		a.safeUpdate(func() {
+			a.applySwitch(cfg, spcs, closeSession, err)
+		})
+	}()
+}
+
+// applySwitch installs the new session, or reports err and keeps the old one.
+// It must run on the UI goroutine.
+func (a *App) applySwitch(cfg config.Config, spcs *snowflake.SPCS, closeSession func() error, err error) {
+	a.switching = false
+	if err != nil {
+		msg := fmt.Sprintf("Switch to context %s failed: %s", cfg.Context, withHint(err))
+		if a.cfg.Context != "" {
+			msg += fmt.Sprintf("; still on %s", a.cfg.Context)
+		}
+		a.recordError(msg, err)
+		a.setError(msg)
+		a.updateFooterStatus()
+		return
+	}
+
+	a.rememberView()
+	previous := a.closeSession
+	a.resetRefresh()
+	a.spcs = spcs
+	a.cfg = cfg
+	a.closeSession = closeSession
+	if previous != nil {
+		go previous()
+	}
+
+	a.header.SetConfig(cfg)
+	a.header.SetSummary("")
+	a.header.SetWarnings(nil)
+	a.activeService = ""
+	a.activeSchema = ""
+	a.activeRepo = ""
+	a.services = nil
+	a.rows = nil
+	a.collapsed = map[string]bool{}
+	a.lastMutation = nil
+	a.pending = map[string]string{}
+	a.counts = newResourceCounts()
+	a.lastError = nil
+	a.conn = connHealth{}
+	a.reconnect.Store(false)
+	a.lastRefresh = time.Time{}
+	a.setConnection()
+	a.setError("")
+	a.restoreView()
+	a.fetchOverview(a.rootContext())
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/marcelinojackson-org/snow9s/internal/config"
+	"github.com/marcelinojackson-org/snow9s/internal/snowflake"
+)
+
+func TestApplySwitchReplacesSession(t *testing.T) {
+	app := newTestApp(t)
+	app.stopped.Store(true)
+	app.cfg.Context = "dev"
+	closed := make(chan struct{})
+	app.EnableContextSwitch(ContextSwitch{}, func() error {
+		close(closed)
+		return nil
+	})
+	app.view = viewInstances
+	app.activeService = "api"
+
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock: %v", err)
+	}
+	defer db.Close()
+	cfg := config.Config{Context: "staging", Database: "STG", Schema: "APP"}
+	spcs := snowflake.NewSPCS(db, cfg)
+	app.applySwitch(cfg, spcs, db.Close, nil)
+
+	if app.spcs != spcs || app.cfg.Context != "staging" {
+		t.Fatalf("session not replaced: %+v", app.cfg)
+	}
+	if app.view != viewServices || app.activeService != "" {
+		t.Fatalf("views not reset: view=%s service=%q", app.view, app.activeService)
+	}
+	if !strings.Contains(app.header.render(), "staging (STG.APP)") {
+		t.Fatalf("header not updated: %q", app.header.render())
+	}
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatalf("previous session not closed")
+	}
+}
+
+func TestApplySwitchFailureKeepsSession(t *testing.T) {
+	app := newTestApp(t)
+	app.stopped.Store(true)
+	app.cfg.Context = "dev"
+	closed := false
+	app.EnableContextSwitch(ContextSwitch{}, func() error {
+		closed = true
+		return nil
+	})
+	app.activeService = "api"
+
+	app.applySwitch(config.Config{Context: "prod"}, nil, nil, errors.New("390100: Incorrect username or password"))
+
+	if app.cfg.Context != "dev" || app.activeService != "api" || closed {
+		t.Fatalf("failed switch changed the session: %+v closed=%v", app.cfg, closed)
+	}
+	if msg := app.errorView.GetText(true); !strings.Contains(msg, "Switch to context prod failed") || !strings.Contains(msg, "still on dev") {
+		t.Fatalf("unexpected error: %q", msg)
+	}
+	if app.switching {
+		t.Fatalf("switching flag left set")
+	}
+}
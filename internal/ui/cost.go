@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/marcelinojackson-org/snow9s/internal/config"
+	"github.com/marcelinojackson-org/snow9s/pkg/models"
+)
+
+// resourceCounts tracks the last observed totals behind the cost warnings;
+// -1 means the resource has not been loaded yet.
+type resourceCounts struct {
+	runningServices int
+	activeNodes     int
+}
+
+func newResourceCounts() resourceCounts {
+	return resourceCounts{runningServices: -1, activeNodes: -1}
+}
+
+func countRunningServices(services []models.Service) int {
+	n := 0
+	for _, s := range services {
+		if models.NormalizeStatus(s.Status) == models.StatusRunning {
+			n++
+		}
+	}
+	return n
+}
+
+func countActiveNodes(pools []models.ComputePool) int {
+	n := 0
+	for _, p := range pools {
+		if nodes, err := strconv.Atoi(strings.TrimSpace(p.ActiveNodes)); err == nil {
+			n += nodes
+		}
+	}
+	return n
+}
+
+// utilizationCell renders a pool's utilization for the UTIL column, or "-"
+// when its maximum size is unknown.
+func utilizationCell(p models.ComputePool) string {
+	pct, ok := p.Utilization()
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%d%%", pct)
+}
+
+// costWarnings lists the counts that exceed their configured thresholds.
+func costWarnings(limits config.CostWarnings, counts resourceCounts) []string {
+	warnings := []string{}
+	if limits.RunningServices > 0 && counts.runningServices > limits.RunningServices {
+		warnings = append(warnings, fmt.Sprintf("⚠ %d running services", counts.runningServices))
+	}
+	if limits.ActiveNodes > 0 && counts.activeNodes > limits.ActiveNodes {
+		warnings = append(warnings, fmt.Sprintf("⚠ %d active pool nodes", counts.activeNodes))
+	}
+	return warnings
+}
@@ -0,0 +1,28 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/marcelinojackson-org/snow9s/internal/config"
+	"github.com/marcelinojackson-org/snow9s/pkg/models"
+)
+
+func TestCostWarnings(t *testing.T) {
+	services := []models.Service{{Status: "RUNNING"}, {Status: "running"}, {Status: "SUSPENDED"}}
+	pools := []models.ComputePool{{ActiveNodes: "3"}, {ActiveNodes: "2"}, {ActiveNodes: ""}}
+	counts := resourceCounts{runningServices: countRunningServices(services), activeNodes: countActiveNodes(pools)}
+	if counts.runningServices != 2 || counts.activeNodes != 5 {
+		t.Fatalf("unexpected counts %+v", counts)
+	}
+
+	got := costWarnings(config.CostWarnings{RunningServices: 1, ActiveNodes: 4}, counts)
+	if len(got) != 2 || got[0] != "⚠ 2 running services" || got[1] != "⚠ 5 active pool nodes" {
+		t.Fatalf("unexpected warnings %v", got)
+	}
+	if got := costWarnings(config.CostWarnings{RunningServices: 2, ActiveNodes: 0}, counts); len(got) != 0 {
+		t.Fatalf("expected no warnings at threshold or when disabled, got %v", got)
+	}
+	if got := costWarnings(config.CostWarnings{RunningServices: 1, ActiveNodes: 1}, newResourceCounts()); len(got) != 0 {
+		t.Fatalf("expected no warnings before data loads, got %v", got)
+	}
+}
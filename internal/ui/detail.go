@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/marcelinojackson-org/snow9s/internal/snowflake"
+	"github.com/rivo/tview"
+)
+
+// describePage names the pages entry holding the describe view.
+const describePage = "describe"
+
+// DetailView lists a resource's attributes as KEY/VALUE rows in the order
+// Snowflake returned them. A filter narrows the rows by key.
+type DetailView struct {
+	*tview.Table
+	styles  StyleConfig
+	attrs   []snowflake.Attribute
+	shown   []snowflake.Attribute
+	filter  string
+	message string
+}
+
+// NewDetailView builds an empty, scrollable describe table.
+func NewDetailView(styles StyleConfig) *DetailView {
+	table := tview.NewTable()
+	table.SetBorder(true)
+	table.SetFixed(1, 0)
+	table.SetSelectable(true, false)
+	table.SetBackgroundColor(styles.Background)
+	table.SetBorderColor(styles.Border)
+	table.SetSelectedStyle(tcell.StyleDefault.Foreground(styles.SelectionText).Background(styles.SelectionBg).Bold(true))
+	return &DetailView{Table: table, styles: styles}
+}
+
+// SetAttributes replaces the rows, keeping the current filter.
+func (d *DetailView) SetAttributes(attrs []snowflake.Attribute) {
+	d.attrs = attrs
+	d.message = ""
+	d.render()
+	d.Select(1, 0)
+	d.ScrollToBeginning()
+}
+
+// SetMessage replaces the rows with a single line, e.g. while loading.
+func (d *DetailView) SetMessage(msg string) {
+	d.attrs = nil
+	d.message = msg
+	d.render()
+}
+
+// SetFilter shows only the attributes whose key contains text.
+func (d *DetailView) SetFilter(text string) {
+	d.filter = strings.ToLower(strings.TrimSpace(text))
+	d.render()
+}
+
+// Filter returns the active key filter.
+func (d *DetailView) Filter() string {
+	return d.filter
+}
+
+// Shown returns the attributes passing the filter, in display order.
+func (d *DetailView) Shown() []snowflake.Attribute {
+	return d.shown
+}
+
+func (d *DetailView) render() {
+	d.Clear()
+	d.shown = nil
+	for _, attr := range d.attrs {
+		if d.filter == "" || strings.Contains(attr.Name, d.filter) {
+			d.shown = append(d.shown, attr)
+		}
+	}
+
+	for c, h := range []string{"KEY", "VALUE"} {
+		d.SetCell(0, c, tview.NewTableCell(" "+h+" ").
+			SetTextColor(d.styles.PrimaryText).
+			SetSelectable(false))
+	}
+	if d.message != "" {
+		d.SetCell(1, 0, tview.NewTableCell(" "+d.message).SetTextColor(d.styles.SecondaryText))
+		return
+	}
+	if len(d.shown) == 0 && d.filter != "" {
+		d.SetCell(1, 0, tview.NewTableCell(fmt.Sprintf(" No keys match %q", d.filter)).SetTextColor(d.styles.SecondaryText))
+		return
+	}
+	for r, attr := range d.shown {
+		bg := d.styles.Background
+		if r%2 == 1 {
+			bg = d.styles.RowAltBg
+		}
+		// Cells hold one line; multi-line values such as specs are joined.
+		value := strings.ReplaceAll(strings.TrimSpace(attr.Value), "\n", " ↵ ")
+		d.SetCell(r+1, 0, tview.NewTableCell(" "+strings.ToUpper(attr.Name)+" ").
+			SetTextColor(d.styles.SecondaryText).
+			SetBackgroundColor(bg))
+		d.SetCell(r+1, 1, tview.NewTableCell(" "+value).
+			SetTextColor(d.styles.PrimaryText).
+			SetBackgroundColor(bg).
+			SetExpansion(1))
+	}
+}
+
+// openDescribe shows every SHOW SERVICES column of the selected service.
+func (a *App) openDescribe() {
+	row, ok := a.table.SelectedRow()
+	if !ok || len(row.Cells) < 2 || row.Cells[1] == "" {
+		return
+	}
+	name, spcs := row.Cells[1], a.spcsIn(row.Cells[0])
+	a.describeView.SetTitle(fmt.Sprintf(" Describe: %s (/ filter keys, Esc to close) ", name))
+	a.describeView.SetFilter("")
+	a.describeView.SetMessage("Loading…")
+	a.describeVisible = true
+	a.describeGen++
+	gen := a.describeGen
+	a.pages.ShowPage(describePage)
+	a.app.SetFocus(a.describeView)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(a.rootContext(), a.cfg.Timeout())
+		defer cancel()
+		attrs, err := spcs.DescribeServiceAttributes(ctx, name)
+		a.safeUpdate(func() {
+			if !a.describeCurrent(gen) {
+				return
+			}
+			if err != nil {
+				a.describeView.SetMessage(fmt.Sprintf("Describe service failed: %s", withHint(err)))
+				return
+			}
+			a.describeView.SetAttributes(a.localizeTimestamps(attrs))
+		})
+	}()
+}
+
+// describeCurrent reports whether the describe view opened as gen is still
+// showing, so a describe that finishes after the view closed or moved to
+// another service is dropped.
+func (a *App) describeCurrent(gen uint64) bool {
+	return a.describeVisible && gen == a.describeGen
+}
+
+func (a *App) closeDescribe() {
+	a.describeVisible = false
+	a.pages.HidePage(describePage)
+	a.app.SetFocus(a.table)
+}
@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gdamore/tcell/v2"
+	"github.com/marcelinojackson-org/snow9s/internal/snowflake"
+)
+
+func TestDetailViewKeepsOrderAndFiltersKeys(t *testing.T) {
+	view := NewDetailView(DefaultStyles())
+	view.SetAttributes([]snowflake.Attribute{
+		{Name: "name", Value: "API"},
+		{Name: "status", Value: "RUNNING"},
+		{Name: "compute_pool", Value: "POOL1"},
+		{Name: "created_on", Value: "2024-01-01"},
+	})
+	if got := view.GetCell(1, 0).Text; got != " NAME " {
+		t.Fatalf("expected the first column first, got %q", got)
+	}
+	if got := view.GetCell(3, 1).Text; got != " POOL1" {
+		t.Fatalf("expected column order kept, got %q", got)
+	}
+
+	view.SetFilter("O")
+	shown := view.Shown()
+	if len(shown) != 2 || shown[0].Name != "compute_pool" || shown[1].Name != "created_on" {
+		t.Fatalf("unexpected filtered keys: %+v", shown)
+	}
+	view.SetFilter("nothing")
+	if len(view.Shown()) != 0 || view.GetCell(1, 0).Text == "" {
+		t.Fatalf("expected a no-match message, got %+v", view.Shown())
+	}
+}
+
+func TestEnterOnServiceOpensDescribe(t *testing.T) {
+	app, _ := newTestAppWithDB(t)
+	app.stopped.Store(true)
+	app.table.SetData([]string{"NAMESPACE", "NAME", "STATUS", "POOL", "AGE"}, []TableRow{{Cells: []string{"PUBLIC", "API", "RUNNING", "POOL1", "1d"}}})
+	app.table.SetFilter("api")
+	app.table.Select(1, 0)
+
+	app.handleKey(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+	if !app.describeVisible || app.detailVisible {
+		t.Fatalf("expected the describe view, describe=%v detail=%v", app.describeVisible, app.detailVisible)
+	}
+	gen := app.describeGen
+	if !app.describeCurrent(gen) {
+		t.Fatalf("the open describe view should accept its own load")
+	}
+
+	app.handleKey(tcell.NewEventKey(tcell.KeyRune, '/', tcell.ModNone))
+	app.filterField.SetText("pool")
+	if app.describeView.Filter() != "pool" || app.table.Filter() != "api" {
+		t.Fatalf("filter should apply to the describe keys only")
+	}
+	app.completeInput(tcell.KeyEsc)
+	if app.describeView.Filter() != "" || !app.describeVisible {
+		t.Fatalf("esc should clear the key filter and keep the view open")
+	}
+	if app.table.Filter() != "api" {
+		t.Fatalf("esc in the describe view must keep the table filter, got %q", app.table.Filter())
+	}
+
+	app.handleKey(tcell.NewEventKey(tcell.KeyEsc, 0, tcell.ModNone))
+	if app.describeVisible {
+		t.Fatalf("esc should close the describe view")
+	}
+	if app.describeCurrent(gen) {
+		t.Fatalf("a closed describe view must drop late results")
+	}
+	app.openDescribe()
+	if app.describeCurrent(gen) || !app.describeCurrent(app.describeGen) {
+		t.Fatalf("a reopened describe view must drop the earlier load")
+	}
+}
+
+func TestServiceDetailLoadsInBackground(t *testing.T) {
+	app, mock := newTestAppWithDB(t)
+	// Instances and container statuses load concurrently.
+	mock.MatchExpectationsInOrder(false)
+
+	spec := "spec:\n  containers:\n  - name: main\n    image: /db/public/repo/api:1.0\n"
+	mock.ExpectQuery(regexp.QuoteMeta(`SHOW SERVICES LIKE 'api'`)).WillReturnRows(
+		sqlmock.NewRows([]string{"name", "status", "spec"}).AddRow("api", "RUNNING", spec))
+	mock.ExpectQuery("SHOW SERVICE INSTANCES").WillReturnRows(sqlmock.NewRows([]string{"instance_id", "status"}))
+	mock.ExpectQuery(regexp.QuoteMeta("SYSTEM$GET_SERVICE_STATUS")).WillReturnRows(
+		sqlmock.NewRows([]string{"status"}).AddRow(`[{"status":"READY","containerName":"main","instanceId":"0"}]`))
+
+	detail := app.loadServiceDetail(context.Background(), "PUBLIC", "api")
+	// The spec came with the describe, so DESCRIBE SERVICE must not run.
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("expectations: %v", err)
+	}
+	row := TableRow{Cells: []string{"PUBLIC", "api", "RUNNING", "POOL1", "1d"}}
+	text := app.formatServiceDetail(row, detail)
+	for _, want := range []string{"Service: api", "status: RUNNING", "main  image=api  version=1.0", "instance 0  main  READY", "(none)"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("detail missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "spec: ") {
+		t.Fatalf("raw spec should not be listed with the attributes:\n%s", text)
+	}
+}
+
+func TestOpenDetailDoesNotBlock(t *testing.T) {
+	app, _ := newTestAppWithDB(t)
+	app.stopped.Store(true) // drop the failed background load
+	app.view = viewServices
+	app.table.SetData([]string{"NAMESPACE", "NAME", "STATUS", "POOL", "AGE"}, []TableRow{{Cells: []string{"PUBLIC", "api", "RUNNING", "POOL1", "1d"}}})
+
+	app.openDetail()
+	if !app.detailVisible || app.detailView.GetText(true) != "Loading…" {
+		t.Fatalf("expected the pane to open while loading, got %q", app.detailView.GetText(true))
+	}
+	gen := app.detailGen
+	if !app.detailCurrent(gen) {
+		t.Fatalf("the open pane should accept its own load")
+	}
+	app.closeDetail()
+	if app.detailCurrent(gen) {
+		t.Fatalf("a closed pane must drop late results")
+	}
+	app.showDetail(" Logs ", "Loading logs…")
+	if app.detailCurrent(gen) {
+		t.Fatalf("a reopened pane must drop the earlier load")
+	}
+	app.closeDetail()
+
+	// Compute pools are described in the background too.
+	app.view = viewPools
+	app.table.SetData([]string{"NAME", "STATE"}, []TableRow{{Cells: []string{"POOL1", "ACTIVE"}}})
+	app.openDetail()
+	if !app.detailVisible || app.detailView.GetText(true) != "Loading…" || app.detailGen == gen {
+		t.Fatalf("expected the pool pane to open while loading, got %q", app.detailView.GetText(true))
+	}
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/marcelinojackson-org/snow9s/internal/snowflake"
+)
+
+// errorDetails captures the context of the last Snowflake failure so it can be
+// copied into a ticket.
+type errorDetails struct {
+	message string
+	query   string
+	queryID string
+	at      time.Time
+}
+
+// withHint renders err followed by any guidance snowflake.Hint has for it.
+func withHint(err error) string {
+	if hint := snowflake.Hint(err); hint != "" {
+		return fmt.Sprintf("%v — %s", err, hint)
+	}
+	return err.Error()
+}
+
+// recordError remembers err with the message shown in the error bar. It must
+// run on the UI goroutine.
+func (a *App) recordError(msg string, err error) {
+	query, queryID := snowflake.ErrorDetails(err)
+	a.lastError = &errorDetails{message: msg, query: query, queryID: queryID, at: a.clock.Now()}
+}
+
+// text renders the details as plain text with credentials redacted.
+func (d errorDetails) text(a *App) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Error: %s\n", d.message)
+	if d.queryID != "" {
+		fmt.Fprintf(&b, "Query ID: %s\n", d.queryID)
+	}
+	if d.query != "" {
+		fmt.Fprintf(&b, "SQL: %s\n", d.query)
+	}
+	fmt.Fprintf(&b, "Context: %s.%s (account %s, user %s)\n", a.cfg.Database, a.cfg.Schema, a.cfg.Account, a.cfg.User)
+	fmt.Fprintf(&b, "Time: %s\n", d.at.UTC().Format(time.RFC3339))
+	return snowflake.Redact(a.cfg, b.String())
+}
+
+// copyErrorDetails copies the last error and its context to the clipboard.
+func (a *App) copyErrorDetails() {
+	if a.lastError == nil {
+		a.footer.SetStatus(fmt.Sprintf("%s  no error to copy", a.table.SelectionInfo()))
+		return
+	}
+	a.copyText(a.lastError.text(a), "error details")
+}
@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type filterKind int
+
+const (
+	filterLiteral filterKind = iota
+	filterColumn
+	filterRegex
+	filterAge
+)
+
+// tableFilter is a parsed filter expression. Only recognized prefixes change
+// the meaning of the input; everything else is matched literally.
+//
+//	name:foo   substring match against a single column
+//	~^api-     case-insensitive regular expression
+//	/^api-/    the same, delimited by slashes
+//	age>2d     rows older (or, with <, younger) than the given age
+//
+// note explains a fallback, such as an invalid regex matched literally.
+type tableFilter struct {
+	kind   filterKind
+	text   string
+	column int
+	re     *regexp.Regexp
+	older  bool
+	age    time.Duration
+	note   string
+}
+
+var ageFilterPattern = regexp.MustCompile(`^age\s*([<>])\s*(\d+(?:mo|[smhdwy]))$`)
+
+func parseFilter(raw string, headers []string) tableFilter {
+	text := strings.TrimSpace(raw)
+	literal := tableFilter{kind: filterLiteral, text: strings.ToLower(text), column: -1}
+	if text == "" {
+		return literal
+	}
+
+	if pattern, ok := regexPattern(text); ok {
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			literal.note = "invalid regex, matching literally"
+			return literal
+		}
+		return tableFilter{kind: filterRegex, re: re, column: -1}
+	}
+
+	if m := ageFilterPattern.FindStringSubmatch(strings.ToLower(text)); m != nil {
+		if age, ok := parseAge(m[2]); ok {
+			return tableFilter{kind: filterAge, column: indexOfHeader(headers, "AGE"), older: m[1] == ">", age: age}
+		}
+	}
+
+	if prefix, value, found := strings.Cut(text, ":"); found {
+		if col := indexOfHeader(headers, prefix); col >= 0 {
+			return tableFilter{kind: filterColumn, text: strings.ToLower(strings.TrimSpace(value)), column: col}
+		}
+	}
+	return literal
+}
+
+// regexPattern extracts the expression from ~expr or /expr/.
+func regexPattern(text string) (string, bool) {
+	if pattern, ok := strings.CutPrefix(text, "~"); ok && pattern != "" {
+		return pattern, true
+	}
+	if len(text) > 2 && strings.HasPrefix(text, "/") && strings.HasSuffix(text, "/") {
+		return text[1 : len(text)-1], true
+	}
+	return "", false
+}
+
+func (f tableFilter) matches(row TableRow) bool {
+	switch f.kind {
+	case filterColumn:
+		if f.column >= len(row.Cells) {
+			return false
+		}
+		return strings.Contains(strings.ToLower(row.Cells[f.column]), f.text)
+	case filterRegex:
+		return f.re.MatchString(rowText(row))
+	case filterAge:
+		if f.column < 0 || f.column >= len(row.Cells) {
+			return false
+		}
+		age, ok := parseAge(strings.TrimSpace(row.Cells[f.column]))
+		if !ok {
+			return false
+		}
+		if f.older {
+			return age > f.age
+		}
+		return age < f.age
+	default:
+		if f.text == "" {
+			return true
+		}
+		return strings.Contains(strings.ToLower(rowText(row)), f.text)
+	}
+}
+
+// rowText joins the cells and hidden values a literal or regex filter sees.
+func rowText(row TableRow) string {
+	return strings.Join(append(slices.Clip(row.Cells), row.Hidden...), " ")
+}
+
+// spans returns the byte ranges of value, the cell in column col, that the
+// filter matched, for highlighting. Regexes match within a single cell here,
+// so a match spanning two cells is not highlighted.
+func (f tableFilter) spans(col int, value string) [][]int {
+	switch f.kind {
+	case filterRegex:
+		var out [][]int
+		for _, loc := range f.re.FindAllStringIndex(value, -1) {
+			if loc[1] > loc[0] {
+				out = append(out, loc)
+			}
+		}
+		return out
+	case filterColumn, filterLiteral:
+		if f.text == "" || (f.kind == filterColumn && col != f.column) {
+			return nil
+		}
+		return literalSpans(value, f.text)
+	default:
+		return nil
+	}
+}
+
+// literalSpans finds the case-insensitive occurrences of needle, which is
+// already lowercase. Offsets index value itself, so runes whose lowercase
+// form has another length fall back to no highlight.
+func literalSpans(value, needle string) [][]int {
+	lower := strings.ToLower(value)
+	if len(lower) != len(value) {
+		return nil
+	}
+	var out [][]int
+	for offset := 0; ; {
+		i := strings.Index(lower[offset:], needle)
+		if i < 0 {
+			return out
+		}
+		start := offset + i
+		out = append(out, []int{start, start + len(needle)})
+		offset = start + len(needle)
+	}
+}
+
+// parseAge reverses models.FormatAge for the terse k9s units.
+func parseAge(value string) (time.Duration, bool) {
+	units := []struct {
+		suffix string
+		unit   time.Duration
+	}{
+		{"mo", 30 * 24 * time.Hour},
+		{"s", time.Second},
+		{"m", time.Minute},
+		{"h", time.Hour},
+		{"d", 24 * time.Hour},
+		{"w", 7 * 24 * time.Hour},
+		{"y", 365 * 24 * time.Hour},
+	}
+	for _, u := range units {
+		number, ok := strings.CutSuffix(value, u.suffix)
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return 0, false
+		}
+		return time.Duration(n) * u.unit, true
+	}
+	return 0, false
+}
+
+func indexOfHeader(headers []string, name string) int {
+	for i, h := range headers {
+		if strings.EqualFold(h, strings.TrimSpace(name)) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/marcelinojackson-org/snow9s/pkg/models"
+)
+
+func TestParseFilterLiteralFallback(t *testing.T) {
+	headers := []string{"NAMESPACE", "NAME", "STATUS", "POOL", "AGE"}
+	rows := []TableRow{
+		{Cells: []string{"PUBLIC", "foo/bar", "RUNNING", "pool1", "2d"}},
+		{Cells: []string{"PUBLIC", "a:b", "STOPPED", "pool1", "5m"}},
+		{Cells: []string{"PUBLIC", "old", "RUNNING", "pool2", "14mo"}},
+	}
+	cases := []struct {
+		filter string
+		want   []bool
+	}{
+		{"foo/bar", []bool{true, false, false}},
+		{"/bar", []bool{true, false, false}},
+		{"a:b", []bool{false, true, false}},
+		{"name:foo", []bool{true, false, false}},
+		{"status:stop", []bool{false, true, false}},
+		{"~^public\\s+a:", []bool{false, true, false}},
+		{"/^public\\s+a:/", []bool{false, true, false}},
+		{"/RUNNING|5m/", []bool{true, true, true}},
+		{"~[", []bool{false, false, false}},
+		{"/[/", []bool{false, false, false}},
+		{"age>1d", []bool{true, false, true}},
+		{"age<1h", []bool{false, true, false}},
+		{"age>1y", []bool{false, false, true}},
+		{"age<2mo", []bool{true, true, false}},
+	}
+	for _, c := range cases {
+		f := parseFilter(c.filter, headers)
+		for i, row := range rows {
+			if got := f.matches(row); got != c.want[i] {
+				t.Fatalf("filter %q row %d: expected %v got %v", c.filter, i, c.want[i], got)
+			}
+		}
+	}
+}
+
+func TestInvalidRegexFallsBackToLiteral(t *testing.T) {
+	f := parseFilter("/a[b/", nil)
+	if f.kind != filterLiteral || f.note == "" {
+		t.Fatalf("expected literal fallback with a note, got %+v", f)
+	}
+	if !f.matches(TableRow{Cells: []string{"/a[b/"}}) {
+		t.Fatal("literal fallback should match the raw text")
+	}
+	if f := parseFilter("/bar", nil); f.kind != filterLiteral || f.note != "" {
+		t.Fatalf("unterminated slash should stay a plain literal, got %+v", f)
+	}
+
+	table := NewDataTable(DefaultStyles())
+	table.SetData([]string{"NAME"}, []TableRow{{Cells: []string{"api"}}})
+	table.SetFilter("~(")
+	if table.FilterNote() == "" {
+		t.Fatal("table should expose the fallback note for the footer")
+	}
+	table.SetFilter("api")
+	if table.FilterNote() != "" {
+		t.Fatalf("note should clear, got %q", table.FilterNote())
+	}
+}
+
+func TestFilterMatchesHiddenOwner(t *testing.T) {
+	rows := serviceRows([]models.Service{{Namespace: "PUBLIC", Name: "api", Status: "running", Owner: "ALICE_ROLE"}}, false)
+	headers := serviceHeaders(false)
+	if !parseFilter("alice", headers).matches(rows[0]) || !parseFilter("~alice_role$", headers).matches(rows[0]) {
+		t.Fatalf("a literal filter should match the owner outside the wide layout")
+	}
+	if parseFilter("bob", headers).matches(rows[0]) {
+		t.Fatalf("unexpected match on another owner")
+	}
+}
@@ -10,6 +10,7 @@ import (
 type Footer struct {
 	view   *tview.TextView
 	styles StyleConfig
+	conn   string
 	hints  string
 	status string
 }
@@ -33,6 +34,12 @@ func (f *Footer) SetHints(hints []string) {
 	f.render()
 }
 
+// SetConnection sets the connection status cell shown before the hints.
+func (f *Footer) SetConnection(status string) {
+	f.conn = status
+	f.render()
+}
+
 // SetStatus appends additional info (e.g. counts, filter) to the footer.
 func (f *Footer) SetStatus(status string) {
 	f.status = strings.TrimSpace(status)
@@ -41,6 +48,9 @@ func (f *Footer) SetStatus(status string) {
 
 func (f *Footer) render() {
 	text := f.hints
+	if f.conn != "" {
+		text = f.conn + "  " + text
+	}
 	if f.status != "" {
 		text = strings.TrimSpace(text + "  " + f.status)
 	}
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/marcelinojackson-org/snow9s/pkg/models"
+)
+
+// statusGroupOrder lists the group headers that always lead the grouped view.
+var statusGroupOrder = []string{"RUNNING", "STARTING", "STOPPED", "SUSPENDED"}
+
+// serviceHeaders are the Services columns; wide adds the less used SHOW
+// SERVICES columns before AGE.
+func serviceHeaders(wide bool) []string {
+	if wide {
+		return []string{"NAMESPACE", "NAME", "STATUS", "POOL", "MIN", "MAX", "OWNER", "DNS_NAME", "SPEC_DIGEST", "AGE"}
+	}
+	return []string{"NAMESPACE", "NAME", "STATUS", "POOL", "AGE"}
+}
+
+// serviceRows renders services as flat table rows matching serviceHeaders.
+func serviceRows(services []models.Service, wide bool) []TableRow {
+	rows := make([]TableRow, 0, len(services))
+	for _, s := range services {
+		age := s.Age
+		if age == "" && !s.CreatedAt.IsZero() {
+			age = models.HumanizeAge(s.CreatedAt)
+		}
+		cells := []string{s.Namespace, s.Name, strings.ToUpper(s.Status), s.ComputePool, age}
+		if wide {
+			cells = []string{s.Namespace, s.Name, strings.ToUpper(s.Status), s.ComputePool, s.MinInstances, s.MaxInstances, s.Owner, s.DNSName, s.SpecDigest, age}
+		}
+		row := TableRow{Cells: cells, Model: s, Key: s.Namespace + "." + s.Name}
+		if !wide {
+			row.Hidden = []string{s.Owner}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// groupServiceRows collapses services under status headers with counts.
+// Members of groups marked in collapsed are omitted.
+func groupServiceRows(services []models.Service, collapsed map[string]bool, wide bool) []TableRow {
+	members := map[string][]models.Service{}
+	for _, s := range services {
+		key := strings.ToUpper(strings.TrimSpace(s.Status))
+		if key == "" {
+			key = "UNKNOWN"
+		}
+		members[key] = append(members[key], s)
+	}
+
+	keys := make([]string, 0, len(members))
+	seen := map[string]bool{}
+	for _, key := range statusGroupOrder {
+		if len(members[key]) > 0 {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+	extra := []string{}
+	for key := range members {
+		if !seen[key] {
+			extra = append(extra, key)
+		}
+	}
+	sort.Strings(extra)
+	keys = append(keys, extra...)
+
+	rows := make([]TableRow, 0, len(services)+len(keys))
+	for _, key := range keys {
+		marker := "▾"
+		if collapsed[key] {
+			marker = "▸"
+		}
+		cells := make([]string, len(serviceHeaders(wide)))
+		cells[0] = fmt.Sprintf("%s %s (%d)", marker, key, len(members[key]))
+		rows = append(rows, TableRow{Cells: cells, Group: key})
+		if collapsed[key] {
+			continue
+		}
+		rows = append(rows, serviceRows(members[key], wide)...)
+	}
+	return rows
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/marcelinojackson-org/snow9s/pkg/models"
+)
+
+func TestGroupServiceRows(t *testing.T) {
+	services := []models.Service{
+		{Name: "a", Status: "suspended"},
+		{Name: "b", Status: "running"},
+		{Name: "c", Status: "running"},
+		{Name: "d", Status: "failed"},
+	}
+	rows := groupServiceRows(services, map[string]bool{}, false)
+	if len(rows) != 7 { // 3 headers + 4 members
+		t.Fatalf("expected 7 rows got %d", len(rows))
+	}
+	if rows[0].Group != "RUNNING" || rows[0].Cells[0] != "▾ RUNNING (2)" {
+		t.Fatalf("unexpected first header: %+v", rows[0])
+	}
+	if rows[3].Group != "SUSPENDED" || rows[5].Group != "FAILED" {
+		t.Fatalf("unexpected group order: %+v", rows)
+	}
+
+	rows = groupServiceRows(services, map[string]bool{"RUNNING": true}, false)
+	if len(rows) != 5 {
+		t.Fatalf("expected collapsed group to hide members, got %d rows", len(rows))
+	}
+	if rows[0].Cells[0] != "▸ RUNNING (2)" {
+		t.Fatalf("unexpected collapsed header: %q", rows[0].Cells[0])
+	}
+}
+
+func TestGroupHeadersSurviveFilter(t *testing.T) {
+	table := NewDataTable(DefaultStyles())
+	services := []models.Service{
+		{Name: "alpha", Status: "running"},
+		{Name: "beta", Status: "suspended"},
+	}
+	table.SetData([]string{"NAMESPACE", "NAME", "STATUS", "POOL", "AGE"}, groupServiceRows(services, map[string]bool{}, false))
+	table.SetFilter("beta")
+	if got := table.GetRowCount(); got != 3 { // header + group + member
+		t.Fatalf("expected header, group and member rows got %d", got)
+	}
+	if !table.SelectGroup("SUSPENDED") {
+		t.Fatalf("expected suspended group to be selectable")
+	}
+	fg, _, _ := table.GetCell(1, 0).Style.Decompose()
+	if fg != DefaultStyles().StatusSuspended {
+		t.Fatalf("group header not status colored")
+	}
+}
+
+func TestToggleWide(t *testing.T) {
+	app := newTestApp(t)
+	app.stopped.Store(true)
+	app.services = []models.Service{{Namespace: "PUBLIC", Name: "api", Status: "running", Owner: "SYSADMIN", SpecDigest: "abc"}}
+	app.table.SetData(serviceHeaders(false), serviceRows(app.services, false))
+
+	app.toggleWide()
+	headers := app.table.Headers()
+	if len(headers) != 10 || headers[6] != "OWNER" || headers[9] != "AGE" {
+		t.Fatalf("unexpected wide headers: %v", headers)
+	}
+	if row, _ := app.table.SelectedRow(); row.Cells[6] != "SYSADMIN" || row.Cells[8] != "abc" {
+		t.Fatalf("unexpected wide row: %v", row.Cells)
+	}
+	app.grouped = true
+	app.table.SetData(app.table.Headers(), app.displayRows(nil))
+	if row, _ := app.table.SelectedRow(); len(row.Cells) != len(headers) {
+		t.Fatalf("group header has %d cells, want %d", len(row.Cells), len(headers))
+	}
+	app.toggleWide()
+	if got := len(app.table.Headers()); got != 5 {
+		t.Fatalf("expected the narrow table back, got %d columns", got)
+	}
+}
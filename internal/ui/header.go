@@ -2,34 +2,52 @@ package ui
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"github.com/marcelinojackson-org/snow9s/internal/config"
 )
 
 // Header renders the cyan banner with context details.
 type Header struct {
-	view    *tview.TextView
-	cfg     config.Config
-	styles  StyleConfig
-	version string
-	viewTag string
+	view     *tview.TextView
+	cfg      config.Config
+	styles   StyleConfig
+	version  string
+	viewTag  string
+	utc      bool
+	warnings []string
+	summary  string
+	offline  bool
 }
 
 // NewHeader builds the banner widget.
 func NewHeader(cfg config.Config, version string, styles StyleConfig) *Header {
 	view := tview.NewTextView().SetDynamicColors(true)
-	view.SetBackgroundColor(styles.HeaderBg)
-	view.SetTextColor(styles.HeaderText)
 	view.SetRegions(false)
 	view.SetWrap(false)
 
-	h := &Header{view: view, cfg: cfg, styles: styles, version: version}
-	h.Refresh()
+	h := &Header{view: view, styles: styles, version: version}
+	h.SetConfig(cfg)
 	return h
 }
 
+// SetConfig shows a new connection's context, coloring the banner red for
+// production.
+func (h *Header) SetConfig(cfg config.Config) {
+	h.cfg = cfg
+	if cfg.IsProduction() {
+		h.view.SetBackgroundColor(tcell.ColorRed)
+		h.view.SetTextColor(tcell.ColorWhite)
+	} else {
+		h.view.SetBackgroundColor(h.styles.HeaderBg)
+		h.view.SetTextColor(h.styles.HeaderText)
+	}
+	h.Refresh()
+}
+
 // View exposes the underlying TextView for layout composition.
 func (h *Header) View() *tview.TextView {
 	return h.view
@@ -40,19 +58,74 @@ func (h *Header) Refresh() {
 	h.view.SetText(h.render())
 }
 
+// SetUTC switches the clock between UTC and local time.
+func (h *Header) SetUTC(utc bool) {
+	h.utc = utc
+	h.Refresh()
+}
+
+// SetNamespace updates the database and schema shown in the banner.
+func (h *Header) SetNamespace(database, schema string) {
+	h.cfg.Database = database
+	h.cfg.Schema = schema
+	h.Refresh()
+}
+
+// SetWarnings replaces the cost-awareness warnings shown at the end of the banner.
+func (h *Header) SetWarnings(warnings []string) {
+	h.warnings = warnings
+	h.Refresh()
+}
+
+// SetSummary replaces the resource counts shown after the view label.
+func (h *Header) SetSummary(summary string) {
+	h.summary = summary
+	h.Refresh()
+}
+
 // SetView updates the current view label.
 func (h *Header) SetView(view string) {
 	h.viewTag = view
 	h.Refresh()
 }
 
+// SetOffline flags the banner while the connection to Snowflake is down.
+func (h *Header) SetOffline(offline bool) {
+	h.offline = offline
+	h.Refresh()
+}
+
 func (h *Header) render() string {
 	left := fmt.Sprintf(" snow9s v%s ", h.version)
-	ctx := fmt.Sprintf(" Context: %s.%s | User: %s ", h.cfg.Database, h.cfg.Schema, h.cfg.User)
+	if h.cfg.IsProduction() {
+		left += "[PROD] "
+	}
+	user := h.cfg.User
+	if h.cfg.Role != "" {
+		user = fmt.Sprintf("%s (%s)", user, h.cfg.Role)
+	}
+	ctx := fmt.Sprintf(" Context: %s.%s | User: %s ", h.cfg.Database, h.cfg.Schema, user)
+	if h.cfg.Context != "" {
+		ctx = fmt.Sprintf(" Context: %s (%s.%s) | User: %s ", h.cfg.Context, h.cfg.Database, h.cfg.Schema, user)
+	}
 	view := " Services "
 	if h.viewTag != "" {
 		view = fmt.Sprintf(" %s ", h.viewTag)
 	}
-	right := fmt.Sprintf(" %s ", time.Now().Format("15:04:05"))
-	return fmt.Sprintf("%s┃%s┃%s┃%s", left, ctx, view, right)
+	now := time.Now()
+	if h.utc {
+		now = now.UTC()
+	}
+	right := fmt.Sprintf(" %s ", now.Format("15:04:05 MST"))
+	text := fmt.Sprintf("%s┃%s┃%s┃%s", left, ctx, view, right)
+	if h.summary != "" {
+		text += fmt.Sprintf("┃ %s ", h.summary)
+	}
+	if h.offline {
+		text += fmt.Sprintf("┃ [#%06x::b]OFFLINE[-::-] ", h.styles.StatusStopped.Hex())
+	}
+	if len(h.warnings) > 0 {
+		text += fmt.Sprintf("┃ [yellow::b]%s[-::-] ", strings.Join(h.warnings, "  "))
+	}
+	return text
 }
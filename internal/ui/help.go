@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// helpPage names the pages entry holding the help overlay.
+const helpPage = "help"
+
+// keyBinding documents one key binding. Bindings with a hint also appear in
+// the footer, so the footer and the help overlay share this list.
+type keyBinding struct {
+	category    string
+	keys        string
+	hint        string
+	description string
+}
+
+// Help categories, in display order.
+const (
+	categoryNavigation = "Navigation"
+	categoryViews      = "Views"
+	categoryFilter     = "Filter"
+	categoryActions    = "Actions"
+	categoryDisplay    = "Display"
+)
+
+var helpCategories = []string{categoryNavigation, categoryViews, categoryFilter, categoryActions, categoryDisplay}
+
+var keyBindings = []keyBinding{
+	{categoryNavigation, "j/k/↓/↑", "Move", "move the selection"},
+	{categoryNavigation, "g/G", "Top/Bottom", "jump to the first or last row"},
+	{categoryNavigation, "ctrl+d/ctrl+u", "Page", "page down or up"},
+	{categoryNavigation, "enter", "Details", "describe a service, open details, a repo's images, or a database's schemas (expand/collapse a group)"},
+	{categoryNavigation, "wheel/click", "", "move or select a row; double click opens it like enter"},
+	{categoryNavigation, "b", "Back", "back to services, repos, or databases"},
+	{categoryNavigation, "esc", "", "close a pane or overlay, clear the filter being typed, or cancel a query you started"},
+	{categoryViews, "s/p/r/d", "Views", "services, pools, repos, databases"},
+	{categoryViews, "i", "Instances", "instances of the selected service"},
+	{categoryViews, "e", "Events", "status events of the selected service"},
+	{categoryViews, "o", "Endpoints", "endpoints of the selected service"},
+	{categoryViews, "l", "Logs", "logs of the selected service"},
+	{categoryViews, "v", "", "spec, containers, and instances of the selected service"},
+	{categoryViews, "m", "", "YAML spec of the selected service (:spec)"},
+	{categoryViews, "n", "", "switch schema (:ns <schema>)"},
+	{categoryViews, ":services all", "", "services in every schema of the database"},
+	{categoryViews, "ctrl+x", "", "switch config context (:ctx [name])"},
+	{categoryViews, ":sql", "", "run a read-only query (:sql SELECT …); ctrl+r reruns it"},
+	{categoryFilter, "/", "Filter", "filter rows (name:, ~regex, age>2d)"},
+	{categoryFilter, ":", "Cmd", "command mode (:svc, :pools, :help, …)"},
+	{categoryFilter, "f", "", "filter services by the selected pool"},
+	{categoryActions, "S/R", "Suspend/Resume", "suspend or resume the selected service"},
+	{categoryActions, "X", "Drop", "drop the selected service"},
+	{categoryActions, "N", "Scale pool", "set the selected pool's min/max nodes"},
+	{categoryActions, ".", "", "repeat the last action on the selection"},
+	{categoryActions, "y", "", "copy the selected row"},
+	{categoryActions, "Y", "", "copy the selected row's name"},
+	{categoryActions, "c", "", "copy the selected endpoint's URL"},
+	{categoryActions, "J", "", "copy visible rows as JSON"},
+	{categoryActions, "E", "", "copy the last error's details"},
+	{categoryActions, "ctrl+r", "Refresh", "refresh now"},
+	{categoryActions, "P", "Pause", "pause or resume automatic refresh"},
+	{categoryDisplay, "z", "", "group services by status"},
+	{categoryDisplay, "ctrl+w", "", "toggle wide service columns (owner, DNS name, instances, spec digest)"},
+	{categoryDisplay, "space", "", "fold or unfold a group"},
+	{categoryDisplay, "*", "", "pin or unpin a favorite service"},
+	{categoryDisplay, "#", "", "switch the footer's status counts between filtered and all services"},
+	{categoryDisplay, "u", "", "toggle UTC and local time"},
+	{categoryDisplay, "w", "", "toggle wrapping"},
+	{categoryDisplay, "?", "", "toggle this help"},
+	{categoryDisplay, "q", "Quit", "quit"},
+}
+
+// defaultKeyHints lists the footer hints.
+func defaultKeyHints() []string {
+	hints := []string{}
+	for _, b := range keyBindings {
+		if b.hint != "" {
+			hints = append(hints, b.keys+" "+b.hint)
+		}
+	}
+	return hints
+}
+
+// helpText renders every key binding grouped by category.
+func helpText() string {
+	width := 0
+	for _, b := range keyBindings {
+		width = max(width, len([]rune(b.keys)))
+	}
+	var sb strings.Builder
+	for i, category := range helpCategories {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(fmt.Sprintf("[::b]%s[::-]\n", category))
+		for _, b := range keyBindings {
+			if b.category == category {
+				pad := strings.Repeat(" ", width-len([]rune(b.keys)))
+				sb.WriteString(fmt.Sprintf("  %s%s  %s\n", tview.Escape(b.keys), pad, b.description))
+			}
+		}
+	}
+	return sb.String()
+}
+
+// toggleHelp shows or hides the help overlay.
+func (a *App) toggleHelp() {
+	if a.helpVisible {
+		a.pages.RemovePage(helpPage)
+		a.helpVisible = false
+		a.app.SetFocus(a.table)
+		return
+	}
+	text := helpText()
+	view := tview.NewTextView().SetDynamicColors(true).SetText(text)
+	view.SetBackgroundColor(a.styles.RowAltBg)
+	view.SetTextColor(a.styles.PrimaryText)
+	view.SetBorder(true)
+	view.SetBorderColor(a.styles.HeaderBg)
+	view.SetTitle(" Help (? or Esc to close) ")
+	view.SetBorderPadding(0, 0, 1, 1)
+
+	a.helpVisible = true
+	a.pages.AddPage(helpPage, centered(view, 72, strings.Count(text, "\n")+2), true, true)
+	a.app.SetFocus(view)
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+func TestHelpListsEveryBinding(t *testing.T) {
+	text := helpText()
+	for _, category := range helpCategories {
+		if !strings.Contains(text, category) {
+			t.Fatalf("help missing category %q", category)
+		}
+	}
+	for _, b := range keyBindings {
+		if !strings.Contains(text, b.description) {
+			t.Fatalf("help missing %q", b.description)
+		}
+	}
+	// Footer hints come from the same list.
+	for _, hint := range defaultKeyHints() {
+		keys, _, _ := strings.Cut(hint, " ")
+		if !strings.Contains(text, tview.Escape(keys)) {
+			t.Fatalf("footer hint %q not in help", hint)
+		}
+	}
+}
+
+func TestHelpOverlayToggles(t *testing.T) {
+	app := newTestApp(t)
+	app.pages = tview.NewPages().AddPage("main", app.table, true, true)
+
+	app.toggleHelp()
+	if !app.helpVisible || !app.pages.HasPage(helpPage) {
+		t.Fatalf("help overlay not shown")
+	}
+	if !app.handleKey(tcell.NewEventKey(tcell.KeyEsc, 0, tcell.ModNone)) {
+		t.Fatalf("esc not handled while help is open")
+	}
+	if app.helpVisible || app.pages.HasPage(helpPage) {
+		t.Fatalf("esc did not close help")
+	}
+
+	app.handleKey(tcell.NewEventKey(tcell.KeyRune, '?', tcell.ModNone))
+	app.handleKey(tcell.NewEventKey(tcell.KeyRune, '?', tcell.ModNone))
+	if app.helpVisible {
+		t.Fatalf("? did not close help")
+	}
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// logLines is how many trailing log lines the log pane fetches.
+const logLines = 500
+
+// openLogs shows the selected service's container logs in the detail pane.
+func (a *App) openLogs() {
+	if a.view != viewServices {
+		a.showError("Logs are available from the Services view")
+		return
+	}
+	row, ok := a.table.SelectedRow()
+	if !ok || len(row.Cells) < 2 || row.Cells[1] == "" {
+		a.showError("Select a service first to view logs")
+		return
+	}
+	name, spcs := row.Cells[1], a.spcsIn(row.Cells[0])
+	gen := a.showDetail(fmt.Sprintf(" Logs: %s (Esc to close, w to wrap) ", name), "Loading logs…")
+
+	go func() {
+		ctx, cancel := context.WithTimeout(a.rootContext(), 15*time.Second)
+		defer cancel()
+		logs, err := spcs.GetServiceLogs(ctx, name, "", logLines)
+		a.safeUpdate(func() {
+			if !a.detailCurrent(gen) {
+				return
+			}
+			a.detailView.SetText(formatLogs(name, logs, err))
+			a.detailView.ScrollToEnd()
+		})
+	}()
+}
+
+// formatLogs renders fetched logs for the pane, escaping tview color tags.
+func formatLogs(service, logs string, err error) string {
+	if err != nil {
+		return fmt.Sprintf("Fetching logs for %s failed: %s", service, withHint(err))
+	}
+	if strings.TrimSpace(logs) == "" {
+		return fmt.Sprintf("No logs for %s yet. Containers only log once they start; press e on the service to see why it is waiting.", service)
+	}
+	return tview.Escape(logs)
+}
@@ -0,0 +1,249 @@
+package ui
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/marcelinojackson-org/snow9s/pkg/models"
+	"go.yaml.in/yaml/v3"
+)
+
+// OutputFormats lists the formats accepted by the CLI list commands. wide is
+// the table with extra columns; resources without any print the plain table.
+var OutputFormats = []string{"table", "wide", "json", "yaml", "csv"}
+
+// ValidateOutputFormat rejects formats the list commands cannot render.
+func ValidateOutputFormat(format string) error {
+	for _, f := range OutputFormats {
+		if format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown output format %q (want %s)", format, strings.Join(OutputFormats, ", "))
+}
+
+// PrintServices writes services for the CLI list command as a box-drawn
+// table (wide adds owner, DNS name, instance counts, and spec digest), JSON,
+// YAML, or CSV. Machine-readable formats carry createdAt as RFC3339
+// alongside the humanized age.
+func PrintServices(w io.Writer, services []models.Service, format string, fullTimestamps bool) error {
+	if err := ValidateOutputFormat(format); err != nil {
+		return err
+	}
+	services = append([]models.Service(nil), services...)
+	for i := range services {
+		services[i].Age = createdColumn(services[i].CreatedAt, services[i].Age, false)
+	}
+	if format == "table" || format == "wide" {
+		PrintTable(w, services, fullTimestamps, format == "wide")
+		return nil
+	}
+	rows := make([][]string, 0, len(services))
+	for _, s := range services {
+		rows = append(rows, []string{s.Namespace, s.Name, strings.ToUpper(s.Status), s.ComputePool, formatCreated(s.CreatedAt), s.Age})
+	}
+	return writeStructured(w, format, services, []string{"NAMESPACE", "NAME", "STATUS", "POOL", "CREATED", "AGE"}, rows)
+}
+
+// PrintComputePools writes compute pools in the given output format.
+func PrintComputePools(w io.Writer, pools []models.ComputePool, format string, fullTimestamps bool) error {
+	if err := ValidateOutputFormat(format); err != nil {
+		return err
+	}
+	pools = append([]models.ComputePool(nil), pools...)
+	for i := range pools {
+		pools[i].Age = createdColumn(pools[i].CreatedAt, pools[i].Age, false)
+	}
+	if format == "table" || format == "wide" {
+		headers := []string{"NAME", "STATE", "MIN", "MAX", "FAMILY", "AUTO_RESUME", ageHeader(fullTimestamps)}
+		rows := make([][]string, 0, len(pools))
+		for _, p := range pools {
+			rows = append(rows, []string{p.Name, strings.ToUpper(p.State), p.MinNodes, p.MaxNodes, p.InstanceFamily, strconv.FormatBool(p.AutoResume), createdColumn(p.CreatedAt, p.Age, fullTimestamps)})
+		}
+		printBox(w, headers, rows)
+		return nil
+	}
+	rows := make([][]string, 0, len(pools))
+	for _, p := range pools {
+		rows = append(rows, []string{p.Name, strings.ToUpper(p.State), p.MinNodes, p.MaxNodes, p.InstanceFamily, p.ActiveNodes, strconv.FormatBool(p.AutoResume), formatCreated(p.CreatedAt), p.Age})
+	}
+	return writeStructured(w, format, pools, []string{"NAME", "STATE", "MIN_NODES", "MAX_NODES", "INSTANCE_FAMILY", "ACTIVE_NODES", "AUTO_RESUME", "CREATED", "AGE"}, rows)
+}
+
+// PrintImageRepositories writes image repositories in the given output format.
+func PrintImageRepositories(w io.Writer, repos []models.ImageRepository, format string, fullTimestamps bool) error {
+	if err := ValidateOutputFormat(format); err != nil {
+		return err
+	}
+	repos = append([]models.ImageRepository(nil), repos...)
+	for i := range repos {
+		repos[i].Age = createdColumn(repos[i].CreatedAt, repos[i].Age, false)
+	}
+	if format == "table" || format == "wide" {
+		headers := []string{"NAME", "REPO_URL", "OWNER", ageHeader(fullTimestamps)}
+		rows := make([][]string, 0, len(repos))
+		for _, r := range repos {
+			rows = append(rows, []string{r.Name, r.RepositoryURL, r.Owner, createdColumn(r.CreatedAt, r.Age, fullTimestamps)})
+		}
+		printBox(w, headers, rows)
+		return nil
+	}
+	rows := make([][]string, 0, len(repos))
+	for _, r := range repos {
+		rows = append(rows, []string{r.Name, r.RepositoryURL, r.Owner, formatCreated(r.CreatedAt), r.Age})
+	}
+	return writeStructured(w, format, repos, []string{"NAME", "REPO_URL", "OWNER", "CREATED", "AGE"}, rows)
+}
+
+// writeStructured emits items as JSON or YAML, or headers and rows as CSV.
+func writeStructured(w io.Writer, format string, items any, headers []string, rows [][]string) error {
+	switch format {
+	case "json":
+		return writeJSON(w, items)
+	case "yaml":
+		return writeYAML(w, items)
+	default:
+		return writeCSV(w, headers, rows)
+	}
+}
+
+// PrintTable renders a k9s-like table for the CLI list command.
+// With fullTimestamps the AGE column is replaced by the exact RFC3339
+// creation time; wide adds the columns of the TUI's wide view.
+func PrintTable(w io.Writer, services []models.Service, fullTimestamps, wide bool) {
+	headers := serviceHeaders(wide)
+	headers[len(headers)-1] = ageHeader(fullTimestamps)
+	rows := make([][]string, 0, len(services))
+	for _, row := range serviceRows(services, wide) {
+		s := row.Model.(models.Service)
+		cells := row.Cells
+		cells[len(cells)-1] = createdColumn(s.CreatedAt, s.Age, fullTimestamps)
+		rows = append(rows, cells)
+	}
+	printBox(w, headers, rows)
+}
+
+// maxCellWidth caps a CLI table column; longer values end in an ellipsis.
+const maxCellWidth = 40
+
+// printBox draws rows under headers with box-drawing borders, sizing each
+// column to its widest value up to maxCellWidth.
+func printBox(w io.Writer, headers []string, rows [][]string) {
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = utf8.RuneCountInString(h)
+	}
+	cells := make([][]string, len(rows))
+	for r, row := range rows {
+		cells[r] = make([]string, len(row))
+		for i, v := range row {
+			v = truncateCell(v, maxCellWidth)
+			cells[r][i] = v
+			if l := utf8.RuneCountInString(v); l > widths[i] {
+				widths[i] = l
+			}
+		}
+	}
+	rows = cells
+
+	drawLine := func(left, mid, right string) {
+		fmt.Fprint(w, left)
+		for i, width := range widths {
+			fmt.Fprint(w, strings.Repeat("─", width+2))
+			if i < len(widths)-1 {
+				fmt.Fprint(w, mid)
+			}
+		}
+		fmt.Fprintln(w, right)
+	}
+
+	drawLine("┌", "┬", "┐")
+	fmt.Fprint(w, "│")
+	for i, h := range headers {
+		fmt.Fprintf(w, " %-*s ", widths[i], h)
+		fmt.Fprint(w, "│")
+	}
+	fmt.Fprintln(w)
+	drawLine("├", "┼", "┤")
+	for _, row := range rows {
+		fmt.Fprint(w, "│")
+		for i, v := range row {
+			fmt.Fprintf(w, " %-*s ", widths[i], v)
+			fmt.Fprint(w, "│")
+		}
+		fmt.Fprintln(w)
+	}
+	drawLine("└", "┴", "┘")
+}
+
+// truncateCell shortens v to at most width runes, marking the cut with "…".
+func truncateCell(v string, width int) string {
+	if utf8.RuneCountInString(v) <= width {
+		return v
+	}
+	runes := []rune(v)
+	return string(runes[:width-1]) + "…"
+}
+
+// ageHeader names the creation column for the CLI tables.
+func ageHeader(full bool) string {
+	if full {
+		return "CREATED"
+	}
+	return "AGE"
+}
+
+// createdColumn formats a creation time for the CLI tables: the exact
+// RFC3339 time when full, otherwise age or one derived from created.
+func createdColumn(created time.Time, age string, full bool) string {
+	if full {
+		return formatCreated(created)
+	}
+	if age == "" && !created.IsZero() {
+		return models.HumanizeAge(created)
+	}
+	return age
+}
+
+// formatCreated renders a creation time as RFC3339, or "" when unknown.
+func formatCreated(ts time.Time) string {
+	if ts.IsZero() {
+		return ""
+	}
+	return ts.Format(time.RFC3339)
+}
+
+func writeJSON(w io.Writer, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode json: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+func writeYAML(w io.Writer, v any) error {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode yaml: %w", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func writeCSV(w io.Writer, headers []string, rows [][]string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(headers); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return fmt.Errorf("encode csv: %w", err)
+	}
+	return nil
+}
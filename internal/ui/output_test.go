@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/marcelinojackson-org/snow9s/pkg/models"
+)
+
+func TestPrintServicesFormats(t *testing.T) {
+	created := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	services := []models.Service{{Namespace: "DB.PUBLIC", Name: "api", Status: "running", ComputePool: "POOL", CreatedAt: created, Age: "2h"}}
+
+	var buf bytes.Buffer
+	if err := PrintServices(&buf, services, "json", false); err != nil {
+		t.Fatalf("json: %v", err)
+	}
+	var decoded []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("decode json: %v\n%s", err, buf.String())
+	}
+	if len(decoded) != 1 || decoded[0]["createdAt"] != "2024-03-01T12:30:00Z" || decoded[0]["computePool"] != "POOL" {
+		t.Fatalf("unexpected json: %v", decoded)
+	}
+
+	buf.Reset()
+	if err := PrintServices(&buf, services, "yaml", false); err != nil {
+		t.Fatalf("yaml: %v", err)
+	}
+	if !strings.Contains(buf.String(), "computePool: POOL") || strings.Contains(buf.String(), "│") {
+		t.Fatalf("unexpected yaml:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := PrintServices(&buf, services, "csv", false); err != nil {
+		t.Fatalf("csv: %v", err)
+	}
+	want := "NAMESPACE,NAME,STATUS,POOL,CREATED,AGE\nDB.PUBLIC,api,RUNNING,POOL,2024-03-01T12:30:00Z,2h\n"
+	if buf.String() != want {
+		t.Fatalf("csv = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := PrintServices(&buf, services, "table", false); err != nil {
+		t.Fatalf("table: %v", err)
+	}
+	if !strings.Contains(buf.String(), "┌") || !strings.Contains(buf.String(), "api") {
+		t.Fatalf("unexpected table:\n%s", buf.String())
+	}
+
+	if err := PrintServices(&buf, services, "xml", false); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
+
+func TestPrintPoolsAndRepos(t *testing.T) {
+	created := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	pools := []models.ComputePool{{Name: "POOL", State: "active", MinNodes: "1", MaxNodes: "2", InstanceFamily: "CPU_X64_XS", ActiveNodes: "1", AutoResume: true, CreatedAt: created, Age: "2h"}}
+	var buf bytes.Buffer
+	if err := PrintComputePools(&buf, pools, "csv", false); err != nil {
+		t.Fatalf("pools csv: %v", err)
+	}
+	want := "NAME,STATE,MIN_NODES,MAX_NODES,INSTANCE_FAMILY,ACTIVE_NODES,AUTO_RESUME,CREATED,AGE\nPOOL,ACTIVE,1,2,CPU_X64_XS,1,true,2024-03-01T12:30:00Z,2h\n"
+	if buf.String() != want {
+		t.Fatalf("pools csv = %q, want %q", buf.String(), want)
+	}
+
+	repos := []models.ImageRepository{{Name: "REPO", RepositoryURL: "org-acct.registry/db/public/repo", Owner: "SYSADMIN", CreatedAt: created, Age: "2h"}}
+	buf.Reset()
+	if err := PrintImageRepositories(&buf, repos, "table", true); err != nil {
+		t.Fatalf("repos table: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "REPO_URL") || !strings.Contains(out, "CREATED") || !strings.Contains(out, "2024-03-01T12:30:00Z") {
+		t.Fatalf("unexpected repos table:\n%s", out)
+	}
+}
+
+func TestPrintTableWide(t *testing.T) {
+	services := []models.Service{{Namespace: "PUBLIC", Name: "api", Status: "running", Age: "1d", Owner: "SYSADMIN", DNSName: "api.svc.spcs.internal", MinInstances: "1", MaxInstances: "2", SpecDigest: "abc123"}}
+	var narrow, wide bytes.Buffer
+	if err := PrintServices(&narrow, services, "table", false); err != nil {
+		t.Fatalf("table: %v", err)
+	}
+	if err := PrintServices(&wide, services, "wide", false); err != nil {
+		t.Fatalf("wide: %v", err)
+	}
+	if strings.Contains(narrow.String(), "DNS_NAME") {
+		t.Fatalf("narrow table has wide columns:\n%s", narrow.String())
+	}
+	for _, want := range []string{"OWNER", "DNS_NAME", "SPEC_DIGEST", "SYSADMIN", "api.svc.spcs.internal", "abc123", "1d"} {
+		if !strings.Contains(wide.String(), want) {
+			t.Fatalf("wide table missing %q:\n%s", want, wide.String())
+		}
+	}
+}
+
+func TestPrintTableSizesColumns(t *testing.T) {
+	long := strings.Repeat("x", 60)
+	services := []models.Service{
+		{Namespace: "DB.PUBLIC", Name: "a-service-name-longer-than-sixteen", Status: "running", Age: "1d"},
+		{Namespace: "DB.PUBLIC", Name: long, Status: "suspended", Age: "2d"},
+	}
+	var buf bytes.Buffer
+	PrintTable(&buf, services, false, false)
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	width := utf8.RuneCountInString(lines[0])
+	for _, line := range lines {
+		if got := utf8.RuneCountInString(line); got != width {
+			t.Fatalf("misaligned line (%d runes, want %d):\n%s", got, width, buf.String())
+		}
+	}
+	if !strings.Contains(buf.String(), "a-service-name-longer-than-sixteen") {
+		t.Fatalf("expected long name in full:\n%s", buf.String())
+	}
+	if strings.Contains(buf.String(), long) || !strings.Contains(buf.String(), strings.Repeat("x", maxCellWidth-1)+"…") {
+		t.Fatalf("expected %d-rune cap with ellipsis:\n%s", maxCellWidth, buf.String())
+	}
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/marcelinojackson-org/snow9s/internal/snowflake"
+)
+
+// overviewInterval is how often the header's resource counts are reloaded.
+// They cover every resource type, so they refresh less often than the view.
+const overviewInterval = 30 * time.Second
+
+// fetchOverview loads all resource types concurrently and updates the header
+// counts. Types that fail to load show "?" while the others still render.
+func (a *App) fetchOverview(ctx context.Context) {
+	if a.spcs == nil {
+		return
+	}
+	go func() {
+		timeoutCtx, cancel := context.WithTimeout(ctx, a.cfg.Timeout())
+		defer cancel()
+		overview, _ := a.spcs.LoadOverview(timeoutCtx)
+		a.safeUpdate(func() {
+			a.applyOverview(overview)
+		})
+	}()
+}
+
+// applyOverview shows the counts from overview and feeds the loaded lists
+// into the cost-awareness totals.
+func (a *App) applyOverview(overview snowflake.Overview) {
+	a.header.SetSummary(overviewSummary(overview))
+	if overview.ServicesErr == nil {
+		a.counts.runningServices = countRunningServices(overview.Services)
+	}
+	if overview.PoolsErr == nil {
+		a.counts.activeNodes = countActiveNodes(overview.Pools)
+	}
+	a.header.SetWarnings(costWarnings(a.cfg.CostWarnings, a.counts))
+}
+
+// overviewSummary formats the per-type counts, e.g. "Services 4 · Pools 2 · Repos ?".
+func overviewSummary(overview snowflake.Overview) string {
+	count := func(n int, err error) string {
+		if err != nil {
+			return "?"
+		}
+		return fmt.Sprint(n)
+	}
+	return strings.Join([]string{
+		"Services " + count(len(overview.Services), overview.ServicesErr),
+		"Pools " + count(len(overview.Pools), overview.PoolsErr),
+		"Repos " + count(len(overview.Repositories), overview.RepositoriesErr),
+	}, " · ")
+}
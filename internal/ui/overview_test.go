@@ -0,0 +1,27 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/marcelinojackson-org/snow9s/internal/snowflake"
+	"github.com/marcelinojackson-org/snow9s/pkg/models"
+)
+
+func TestApplyOverviewShowsPartialCounts(t *testing.T) {
+	app := newTestApp(t)
+	app.applyOverview(snowflake.Overview{
+		Services:     []models.Service{{Name: "a", Status: "running"}, {Name: "b", Status: "suspended"}},
+		PoolsErr:     errors.New("denied"),
+		Repositories: []models.ImageRepository{{Name: "r"}},
+	})
+
+	header := app.header.render()
+	if !strings.Contains(header, "Services 2 · Pools ? · Repos 1") {
+		t.Fatalf("unexpected header summary: %q", header)
+	}
+	if app.counts.runningServices != 1 || app.counts.activeNodes != -1 {
+		t.Fatalf("unexpected counts: %+v", app.counts)
+	}
+}
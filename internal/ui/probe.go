@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/marcelinojackson-org/snow9s/pkg/models"
+)
+
+// Reachability labels of the endpoints view's REACHABLE column. Probed
+// results carry the HTTP status or failure after the label, e.g. "UP 302".
+const (
+	reachUp       = "UP"
+	reachDown     = "DOWN"
+	reachChecking = "CHECKING"
+)
+
+const (
+	// probeTimeout bounds each ingress request.
+	probeTimeout = 5 * time.Second
+	// probeInterval is how long a result is shown before the URL is probed
+	// again.
+	probeInterval = 30 * time.Second
+)
+
+// endpointProber checks public ingress URLs in the background and remembers
+// the outcome per URL, so the endpoints view shows reachability without
+// waiting on outbound requests. Requests go through the proxy named by
+// HTTPS_PROXY/HTTP_PROXY and NO_PROXY.
+type endpointProber struct {
+	client   *http.Client
+	interval time.Duration
+
+	mu      sync.Mutex
+	results map[string]probeResult
+	running map[string]bool
+}
+
+// probeResult is the last outcome of probing one URL.
+type probeResult struct {
+	label string
+	at    time.Time
+}
+
+func newEndpointProber(timeout time.Duration) *endpointProber {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	client := &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		// Ingress answers unauthenticated requests with a redirect to the
+		// Snowflake login, which already proves it is up.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return &endpointProber{
+		client:   client,
+		interval: probeInterval,
+		results:  map[string]probeResult{},
+		running:  map[string]bool{},
+	}
+}
+
+// ingressURL returns the URL to probe for ep, or "" when it has no public
+// ingress yet. SHOW ENDPOINTS reports a bare host name, or a message while
+// the ingress is still being provisioned.
+func ingressURL(ep models.Endpoint) string {
+	host := strings.TrimSpace(ep.IngressURL)
+	if !ep.Public || host == "" || strings.ContainsAny(host, " \t") {
+		return ""
+	}
+	if strings.Contains(host, "://") {
+		return host
+	}
+	return "https://" + host
+}
+
+// label returns the reachability cell for ep: the last result, CHECKING
+// until the first probe finishes, or empty when ep is not probed.
+func (p *endpointProber) label(ep models.Endpoint) string {
+	url := ingressURL(ep)
+	if url == "" {
+		return ""
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if result, ok := p.results[url]; ok {
+		return result.label
+	}
+	return reachChecking
+}
+
+// probe starts a background check of each endpoint whose result is missing
+// or older than the probe interval, and calls done once they have all
+// finished. It reports whether any check was started.
+func (p *endpointProber) probe(ctx context.Context, endpoints []models.Endpoint, done func()) bool {
+	var urls []string
+	p.mu.Lock()
+	for _, ep := range endpoints {
+		url := ingressURL(ep)
+		if url == "" || p.running[url] {
+			continue
+		}
+		if result, ok := p.results[url]; ok && time.Since(result.at) < p.interval {
+			continue
+		}
+		p.running[url] = true
+		urls = append(urls, url)
+	}
+	p.mu.Unlock()
+	if len(urls) == 0 {
+		return false
+	}
+
+	var wg sync.WaitGroup
+	for _, url := range urls {
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+			label := p.check(ctx, url)
+			p.mu.Lock()
+			p.results[url] = probeResult{label: label, at: time.Now()}
+			delete(p.running, url)
+			p.mu.Unlock()
+		}(url)
+	}
+	go func() {
+		wg.Wait()
+		done()
+	}()
+	return true
+}
+
+// check requests url with HEAD, retrying with GET when HEAD is not allowed.
+// Any response below 500 counts as up: authentication challenges and
+// redirects come from a live ingress.
+func (p *endpointProber) check(ctx context.Context, url string) string {
+	status, err := p.request(ctx, http.MethodHead, url)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = p.request(ctx, http.MethodGet, url)
+	}
+	switch {
+	case err != nil:
+		return fmt.Sprintf("%s %s", reachDown, probeError(err))
+	case status >= http.StatusInternalServerError:
+		return fmt.Sprintf("%s %d", reachDown, status)
+	default:
+		return fmt.Sprintf("%s %d", reachUp, status)
+	}
+}
+
+func (p *endpointProber) request(ctx context.Context, method, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// probeError shortens a request error for the table cell.
+func probeError(err error) string {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "Client.Timeout"), strings.Contains(msg, "deadline exceeded"):
+		return "timeout"
+	case strings.Contains(msg, "no such host"):
+		return "dns"
+	case strings.Contains(msg, "connection refused"):
+		return "refused"
+	case strings.Contains(msg, "certificate"), strings.Contains(msg, "tls:"):
+		return "tls"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/marcelinojackson-org/snow9s/pkg/models"
+)
+
+func TestEndpointProber(t *testing.T) {
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "https://login.example.com", http.StatusFound)
+	}))
+	defer up.Close()
+	// HEAD is refused, so the prober must fall back to GET.
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	endpoints := []models.Endpoint{
+		{Name: "api", Public: true, IngressURL: up.URL},
+		{Name: "ui", Public: true, IngressURL: down.URL},
+		{Name: "internal", Public: false},
+		{Name: "pending", Public: true, IngressURL: "Endpoints provisioning in progress..."},
+	}
+	prober := newEndpointProber(time.Second)
+	if got := prober.label(endpoints[0]); got != reachChecking {
+		t.Fatalf("expected %s before the first probe, got %q", reachChecking, got)
+	}
+	done := make(chan struct{})
+	if !prober.probe(context.Background(), endpoints, func() { close(done) }) {
+		t.Fatalf("expected probes to start")
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("probes did not finish")
+	}
+
+	want := []string{"UP 302", "DOWN 503", "", ""}
+	for i, ep := range endpoints {
+		if got := prober.label(ep); got != want[i] {
+			t.Fatalf("%s: expected %q got %q", ep.Name, want[i], got)
+		}
+	}
+	if prober.probe(context.Background(), endpoints, func() {}) {
+		t.Fatalf("fresh results must not be probed again")
+	}
+}
+
+func TestIngressURL(t *testing.T) {
+	ep := models.Endpoint{Public: true, IngressURL: "abc-org-acct.snowflakecomputing.app"}
+	if got := ingressURL(ep); got != "https://abc-org-acct.snowflakecomputing.app" {
+		t.Fatalf("expected an https URL, got %q", got)
+	}
+	ep.Public = false
+	if got := ingressURL(ep); got != "" {
+		t.Fatalf("private endpoints must not be probed, got %q", got)
+	}
+}
+
+func TestEndpointsViewReachableColumn(t *testing.T) {
+	app, mock := newTestAppWithDB(t)
+	app.SetProbeEndpoints(true)
+	app.view = viewEndpoints
+	app.activeService = "api"
+
+	mock.ExpectQuery("SHOW ENDPOINTS IN SERVICE").WillReturnRows(
+		sqlmock.NewRows([]string{"name", "port", "protocol", "is_public", "ingress_url"}).
+			AddRow("internal", "8080", "HTTP", "false", ""))
+	data, err := app.loadViewData(context.Background())
+	if err != nil {
+		t.Fatalf("loadViewData: %v", err)
+	}
+	if len(data.headers) != reachableColumn+1 || data.headers[reachableColumn] != "REACHABLE" || data.formats[reachableColumn] != FormatReachability {
+		t.Fatalf("expected a REACHABLE column, got %v", data.headers)
+	}
+	if got := data.rows[0].Cells[reachableColumn]; got != "" {
+		t.Fatalf("private endpoints are not probed, got %q", got)
+	}
+}
+
+func TestRefreshReachabilityFillsProbedRows(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+	app, mock := newTestAppWithDB(t)
+	app.stopped.Store(true) // refreshReachability is called directly below
+	app.SetProbeEndpoints(true)
+	app.view = viewEndpoints
+	app.activeService = "api"
+
+	mock.ExpectQuery("SHOW ENDPOINTS IN SERVICE").WillReturnRows(
+		sqlmock.NewRows([]string{"name", "port", "protocol", "is_public", "ingress_url"}).
+			AddRow("web", "8080", "HTTP", "true", srv.URL))
+	data, err := app.loadViewData(context.Background())
+	if err != nil {
+		t.Fatalf("loadViewData: %v", err)
+	}
+	app.rows = data.rows
+	app.table.SetData(data.headers, data.rows)
+
+	ep := data.rows[0].Model.(models.Endpoint)
+	deadline := time.Now().Add(5 * time.Second)
+	for app.prober.label(ep) == reachChecking && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	app.refreshReachability()
+	if got := app.rows[0].Cells[reachableColumn]; got != "UP 200" {
+		t.Fatalf("expected the probed row to show UP 200, got %q", got)
+	}
+}
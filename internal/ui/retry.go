@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"sync"
+	"time"
+)
+
+// refreshInterval is how often the background loop reloads the active view.
+const refreshInterval = 5 * time.Second
+
+// retryBaseDelay is the first automatic retry delay after a failed refresh.
+const retryBaseDelay = 2 * time.Second
+
+// backoff schedules automatic retries of failed refreshes, doubling the delay
+// after each consecutive failure up to max. A zero max disables retries.
+type backoff struct {
+	mu       sync.Mutex
+	base     time.Duration
+	max      time.Duration
+	failures int
+	next     time.Time
+}
+
+func newBackoff(base, max time.Duration) *backoff {
+	return &backoff{base: base, max: max}
+}
+
+// fail records a failure at now and returns the delay before the next retry.
+func (b *backoff) fail(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.max <= 0 {
+		return 0
+	}
+	delay := b.base
+	for i := 0; i < b.failures && delay < b.max; i++ {
+		delay *= 2
+	}
+	if delay > b.max {
+		delay = b.max
+	}
+	b.failures++
+	b.next = now.Add(delay)
+	return delay
+}
+
+// reset clears the failure streak after a success or a manual refresh.
+func (b *backoff) reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.next = time.Time{}
+}
+
+// pending reports whether a retry is scheduled.
+func (b *backoff) pending() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failures > 0 && b.max > 0
+}
+
+// remaining returns the time left before the scheduled retry, or zero when
+// none is pending or it is due.
+func (b *backoff) remaining(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures == 0 || b.max <= 0 || !now.Before(b.next) {
+		return 0
+	}
+	return b.next.Sub(now)
+}
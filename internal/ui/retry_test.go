@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffDoublesAndCaps(t *testing.T) {
+	b := newBackoff(2*time.Second, 10*time.Second)
+	now := time.Now()
+	want := []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second}
+	for i, w := range want {
+		if got := b.fail(now); got != w {
+			t.Fatalf("failure %d: expected %v got %v", i+1, w, got)
+		}
+	}
+	if got := b.remaining(now.Add(4 * time.Second)); got != 6*time.Second {
+		t.Fatalf("expected 6s remaining got %v", got)
+	}
+	if got := b.remaining(now.Add(11 * time.Second)); got != 0 {
+		t.Fatalf("expected retry to be due got %v", got)
+	}
+	b.reset()
+	if b.pending() || b.fail(now) != 2*time.Second {
+		t.Fatalf("reset did not restart the backoff")
+	}
+}
+
+func TestBackoffDisabled(t *testing.T) {
+	b := newBackoff(2*time.Second, 0)
+	if got := b.fail(time.Now()); got != 0 || b.pending() {
+		t.Fatalf("expected disabled backoff got delay %v pending %v", got, b.pending())
+	}
+}
@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/marcelinojackson-org/snow9s/internal/snowflake"
+	"github.com/marcelinojackson-org/snow9s/pkg/models"
+	"github.com/rivo/tview"
+)
+
+// scalePage names the pages entry holding the compute pool scale form.
+const scalePage = "scale"
+
+// scalePool opens a form to edit the selected compute pool's min and max
+// nodes and applies the change as a mutation.
+func (a *App) scalePool() {
+	if a.view != viewPools {
+		return
+	}
+	row, ok := a.table.SelectedRow()
+	if !ok {
+		a.showError("Select a compute pool to scale")
+		return
+	}
+	pool, ok := row.Model.(models.ComputePool)
+	if !ok {
+		return
+	}
+
+	previous := a.app.GetFocus()
+	closeForm := func() {
+		a.pages.RemovePage(scalePage)
+		a.modalVisible = false
+		if previous == nil {
+			previous = a.table
+		}
+		a.app.SetFocus(previous)
+	}
+
+	form := tview.NewForm()
+	form.AddInputField("Min nodes", pool.MinNodes, 6, tview.InputFieldInteger, nil)
+	form.AddInputField("Max nodes", pool.MaxNodes, 6, tview.InputFieldInteger, nil)
+	form.AddButton("Save", func() {
+		minNodes, maxNodes, err := parseNodeCounts(
+			form.GetFormItemByLabel("Min nodes").(*tview.InputField).GetText(),
+			form.GetFormItemByLabel("Max nodes").(*tview.InputField).GetText(),
+		)
+		if err != nil {
+			a.setError(err.Error())
+			return
+		}
+		closeForm()
+		a.runMutation(mutation{
+			name:    fmt.Sprintf("scale to %d-%d nodes", minNodes, maxNodes),
+			view:    viewPools,
+			pending: "RESIZING",
+			run: func(ctx context.Context, spcs *snowflake.SPCS, target string) error {
+				return spcs.AlterComputePool(ctx, target, minNodes, maxNodes)
+			},
+		}, "", pool.Name)
+	})
+	form.AddButton("Cancel", closeForm)
+	form.SetCancelFunc(closeForm)
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Scale %s ", pool.Name))
+	form.SetBackgroundColor(a.styles.RowAltBg)
+	form.SetBorderColor(a.styles.HeaderBg)
+	form.SetTitleColor(a.styles.PrimaryText)
+	form.SetLabelColor(a.styles.SecondaryText)
+	form.SetFieldBackgroundColor(a.styles.Border)
+	form.SetFieldTextColor(a.styles.PrimaryText)
+	form.SetButtonBackgroundColor(a.styles.Border)
+	form.SetButtonTextColor(a.styles.PrimaryText)
+
+	a.modalVisible = true
+	a.pages.AddPage(scalePage, centered(form, 40, 9), true, true)
+	a.app.SetFocus(form)
+}
+
+// parseNodeCounts validates the scale form's min and max node counts.
+func parseNodeCounts(minText, maxText string) (int, int, error) {
+	minNodes, err := strconv.Atoi(strings.TrimSpace(minText))
+	if err != nil {
+		return 0, 0, fmt.Errorf("min nodes must be a number")
+	}
+	maxNodes, err := strconv.Atoi(strings.TrimSpace(maxText))
+	if err != nil {
+		return 0, 0, fmt.Errorf("max nodes must be a number")
+	}
+	if minNodes < 1 {
+		return 0, 0, fmt.Errorf("min nodes must be at least 1")
+	}
+	if minNodes > maxNodes {
+		return 0, 0, fmt.Errorf("min nodes (%d) must not exceed max nodes (%d)", minNodes, maxNodes)
+	}
+	return minNodes, maxNodes, nil
+}
+
+// centered places p in a width x height box in the middle of the screen.
+func centered(p tview.Primitive, width, height int) tview.Primitive {
+	column := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(nil, 0, 1, false).
+		AddItem(p, height, 0, true).
+		AddItem(nil, 0, 1, false)
+	return tview.NewFlex().
+		AddItem(nil, 0, 1, false).
+		AddItem(column, width, 0, true).
+		AddItem(nil, 0, 1, false)
+}
@@ -0,0 +1,14 @@
+package ui
+
+import "testing"
+
+func TestParseNodeCounts(t *testing.T) {
+	if minNodes, maxNodes, err := parseNodeCounts(" 1", "3 "); err != nil || minNodes != 1 || maxNodes != 3 {
+		t.Fatalf("unexpected result %d %d %v", minNodes, maxNodes, err)
+	}
+	for _, c := range [][2]string{{"3", "1"}, {"0", "2"}, {"", "2"}, {"1", "x"}} {
+		if _, _, err := parseNodeCounts(c[0], c[1]); err == nil {
+			t.Fatalf("expected error for min=%q max=%q", c[0], c[1])
+		}
+	}
+}
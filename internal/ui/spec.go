@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/marcelinojackson-org/snow9s/pkg/models"
+	"github.com/rivo/tview"
+)
+
+// formatSpecSummary renders a service spec's containers and endpoints as an
+// indented block for the detail pane.
+func formatSpecSummary(spec models.ServiceSpec) string {
+	var b strings.Builder
+	b.WriteString("  Containers:\n")
+	if len(spec.Containers) == 0 {
+		b.WriteString("    (none)\n")
+	}
+	for _, c := range spec.Containers {
+		version := c.Image.Tag
+		if c.Image.Digest != "" {
+			version = c.Image.Digest
+		}
+		fmt.Fprintf(&b, "    %s  image=%s  version=%s  repo=%s\n", c.Name, c.Image.Name, version, c.Image.RepositoryPath())
+	}
+	b.WriteString("  Endpoints:\n")
+	if len(spec.Endpoints) == 0 {
+		b.WriteString("    (none)\n")
+	}
+	for _, e := range spec.Endpoints {
+		port := e.PortRange
+		if port == "" {
+			port = fmt.Sprint(e.Port)
+		}
+		visibility := "internal"
+		if e.Public {
+			visibility = "public"
+		}
+		fmt.Fprintf(&b, "    %s  %s/%s  %s\n", e.Name, port, e.Protocol, visibility)
+	}
+	return b.String()
+}
+
+// yamlKeyLine splits a YAML line into indentation, an optional list dash, the
+// key with its colon, and the value.
+var yamlKeyLine = regexp.MustCompile(`^(\s*)(- )?([^\s#:][^:#]*:)(\s.*|)$`)
+
+// openSpec shows the selected service's YAML spec in the detail pane.
+func (a *App) openSpec() {
+	if a.view != viewServices {
+		a.showError("Specs are available from the Services view")
+		return
+	}
+	row, ok := a.table.SelectedRow()
+	if !ok || len(row.Cells) < 2 || row.Cells[1] == "" {
+		a.showError("Select a service first to view its spec")
+		return
+	}
+	name, spcs := row.Cells[1], a.spcsIn(row.Cells[0])
+	gen := a.showDetail(fmt.Sprintf(" Spec: %s (Esc to close, w to wrap) ", name), "Loading spec…")
+
+	go func() {
+		ctx, cancel := context.WithTimeout(a.rootContext(), a.cfg.Timeout())
+		defer cancel()
+		spec, err := spcs.GetServiceSpec(ctx, name)
+		a.safeUpdate(func() {
+			if !a.detailCurrent(gen) {
+				return
+			}
+			a.detailView.SetText(formatSpec(name, spec, err, !noColorRequested()))
+			a.detailView.ScrollToBeginning()
+		})
+	}()
+}
+
+// formatSpec renders a fetched spec for the pane, highlighted when color is
+// on.
+func formatSpec(service, spec string, err error, color bool) string {
+	if err != nil {
+		return fmt.Sprintf("Fetching the spec of %s failed: %s", service, withHint(err))
+	}
+	if strings.TrimSpace(spec) == "" {
+		return fmt.Sprintf("%s has no spec; DESCRIBE SERVICE returned none, which usually means the role cannot see it.", service)
+	}
+	if !color {
+		return tview.Escape(spec)
+	}
+	return highlightYAML(spec)
+}
+
+// highlightYAML colors comments, keys, and list dashes line by line. It is
+// not a YAML parser; anything it does not recognize is left plain.
+func highlightYAML(text string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "#"):
+			lines[i] = "[gray]" + tview.Escape(line) + "[-]"
+		case yamlKeyLine.MatchString(line):
+			m := yamlKeyLine.FindStringSubmatch(line)
+			dash := ""
+			if m[2] != "" {
+				dash = "[yellow]- [-]"
+			}
+			lines[i] = m[1] + dash + "[aqua]" + tview.Escape(m[3]) + "[-]" + highlightValue(m[4])
+		case strings.HasPrefix(trimmed, "- "):
+			indent := line[:len(line)-len(strings.TrimLeft(line, " "))]
+			lines[i] = indent + "[yellow]- [-]" + highlightValue(strings.TrimPrefix(strings.TrimLeft(line, " "), "- "))
+		default:
+			lines[i] = tview.Escape(line)
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// highlightValue colors a scalar value, keeping any trailing comment gray.
+func highlightValue(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return tview.Escape(value)
+	}
+	comment := ""
+	if i := strings.Index(value, " #"); i >= 0 {
+		value, comment = value[:i], value[i:]
+	}
+	out := "[green]" + tview.Escape(value) + "[-]"
+	if comment != "" {
+		out += "[gray]" + tview.Escape(comment) + "[-]"
+	}
+	return out
+}
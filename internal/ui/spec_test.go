@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rivo/tview"
+)
+
+func TestHighlightYAML(t *testing.T) {
+	spec := "spec:\n  # main container\n  containers:\n  - name: api\n    image: /db/repo/api:1.2 # pinned\n    args: [\"--port\", \"8080\"]\n    - plain\n"
+	got := highlightYAML(spec)
+	for _, want := range []string{
+		"[aqua]spec:[-]",
+		"\n[gray]  # main container[-]\n",
+		"  [yellow]- [-][aqua]name:[-][green] api[-]",
+		"[aqua]image:[-][green] /db/repo/api:1.2[-][gray] # pinned[-]",
+		`[green] ["--port", "8080"[]`,
+		"    [yellow]- [-][green]plain[-]",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestFormatSpecHandlesMissingSpec(t *testing.T) {
+	if got := formatSpec("api", "", nil, true); !strings.Contains(got, "api has no spec") {
+		t.Fatalf("unexpected empty-spec message: %q", got)
+	}
+	if got := formatSpec("api", "", errors.New("boom"), true); !strings.Contains(got, "failed: boom") {
+		t.Fatalf("unexpected error message: %q", got)
+	}
+	if got := formatSpec("api", "a: [b]\n", nil, false); got != tview.Escape("a: [b]\n") {
+		t.Fatalf("expected escaped plain text without color, got %q", got)
+	}
+}
+
+func TestOpenSpecDropsStaleResults(t *testing.T) {
+	app, _ := newTestAppWithDB(t)
+	app.stopped.Store(true) // drop the failed background load
+	app.table.SetData([]string{"NAMESPACE", "NAME", "STATUS"}, []TableRow{{Cells: []string{"PUBLIC", "api", "RUNNING"}}})
+	app.table.Select(1, 0)
+
+	app.openSpec()
+	gen := app.detailGen
+	if !app.detailCurrent(gen) || app.detailView.GetText(true) != "Loading spec…" {
+		t.Fatalf("expected the spec pane to open while loading, got %q", app.detailView.GetText(true))
+	}
+	app.closeDetail()
+	app.openLogs()
+	if app.detailCurrent(gen) {
+		t.Fatalf("a spec load must not fill a pane reopened for something else")
+	}
+}
@@ -1,9 +1,14 @@
 package ui
 
 import (
+	"fmt"
+	"os"
+	"strconv"
 	"strings"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/marcelinojackson-org/snow9s/internal/config"
+	"github.com/marcelinojackson-org/snow9s/pkg/models"
 )
 
 // StyleConfig captures the k9s-inspired palette used throughout the UI.
@@ -21,6 +26,7 @@ type StyleConfig struct {
 	StatusStarting  tcell.Color
 	StatusStopped   tcell.Color
 	StatusSuspended tcell.Color
+	Highlight       tcell.Color
 }
 
 // DefaultStyles returns the base k9s-like scheme.
@@ -39,21 +45,137 @@ func DefaultStyles() StyleConfig {
 		StatusStarting:  tcell.NewHexColor(0xFFFF00),
 		StatusStopped:   tcell.NewHexColor(0xFF0000),
 		StatusSuspended: tcell.NewHexColor(0x666666),
+		Highlight:       tcell.NewHexColor(0xFFA500),
 	}
 }
 
-// StatusColor picks the right status color using the StyleConfig.
+// lightStyles suits terminals with a light background.
+func lightStyles() StyleConfig {
+	return StyleConfig{
+		Background:      tcell.ColorWhite,
+		PrimaryText:     tcell.ColorBlack,
+		SecondaryText:   tcell.NewHexColor(0x5F5F5F),
+		HeaderBg:        tcell.NewHexColor(0x005F87),
+		HeaderText:      tcell.ColorWhite,
+		SelectionBg:     tcell.NewHexColor(0x005F87),
+		SelectionText:   tcell.ColorWhite,
+		Border:          tcell.NewHexColor(0xBCBCBC),
+		RowAltBg:        tcell.NewHexColor(0xEEEEEE),
+		StatusRunning:   tcell.NewHexColor(0x008700),
+		StatusStarting:  tcell.NewHexColor(0xAF8700),
+		StatusStopped:   tcell.NewHexColor(0xD70000),
+		StatusSuspended: tcell.NewHexColor(0x8A8A8A),
+		Highlight:       tcell.NewHexColor(0xD75F00),
+	}
+}
+
+// solarizedStyles is the dark Solarized palette.
+func solarizedStyles() StyleConfig {
+	return StyleConfig{
+		Background:      tcell.NewHexColor(0x002B36),
+		PrimaryText:     tcell.NewHexColor(0x93A1A1),
+		SecondaryText:   tcell.NewHexColor(0x586E75),
+		HeaderBg:        tcell.NewHexColor(0x2AA198),
+		HeaderText:      tcell.NewHexColor(0x002B36),
+		SelectionBg:     tcell.NewHexColor(0xEEE8D5),
+		SelectionText:   tcell.NewHexColor(0x002B36),
+		Border:          tcell.NewHexColor(0x073642),
+		RowAltBg:        tcell.NewHexColor(0x073642),
+		StatusRunning:   tcell.NewHexColor(0x859900),
+		StatusStarting:  tcell.NewHexColor(0xB58900),
+		StatusStopped:   tcell.NewHexColor(0xDC322F),
+		StatusSuspended: tcell.NewHexColor(0x586E75),
+		Highlight:       tcell.NewHexColor(0xCB4B16),
+	}
+}
+
+// themePresets are the palettes selectable with theme.preset or --theme.
+var themePresets = map[string]func() StyleConfig{
+	"default":   DefaultStyles,
+	"light":     lightStyles,
+	"solarized": solarizedStyles,
+}
+
+// ThemeStyles builds the palette for theme: the named preset, then any
+// per-color overrides. Unknown presets and invalid colors keep the preset's
+// value and are reported as warnings.
+func ThemeStyles(theme config.Theme) (StyleConfig, []string) {
+	var warnings []string
+	styles := DefaultStyles()
+	if theme.Preset != "" {
+		if preset, ok := themePresets[strings.ToLower(theme.Preset)]; ok {
+			styles = preset()
+		} else {
+			warnings = append(warnings, fmt.Sprintf("theme: unknown preset %q (want default, light, or solarized)", theme.Preset))
+		}
+	}
+
+	overrides := []struct {
+		key   string
+		value string
+		color *tcell.Color
+	}{
+		{"background", theme.Background, &styles.Background},
+		{"primary_text", theme.PrimaryText, &styles.PrimaryText},
+		{"secondary_text", theme.SecondaryText, &styles.SecondaryText},
+		{"header_bg", theme.HeaderBg, &styles.HeaderBg},
+		{"header_text", theme.HeaderText, &styles.HeaderText},
+		{"selection_bg", theme.SelectionBg, &styles.SelectionBg},
+		{"selection_text", theme.SelectionText, &styles.SelectionText},
+		{"border", theme.Border, &styles.Border},
+		{"row_alt_bg", theme.RowAltBg, &styles.RowAltBg},
+		{"status_running", theme.StatusRunning, &styles.StatusRunning},
+		{"status_starting", theme.StatusStarting, &styles.StatusStarting},
+		{"status_stopped", theme.StatusStopped, &styles.StatusStopped},
+		{"status_suspended", theme.StatusSuspended, &styles.StatusSuspended},
+		{"highlight", theme.Highlight, &styles.Highlight},
+	}
+	for _, o := range overrides {
+		if o.value == "" {
+			continue
+		}
+		color, err := parseHexColor(o.value)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("theme.%s: %v", o.key, err))
+			continue
+		}
+		*o.color = color
+	}
+	return styles, warnings
+}
+
+// parseHexColor reads an RGB color written as "#rrggbb" or "rrggbb".
+func parseHexColor(value string) (tcell.Color, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(value), "#")
+	if len(hex) != 6 {
+		return tcell.ColorDefault, fmt.Errorf("invalid hex color %q", value)
+	}
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return tcell.ColorDefault, fmt.Errorf("invalid hex color %q", value)
+	}
+	return tcell.NewHexColor(int32(rgb)), nil
+}
+
+// StatusColor picks the right status color using the StyleConfig. Statuses
+// are normalized first, so any casing or alias gets the same color.
 func (s StyleConfig) StatusColor(status string) tcell.Color {
-	switch strings.ToLower(status) {
-	case "running", "started", "ready":
+	switch models.NormalizeStatus(status) {
+	case models.StatusRunning, models.StatusReady, models.StatusDone:
 		return s.StatusRunning
-	case "starting", "init", "pending":
+	case models.StatusStarting, models.StatusPending, models.StatusSuspending, models.StatusDeleting, "warning":
 		return s.StatusStarting
-	case "suspended", "paused":
+	case models.StatusSuspended:
 		return s.StatusSuspended
-	case "stopped", "failed", "error", "down":
+	case models.StatusStopped, models.StatusFailed, models.StatusInternalError, models.StatusCancelled, "error", "down":
 		return s.StatusStopped
 	default:
 		return s.SecondaryText
 	}
 }
+
+// noColorRequested reports whether the NO_COLOR convention asks for plain,
+// uncolored output.
+func noColorRequested() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
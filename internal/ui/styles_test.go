@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/marcelinojackson-org/snow9s/internal/config"
+)
+
+func TestThemeStyles(t *testing.T) {
+	styles, warnings := ThemeStyles(config.Theme{})
+	if styles != DefaultStyles() || len(warnings) != 0 {
+		t.Fatalf("empty theme should use defaults, warnings: %v", warnings)
+	}
+
+	styles, warnings = ThemeStyles(config.Theme{Preset: "Light", HeaderBg: "#112233", Border: "#12345"})
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "theme.border") {
+		t.Fatalf("expected one border warning, got %v", warnings)
+	}
+	if styles.HeaderBg != tcell.NewHexColor(0x112233) {
+		t.Fatalf("header_bg override not applied: %v", styles.HeaderBg)
+	}
+	if styles.Border != lightStyles().Border || styles.Background != tcell.ColorWhite {
+		t.Fatalf("expected light preset values, got %+v", styles)
+	}
+
+	styles, warnings = ThemeStyles(config.Theme{Preset: "neon"})
+	if styles != DefaultStyles() || len(warnings) != 1 {
+		t.Fatalf("unknown preset should fall back to defaults with a warning: %v", warnings)
+	}
+}
+
+func TestStatusColorNormalizes(t *testing.T) {
+	styles := DefaultStyles()
+	for status, want := range map[string]tcell.Color{
+		"RUNNING":        styles.StatusRunning,
+		"ready":          styles.StatusRunning,
+		"Completed":      styles.StatusRunning,
+		"PENDING":        styles.StatusStarting,
+		"paused":         styles.StatusSuspended,
+		"INTERNAL_ERROR": styles.StatusStopped,
+		"Failed":         styles.StatusStopped,
+		"mystery":        styles.SecondaryText,
+	} {
+		if got := styles.StatusColor(status); got != want {
+			t.Errorf("StatusColor(%q) = %v, want %v", status, got, want)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/marcelinojackson-org/snow9s/pkg/models"
+)
+
+// statusSummary counts services per status, most common first, as
+// "running:12 pending:2 failed:1" with each entry in its status color.
+func statusSummary(services []models.Service, styles StyleConfig) string {
+	counts := map[string]int{}
+	for _, svc := range services {
+		status := models.NormalizeStatus(svc.Status)
+		if status == "" {
+			status = "unknown"
+		}
+		counts[status]++
+	}
+	statuses := make([]string, 0, len(counts))
+	for status := range counts {
+		statuses = append(statuses, status)
+	}
+	slices.SortFunc(statuses, func(a, b string) int {
+		if counts[a] != counts[b] {
+			return counts[b] - counts[a]
+		}
+		return strings.Compare(a, b)
+	})
+	parts := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		parts = append(parts, fmt.Sprintf("[#%06x]%s:%d[-]", styles.StatusColor(status).Hex(), status, counts[status]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// serviceSummary is the per-status breakdown for the footer in the Services
+// view. While a filter is active it counts the matching services unless
+// summaryTotal asks for the whole list.
+func (a *App) serviceSummary() string {
+	if a.view != viewServices || len(a.services) == 0 {
+		return ""
+	}
+	if strings.TrimSpace(a.table.Filter()) == "" {
+		return statusSummary(a.services, a.styles)
+	}
+	if a.summaryTotal {
+		return statusSummary(a.services, a.styles) + " (total)"
+	}
+	var visible []models.Service
+	for _, m := range a.table.VisibleModels() {
+		if svc, ok := m.(models.Service); ok {
+			visible = append(visible, svc)
+		}
+	}
+	if len(visible) == 0 {
+		return ""
+	}
+	return statusSummary(visible, a.styles) + " (filtered)"
+}
+
+// toggleSummaryTotal switches the status counts between the filtered
+// services and all of them.
+func (a *App) toggleSummaryTotal() {
+	a.summaryTotal = !a.summaryTotal
+	a.updateFooterStatus()
+}
@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/marcelinojackson-org/snow9s/pkg/models"
+)
+
+func TestStatusSummary(t *testing.T) {
+	styles := DefaultStyles()
+	services := []models.Service{
+		{Name: "a", Status: "RUNNING"},
+		{Name: "b", Status: "running"},
+		{Name: "c", Status: "FAILED"},
+		{Name: "d", Status: "PENDING"},
+		{Name: "e"},
+	}
+	want := fmt.Sprintf("[#%06x]running:2[-] [#%06x]failed:1[-] [#%06x]pending:1[-] [#%06x]unknown:1[-]",
+		styles.StatusRunning.Hex(), styles.StatusStopped.Hex(), styles.StatusColor("pending").Hex(), styles.StatusColor("unknown").Hex())
+	if got := statusSummary(services, styles); got != want {
+		t.Fatalf("got %q\nwant %q", got, want)
+	}
+}
+
+func TestServiceSummaryFollowsFilter(t *testing.T) {
+	app := newTestApp(t)
+	app.services = []models.Service{
+		{Namespace: "PUBLIC", Name: "api", Status: "RUNNING"},
+		{Namespace: "PUBLIC", Name: "web", Status: "RUNNING"},
+		{Namespace: "PUBLIC", Name: "job", Status: "FAILED"},
+	}
+	app.table.SetData([]string{"NAMESPACE", "NAME", "STATUS", "POOL", "AGE"}, serviceRows(app.services, false))
+	total := statusSummary(app.services, app.styles)
+	if got := app.serviceSummary(); got != total {
+		t.Fatalf("unfiltered summary: got %q want %q", got, total)
+	}
+
+	app.table.SetFilter("api")
+	filtered := statusSummary(app.services[:1], app.styles) + " (filtered)"
+	if got := app.serviceSummary(); got != filtered {
+		t.Fatalf("filtered summary: got %q want %q", got, filtered)
+	}
+	app.toggleSummaryTotal()
+	if got := app.serviceSummary(); got != total+" (total)" {
+		t.Fatalf("toggled summary: got %q", got)
+	}
+
+	app.view = viewPools
+	if got := app.serviceSummary(); got != "" {
+		t.Fatalf("summary outside Services: %q", got)
+	}
+}
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -9,10 +10,57 @@ import (
 	"github.com/rivo/tview"
 )
 
+// TableRow is a single rendered row. Rows with a non-empty Group are
+// collapsible status headers rather than resource records; Pinned rows
+// float to the top and are never hidden by the filter. Model keeps the typed
+// record the row was rendered from. Key identifies the record across
+// refreshes; when empty the first cell is used.
 type TableRow struct {
-	Cells []string
+	Cells  []string
+	Group  string
+	Pinned bool
+	Model  any
+	Key    string
+	// Hidden holds values the filter matches without a column of their own,
+	// such as a service's owner outside the wide layout.
+	Hidden []string
 }
 
+// rowKey returns the identity used to keep a row selected across renders.
+func rowKey(row TableRow) string {
+	switch {
+	case row.Group != "":
+		return "group:" + row.Group
+	case row.Key != "":
+		return row.Key
+	case len(row.Cells) > 0:
+		return row.Cells[0]
+	default:
+		return ""
+	}
+}
+
+// ColumnFormat controls how a column's values are rendered.
+type ColumnFormat int
+
+const (
+	// FormatText renders values as-is.
+	FormatText ColumnFormat = iota
+	// FormatBool renders true/false as colored ✓/✗ (Y/N in ASCII mode).
+	FormatBool
+	// FormatPercent colors percentages green, yellow, or red as they rise.
+	FormatPercent
+	// FormatReachability colors endpoint probe results green when up, red
+	// when down, and yellow while checking.
+	FormatReachability
+)
+
+// Thresholds at which FormatPercent cells turn yellow and red.
+const (
+	percentWarn     = 70
+	percentCritical = 90
+)
+
 // DataTable extends tview.Table with k9s-like styling and filtering.
 type DataTable struct {
 	*tview.Table
@@ -20,8 +68,14 @@ type DataTable struct {
 	headers      []string
 	rows         []TableRow
 	filtered     []TableRow
+	shown        []TableRow
 	filter       string
+	filterNote   string
+	match        tableFilter
 	statusColumn int
+	padding      int
+	formats      map[int]ColumnFormat
+	ascii        bool
 	mu           sync.Mutex
 }
 
@@ -37,7 +91,37 @@ func NewDataTable(styles StyleConfig) *DataTable {
 	table.SetBorderColor(styles.Border)
 	table.SetSelectedStyle(tcell.StyleDefault.Foreground(styles.SelectionText).Background(styles.SelectionBg).Bold(true))
 
-	return &DataTable{Table: table, styles: styles, statusColumn: -1}
+	return &DataTable{Table: table, styles: styles, statusColumn: -1, padding: 1}
+}
+
+// SetLayout switches between the borderless default and a bordered table and
+// sets the horizontal cell padding.
+func (t *DataTable) SetLayout(bordered bool, padding int) {
+	if padding < 0 {
+		padding = 0
+	}
+	t.SetBorders(bordered)
+	t.SetBorder(bordered)
+	t.mu.Lock()
+	t.padding = padding
+	t.mu.Unlock()
+	t.render()
+}
+
+// SetColumnFormats sets per-column render formats; unlisted columns are text.
+func (t *DataTable) SetColumnFormats(formats map[int]ColumnFormat) {
+	t.mu.Lock()
+	t.formats = formats
+	t.mu.Unlock()
+	t.render()
+}
+
+// SetASCII switches glyphs to plain ASCII without color, e.g. for NO_COLOR.
+func (t *DataTable) SetASCII(ascii bool) {
+	t.mu.Lock()
+	t.ascii = ascii
+	t.mu.Unlock()
+	t.render()
 }
 
 // SetStatusColumn configures which column is treated as a status column.
@@ -65,6 +149,21 @@ func (t *DataTable) SetFilter(filter string) {
 	t.applyFilter()
 }
 
+// Filter returns the active filter text.
+func (t *DataTable) Filter() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.filter
+}
+
+// FilterNote explains how the active filter was interpreted when it fell
+// back to literal matching, or returns "".
+func (t *DataTable) FilterNote() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.filterNote
+}
+
 // SelectionInfo returns the formatted selected/total count.
 func (t *DataTable) SelectionInfo() string {
 	t.mu.Lock()
@@ -80,6 +179,13 @@ func (t *DataTable) SelectionInfo() string {
 	return fmt.Sprintf("%d/%d", row, total)
 }
 
+// Len returns how many rows pass the current filter.
+func (t *DataTable) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.filtered)
+}
+
 // SelectedRow returns the currently selected row.
 func (t *DataTable) SelectedRow() (TableRow, bool) {
 	t.mu.Lock()
@@ -98,6 +204,28 @@ func (t *DataTable) SelectedRow() (TableRow, bool) {
 	return t.filtered[index], true
 }
 
+// VisibleModels returns the typed records behind the rows that pass the
+// current filter, skipping group headers.
+func (t *DataTable) VisibleModels() []any {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	models := make([]any, 0, len(t.filtered))
+	for _, row := range t.filtered {
+		if row.Group != "" || row.Model == nil {
+			continue
+		}
+		models = append(models, row.Model)
+	}
+	return models
+}
+
+// StatusColumn returns the column colored by status, or -1.
+func (t *DataTable) StatusColumn() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.statusColumn
+}
+
 // Headers returns the current table headers.
 func (t *DataTable) Headers() []string {
 	t.mu.Lock()
@@ -105,42 +233,92 @@ func (t *DataTable) Headers() []string {
 	return append([]string(nil), t.headers...)
 }
 
+// SelectGroup moves the selection to the header row of the given group.
+func (t *DataTable) SelectGroup(group string) bool {
+	t.mu.Lock()
+	idx := -1
+	for i, row := range t.filtered {
+		if row.Group == group {
+			idx = i
+			break
+		}
+	}
+	t.mu.Unlock()
+	if idx < 0 {
+		return false
+	}
+	t.Select(idx+1, 0)
+	return true
+}
+
 func (t *DataTable) applyFilter() {
 	t.mu.Lock()
-	filter := strings.ToLower(strings.TrimSpace(t.filter))
+	raw := strings.TrimSpace(t.filter)
+	filter := parseFilter(raw, t.headers)
 	rows := append([]TableRow(nil), t.rows...)
 	t.mu.Unlock()
 
 	filtered := make([]TableRow, 0, len(rows))
 	for _, row := range rows {
-		if filter == "" {
+		if row.Pinned {
+			filtered = append(filtered, row)
+		}
+	}
+	var pendingGroup *TableRow
+	for i, row := range rows {
+		if row.Pinned {
+			continue
+		}
+		if raw == "" {
 			filtered = append(filtered, row)
 			continue
 		}
-		joined := strings.ToLower(strings.Join(row.Cells, " "))
-		if strings.Contains(joined, filter) {
+		if row.Group != "" {
+			// Keep group headers only when one of their members matches.
+			pendingGroup = &rows[i]
+			continue
+		}
+		if filter.matches(row) {
+			if pendingGroup != nil {
+				filtered = append(filtered, *pendingGroup)
+				pendingGroup = nil
+			}
 			filtered = append(filtered, row)
 		}
 	}
 
 	t.mu.Lock()
 	t.filtered = filtered
+	t.filterNote = filter.note
+	t.match = filter
 	t.mu.Unlock()
 	t.render()
 }
 
+// render redraws the table, keeping the previously selected record selected
+// when it is still shown and falling back to the first row otherwise.
 func (t *DataTable) render() {
+	selected, _ := t.GetSelection()
 	t.Clear()
 
 	t.mu.Lock()
+	prevKey := ""
+	if selected >= 1 && selected <= len(t.shown) {
+		prevKey = rowKey(t.shown[selected-1])
+	}
 	headers := append([]string(nil), t.headers...)
 	rows := append([]TableRow(nil), t.filtered...)
+	t.shown = rows
 	statusCol := t.statusColumn
+	pad := strings.Repeat(" ", t.padding)
+	formats := t.formats
+	ascii := t.ascii
+	match := t.match
 	t.mu.Unlock()
 
 	// Header row
 	for c, h := range headers {
-		cell := tview.NewTableCell(fmt.Sprintf(" %s ", h)).
+		cell := tview.NewTableCell(pad + h + pad).
 			SetTextColor(t.styles.PrimaryText).
 			SetBackgroundColor(t.styles.Background).
 			SetAlign(tview.AlignLeft).
@@ -157,18 +335,75 @@ func (t *DataTable) render() {
 			bg = t.styles.RowAltBg
 		}
 		for c, v := range row.Cells {
-			cell := tview.NewTableCell(fmt.Sprintf(" %s ", v)).
-				SetTextColor(t.cellColor(c, v, statusCol)).
+			color := t.cellColor(c, v, statusCol)
+			text := tview.Escape(v)
+			if formats[c] == FormatBool && row.Group == "" {
+				v, color = t.boolGlyph(v, color, ascii)
+				text = v
+			} else if row.Group == "" {
+				text = t.highlight(v, match.spans(c, v), ascii)
+			}
+			if formats[c] == FormatPercent && row.Group == "" {
+				color = t.percentColor(v, color)
+			}
+			if formats[c] == FormatReachability && row.Group == "" {
+				color = t.reachabilityColor(v, color)
+			}
+			if c == 0 && row.Pinned {
+				text = "★ " + text
+			}
+			cell := tview.NewTableCell(pad + text + pad).
+				SetTextColor(color).
 				SetBackgroundColor(bg).
 				SetAlign(tview.AlignLeft).
 				SetExpansion(1)
+			if row.Group != "" {
+				cell.SetTextColor(t.styles.StatusColor(row.Group)).SetAttributes(tcell.AttrBold)
+			}
 			t.SetCell(rowIdx, c, cell)
 		}
 	}
 
 	if len(rows) > 0 {
-		t.Select(1, 0)
+		t.Select(selectionIndex(rows, prevKey), 0)
+	}
+}
+
+// selectionIndex returns the table row (1-based, below the header) showing
+// key, or 1 when it is gone.
+func selectionIndex(rows []TableRow, key string) int {
+	if key == "" {
+		return 1
+	}
+	for i, row := range rows {
+		if rowKey(row) == key {
+			return i + 1
+		}
 	}
+	return 1
+}
+
+// highlight escapes value for tview and marks the filter's matches in it with
+// the highlight color, or underlines them in ASCII mode.
+func (t *DataTable) highlight(value string, spans [][]int, ascii bool) string {
+	if len(spans) == 0 {
+		return tview.Escape(value)
+	}
+	open := fmt.Sprintf("[#%06x::b]", t.styles.Highlight.Hex())
+	if ascii {
+		open = "[::u]"
+	}
+	var b strings.Builder
+	prev := 0
+	for _, span := range spans {
+		b.WriteString(tview.Escape(value[prev:span[0]]))
+		b.WriteString(open)
+		b.WriteString(tview.Escape(value[span[0]:span[1]]))
+		b.WriteString("[-::-]")
+		prev = span[1]
+	}
+	b.WriteString(tview.Escape(value[prev:]))
+	return b.String()
 }
 
 func (t *DataTable) cellColor(col int, value string, statusCol int) tcell.Color {
@@ -177,3 +412,62 @@ func (t *DataTable) cellColor(col int, value string, statusCol int) tcell.Color
 	}
 	return t.styles.PrimaryText
 }
+
+// boolGlyph renders a boolean cell value, leaving unparseable values alone.
+func (t *DataTable) boolGlyph(value string, color tcell.Color, ascii bool) (string, tcell.Color) {
+	b, err := strconv.ParseBool(strings.ToLower(strings.TrimSpace(value)))
+	if err != nil {
+		return value, color
+	}
+	switch {
+	case ascii && b:
+		return "Y", t.styles.PrimaryText
+	case ascii:
+		return "N", t.styles.PrimaryText
+	case b:
+		return "✓", t.styles.StatusRunning
+	default:
+		return "✗", t.styles.StatusStopped
+	}
+}
+
+// percentColor picks the threshold color for a value such as "75%", leaving
+// unparseable values alone.
+func (t *DataTable) percentColor(value string, color tcell.Color) tcell.Color {
+	pct, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(value), "%"))
+	switch {
+	case err != nil:
+		return color
+	case pct >= percentCritical:
+		return t.styles.StatusStopped
+	case pct >= percentWarn:
+		return t.styles.StatusStarting
+	default:
+		return t.styles.StatusRunning
+	}
+}
+
+// reachabilityColor colors an endpoint probe result, leaving empty cells
+// alone.
+func (t *DataTable) reachabilityColor(value string, color tcell.Color) tcell.Color {
+	switch {
+	case strings.HasPrefix(value, reachUp):
+		return t.styles.StatusRunning
+	case strings.HasPrefix(value, reachDown):
+		return t.styles.StatusStopped
+	case value == reachChecking:
+		return t.styles.StatusStarting
+	default:
+		return color
+	}
+}
+
+// cloneRows copies rows so display overlays never modify the loaded data.
+func cloneRows(rows []TableRow) []TableRow {
+	out := make([]TableRow, len(rows))
+	for i, row := range rows {
+		row.Cells = append([]string(nil), row.Cells...)
+		out[i] = row
+	}
+	return out
+}
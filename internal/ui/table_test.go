@@ -1,6 +1,10 @@
 package ui
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
 
 func TestTableFiltering(t *testing.T) {
 	table := NewDataTable(DefaultStyles())
@@ -19,6 +23,54 @@ func TestTableFiltering(t *testing.T) {
 	}
 }
 
+func TestSelectionSurvivesRefresh(t *testing.T) {
+	table := NewDataTable(DefaultStyles())
+	headers := []string{"NAMESPACE", "NAME", "STATUS"}
+	rows := func(names ...string) []TableRow {
+		out := []TableRow{}
+		for _, n := range names {
+			out = append(out, TableRow{Cells: []string{"PUBLIC", n, "RUNNING"}, Key: "PUBLIC." + n})
+		}
+		return out
+	}
+	name := func() string {
+		row, _ := table.SelectedRow()
+		return row.Cells[1]
+	}
+
+	table.SetData(headers, rows("alpha", "beta", "gamma", "delta"))
+	table.Select(3, 0)
+	if name() != "gamma" {
+		t.Fatalf("expected gamma selected got %s", name())
+	}
+	// A refresh reorders the rows; the cursor follows gamma.
+	table.SetData(headers, rows("zeta", "alpha", "beta", "delta", "gamma"))
+	if name() != "gamma" {
+		t.Fatalf("selection moved to %s after refresh", name())
+	}
+	table.SetData(headers, rows("alpha", "beta"))
+	if sel, _ := table.GetSelection(); sel != 1 {
+		t.Fatalf("expected fallback to first row got %d", sel)
+	}
+}
+
+func TestEmptyFilterResult(t *testing.T) {
+	table := NewDataTable(DefaultStyles())
+	table.SetData([]string{"NAMESPACE", "NAME"}, []TableRow{{Cells: []string{"PUBLIC", "api"}}, {Cells: []string{"PUBLIC", "worker"}}})
+	table.Select(2, 0)
+	table.SetFilter("nomatch")
+	if table.Len() != 0 || table.SelectionInfo() != "0/0" {
+		t.Fatalf("expected no rows, got %d (%s)", table.Len(), table.SelectionInfo())
+	}
+	if _, ok := table.SelectedRow(); ok {
+		t.Fatal("an empty table must not report a selected row")
+	}
+	table.SetFilter("")
+	if row, ok := table.SelectedRow(); !ok || row.Cells[1] != "api" {
+		t.Fatalf("clearing the filter should select the first row, got %+v", row)
+	}
+}
+
 func TestStatusColoring(t *testing.T) {
 	table := NewDataTable(DefaultStyles())
 	headers := []string{"NAME", "STATUS"}
@@ -31,3 +83,134 @@ func TestStatusColoring(t *testing.T) {
 		t.Fatalf("status color not applied")
 	}
 }
+
+func TestPinnedRowsFloatAndSurviveFilter(t *testing.T) {
+	table := NewDataTable(DefaultStyles())
+	rows := []TableRow{
+		{Cells: []string{"alpha", "running"}},
+		{Cells: []string{"beta", "stopped"}, Pinned: true},
+	}
+	table.SetData([]string{"NAME", "STATUS"}, rows)
+	if got := table.GetCell(1, 0).Text; got != " ★ beta " {
+		t.Fatalf("expected pinned row first got %q", got)
+	}
+	table.SetFilter("alpha")
+	if got := table.GetRowCount(); got != 3 {
+		t.Fatalf("expected pinned row to survive filter, got %d rows", got)
+	}
+}
+
+func TestFilterMatchesHighlighted(t *testing.T) {
+	table := NewDataTable(DefaultStyles())
+	table.SetData([]string{"NAME", "STATUS"}, []TableRow{
+		{Cells: []string{"api-api", "RUNNING"}},
+		{Cells: []string{"[web]", "FAILED"}},
+	})
+	text := func(row, col int) string { return table.GetCell(row, col).Text }
+
+	if got := text(2, 0); got != " [web[] " {
+		t.Fatalf("cell text should be escaped, got %q", got)
+	}
+	table.SetFilter("API")
+	if got, want := text(1, 0), " [#ffa500::b]api[-::-]-[#ffa500::b]api[-::-] "; got != want {
+		t.Fatalf("literal highlight: got %q want %q", got, want)
+	}
+	table.SetFilter("~run+")
+	if got, want := text(1, 1), " [#ffa500::b]RUNN[-::-]ING "; got != want {
+		t.Fatalf("regex highlight: got %q want %q", got, want)
+	}
+	table.SetFilter("status:fail")
+	if got, want := text(1, 0), " [web[] "; got != want {
+		t.Fatalf("a column filter highlights only its column: got %q", got)
+	}
+	if got, want := text(1, 1), " [#ffa500::b]FAIL[-::-]ED "; got != want {
+		t.Fatalf("column highlight: got %q want %q", got, want)
+	}
+}
+
+func TestTableLayoutPadding(t *testing.T) {
+	table := NewDataTable(DefaultStyles())
+	table.SetLayout(true, 3)
+	table.SetData([]string{"NAME"}, []TableRow{{Cells: []string{"svc"}}})
+	if got := table.GetCell(1, 0).Text; got != "   svc   " {
+		t.Fatalf("expected padded cell got %q", got)
+	}
+	table.SetLayout(false, -2)
+	if got := table.GetCell(1, 0).Text; got != "svc" {
+		t.Fatalf("expected negative padding to clamp to zero got %q", got)
+	}
+}
+
+func TestVisibleModelsFollowFilter(t *testing.T) {
+	table := NewDataTable(DefaultStyles())
+	rows := []TableRow{
+		{Cells: []string{"RUNNING (2)"}, Group: "RUNNING"},
+		{Cells: []string{"alpha"}, Model: "a"},
+		{Cells: []string{"beta"}, Model: "b"},
+	}
+	table.SetData([]string{"NAME"}, rows)
+	if got := table.VisibleModels(); len(got) != 2 {
+		t.Fatalf("expected 2 models without group header got %v", got)
+	}
+	table.SetFilter("beta")
+	got := table.VisibleModels()
+	if len(got) != 1 || got[0] != "b" {
+		t.Fatalf("expected filtered model b got %v", got)
+	}
+}
+
+func TestBoolColumnGlyphs(t *testing.T) {
+	styles := DefaultStyles()
+	table := NewDataTable(styles)
+	table.SetColumnFormats(map[int]ColumnFormat{1: FormatBool})
+	table.SetData([]string{"NAME", "AUTO_RESUME"}, []TableRow{
+		{Cells: []string{"a", "true"}},
+		{Cells: []string{"b", "FALSE"}},
+		{Cells: []string{"c", "unknown"}},
+	})
+	cases := []struct {
+		row   int
+		text  string
+		color tcell.Color
+	}{
+		{1, " ✓ ", styles.StatusRunning},
+		{2, " ✗ ", styles.StatusStopped},
+		{3, " unknown ", styles.PrimaryText},
+	}
+	for _, c := range cases {
+		cell := table.GetCell(c.row, 1)
+		if fg, _, _ := cell.Style.Decompose(); cell.Text != c.text || fg != c.color {
+			t.Fatalf("row %d: got %q color %v, want %q color %v", c.row, cell.Text, fg, c.text, c.color)
+		}
+	}
+
+	table.SetASCII(true)
+	if got := table.GetCell(1, 1).Text; got != " Y " {
+		t.Fatalf("expected ASCII Y got %q", got)
+	}
+	if got := table.GetCell(2, 1).Text; got != " N " {
+		t.Fatalf("expected ASCII N got %q", got)
+	}
+	table.SetFilter("true")
+	if got := table.GetRowCount(); got != 2 {
+		t.Fatalf("filter should match raw values, got %d rows", got)
+	}
+}
+
+func TestPercentColumnThresholds(t *testing.T) {
+	styles := DefaultStyles()
+	table := NewDataTable(styles)
+	table.SetColumnFormats(map[int]ColumnFormat{1: FormatPercent})
+	table.SetData([]string{"NAME", "UTIL"}, []TableRow{
+		{Cells: []string{"a", "25%"}},
+		{Cells: []string{"b", "70%"}},
+		{Cells: []string{"c", "100%"}},
+		{Cells: []string{"d", "-"}},
+	})
+	want := []tcell.Color{styles.StatusRunning, styles.StatusStarting, styles.StatusStopped, styles.PrimaryText}
+	for i, color := range want {
+		if got, _, _ := table.GetCell(i+1, 1).Style.Decompose(); got != color {
+			t.Fatalf("row %d: expected color %v got %v", i+1, color, got)
+		}
+	}
+}
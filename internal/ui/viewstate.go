@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"github.com/marcelinojackson-org/snow9s/internal/config"
+)
+
+// topLevelView maps a view to the one to restore on the next start. Child
+// views depend on a selection that is gone by then, so they map to their
+// parent.
+func topLevelView(view viewKind) viewKind {
+	switch view {
+	case viewPools, viewRepos, viewDatabases, viewJobs:
+		return view
+	case viewImages:
+		return viewRepos
+	case viewSchemas:
+		return viewDatabases
+	default:
+		return viewServices
+	}
+}
+
+// restoreView opens the view and filter saved for the current context,
+// falling back to Services.
+func (a *App) restoreView() {
+	saved := a.state.Views[config.StateKey(a.cfg)]
+	a.setView(topLevelView(viewKind(saved.View)))
+	if saved.Filter == "" {
+		return
+	}
+	a.filterField.SetText(saved.Filter)
+	a.table.SetFilter(saved.Filter)
+	a.updateFooterStatus()
+}
+
+// rememberView records the current view and applied filter for the context.
+func (a *App) rememberView() {
+	if a.state.Views == nil {
+		a.state.Views = map[string]config.ViewState{}
+	}
+	a.state.Views[config.StateKey(a.cfg)] = config.ViewState{
+		View:   string(topLevelView(a.view)),
+		Filter: a.table.Filter(),
+	}
+}
+
+// saveViewState persists the current view and filter when the TUI exits.
+// Failing to save only loses the convenience, so errors are ignored.
+func (a *App) saveViewState() {
+	a.rememberView()
+	_ = config.SaveState(a.state)
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/marcelinojackson-org/snow9s/internal/config"
+)
+
+// newContextApp returns a stopped app for the named context whose queries
+// go to a mock that fails them.
+func newContextApp(t *testing.T, name string) *App {
+	t.Helper()
+	app, _ := newMockApp(t, config.Config{Context: name, Database: "DB", Schema: "PUBLIC"})
+	app.stopped.Store(true)
+	return app
+}
+
+func TestViewStateRoundTrip(t *testing.T) {
+	app := newTestApp(t)
+	app.stopped.Store(true)
+	app.cfg.Context = "dev"
+	app.view = viewImages
+	app.table.SetFilter("name:api")
+	app.saveViewState()
+
+	state, err := config.LoadState(config.Config{})
+	if err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	if got := state.Views["dev"]; got.View != string(viewRepos) || got.Filter != "name:api" {
+		t.Fatalf("unexpected saved view state: %+v", got)
+	}
+
+	restored := newContextApp(t, "dev")
+	restored.restoreView()
+	if restored.view != viewRepos || restored.table.Filter() != "name:api" || restored.filterField.GetText() != "name:api" {
+		t.Fatalf("view not restored: view=%s filter=%q", restored.view, restored.table.Filter())
+	}
+
+	other := newContextApp(t, "prod")
+	other.restoreView()
+	if other.view != viewServices || other.table.Filter() != "" {
+		t.Fatalf("another context must start fresh: view=%s filter=%q", other.view, other.table.Filter())
+	}
+}
+
+func TestCorruptStateIsIgnored(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv("SNOW9S_CONFIG", path)
+	if err := os.WriteFile(filepath.Join(filepath.Dir(path), "state.json"), []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	app := newContextApp(t, "dev")
+	app.restoreView()
+	if app.view != viewServices {
+		t.Fatalf("expected the default view, got %s", app.view)
+	}
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/marcelinojackson-org/snow9s/internal/config"
+	"github.com/rivo/tview"
+)
+
+// Text view kinds whose wrap preference is remembered in state.
+const (
+	wrapDetail = "detail"
+	wrapDebug  = "debug"
+)
+
+// wrapTarget returns the text view for kind, or nil if it does not exist.
+func (a *App) wrapTarget(kind string) *tview.TextView {
+	switch kind {
+	case wrapDetail:
+		return a.detailView
+	case wrapDebug:
+		return a.debugView
+	}
+	return nil
+}
+
+// applyWrap sets wrapping on every text view from the saved preferences.
+func (a *App) applyWrap() {
+	for _, kind := range []string{wrapDetail, wrapDebug} {
+		if view := a.wrapTarget(kind); view != nil {
+			view.SetWrap(!a.state.NoWrap[kind])
+		}
+	}
+}
+
+// toggleWrap flips line wrapping for the kind of text view and persists it.
+func (a *App) toggleWrap(kind string) {
+	if a.wrapTarget(kind) == nil {
+		a.showError("Wrap applies to the details and debug panes (run with --debug)")
+		return
+	}
+	if a.state.NoWrap == nil {
+		a.state.NoWrap = map[string]bool{}
+	}
+	a.state.NoWrap[kind] = !a.state.NoWrap[kind]
+	a.applyWrap()
+	if err := config.SaveState(a.state); err != nil {
+		a.showError(fmt.Sprintf("Save preferences failed: %v", err))
+	}
+}
@@ -0,0 +1,20 @@
+// Package version holds build metadata injected at link time.
+package version
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Set via -ldflags "-X github.com/marcelinojackson-org/snow9s/internal/version.Version=...".
+var (
+	Version = "0.1.0"
+	Commit  = "unknown"
+	Date    = "unknown"
+)
+
+// Info returns the build metadata plus the Go toolchain and platform, one field per line.
+func Info() string {
+	return fmt.Sprintf("version:    %s\ncommit:     %s\nbuilt:      %s\ngo:         %s\nplatform:   %s/%s\n",
+		Version, Commit, Date, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+}
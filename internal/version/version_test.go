@@ -0,0 +1,20 @@
+package version
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestInfoIncludesBuildAndPlatform(t *testing.T) {
+	origVersion, origCommit, origDate := Version, Commit, Date
+	t.Cleanup(func() { Version, Commit, Date = origVersion, origCommit, origDate })
+	Version, Commit, Date = "1.2.3", "abc1234", "2024-05-01T00:00:00Z"
+
+	info := Info()
+	for _, want := range []string{"1.2.3", "abc1234", "2024-05-01T00:00:00Z", runtime.Version(), runtime.GOOS + "/" + runtime.GOARCH} {
+		if !strings.Contains(info, want) {
+			t.Fatalf("expected %q in %q", want, info)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// ServiceSpec is the subset of an SPCS service specification shown in the UI.
+type ServiceSpec struct {
+	Containers []SpecContainer `json:"containers"`
+	Endpoints  []SpecEndpoint  `json:"endpoints"`
+}
+
+// SpecContainer is a container declared in a service spec.
+type SpecContainer struct {
+	Name  string   `json:"name"`
+	Image ImageRef `json:"image"`
+}
+
+// SpecEndpoint is a network endpoint declared in a service spec.
+type SpecEndpoint struct {
+	Name      string `json:"name"`
+	Port      int    `json:"port,omitempty"`
+	PortRange string `json:"portRange,omitempty"`
+	Protocol  string `json:"protocol"`
+	Public    bool   `json:"public"`
+}
+
+// ImageRef is a container image reference split into its registry parts.
+// Database and Schema are filled from the service's namespace when the spec
+// uses a path relative to it.
+type ImageRef struct {
+	Raw        string `json:"raw"`
+	Registry   string `json:"registry,omitempty"`
+	Database   string `json:"database,omitempty"`
+	Schema     string `json:"schema,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	Name       string `json:"name"`
+	Tag        string `json:"tag,omitempty"`
+	Digest     string `json:"digest,omitempty"`
+}
+
+// RepositoryPath returns the repository as database.schema.repository.
+func (r ImageRef) RepositoryPath() string {
+	parts := []string{}
+	for _, p := range []string{r.Database, r.Schema, r.Repository} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+type rawSpec struct {
+	Spec struct {
+		Containers []struct {
+			Name  string `yaml:"name"`
+			Image string `yaml:"image"`
+		} `yaml:"containers"`
+		Endpoints []struct {
+			Name      string `yaml:"name"`
+			Port      int    `yaml:"port"`
+			PortRange string `yaml:"portRange"`
+			Protocol  string `yaml:"protocol"`
+			Public    bool   `yaml:"public"`
+		} `yaml:"endpoints"`
+	} `yaml:"spec"`
+}
+
+// ParseServiceSpec extracts containers and endpoints from a service spec YAML.
+// Relative image paths resolve against database and schema.
+func ParseServiceSpec(raw, database, schema string) (ServiceSpec, error) {
+	var doc rawSpec
+	if err := yaml.Unmarshal([]byte(raw), &doc); err != nil {
+		return ServiceSpec{}, fmt.Errorf("parse service spec: %w", err)
+	}
+	spec := ServiceSpec{Containers: []SpecContainer{}, Endpoints: []SpecEndpoint{}}
+	for _, c := range doc.Spec.Containers {
+		spec.Containers = append(spec.Containers, SpecContainer{Name: c.Name, Image: ParseImageRef(c.Image, database, schema)})
+	}
+	for _, e := range doc.Spec.Endpoints {
+		protocol := strings.ToUpper(e.Protocol)
+		if protocol == "" {
+			protocol = "HTTP"
+		}
+		spec.Endpoints = append(spec.Endpoints, SpecEndpoint{Name: e.Name, Port: e.Port, PortRange: e.PortRange, Protocol: protocol, Public: e.Public})
+	}
+	return spec, nil
+}
+
+// ParseImageRef splits an image such as
+// org-acct.registry.snowflakecomputing.com/db/schema/repo/app:1.0 or the
+// relative /db/schema/repo/app:1.0 and repo/app forms. Missing database and
+// schema segments default to the given values; a missing tag means latest.
+func ParseImageRef(image, database, schema string) ImageRef {
+	ref := ImageRef{Raw: image}
+	path := strings.TrimSpace(image)
+	if first, rest, found := strings.Cut(path, "/"); found && strings.ContainsAny(first, ".:") {
+		ref.Registry = first
+		path = rest
+	}
+	path = strings.Trim(path, "/")
+
+	if name, digest, found := strings.Cut(path, "@"); found {
+		path, ref.Digest = name, digest
+	}
+	segments := strings.Split(path, "/")
+	last := segments[len(segments)-1]
+	if i := strings.LastIndex(last, ":"); i >= 0 {
+		ref.Name, ref.Tag = last[:i], last[i+1:]
+	} else {
+		ref.Name = last
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+
+	prefix := segments[:len(segments)-1]
+	ref.Database, ref.Schema = database, schema
+	switch len(prefix) {
+	case 0:
+	case 1:
+		ref.Repository = prefix[0]
+	case 2:
+		ref.Schema, ref.Repository = prefix[0], prefix[1]
+	default:
+		n := len(prefix)
+		ref.Database, ref.Schema, ref.Repository = prefix[n-3], prefix[n-2], prefix[n-1]
+	}
+	return ref
+}
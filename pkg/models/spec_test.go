@@ -0,0 +1,51 @@
+package models
+
+import "testing"
+
+func TestParseServiceSpec(t *testing.T) {
+	raw := `
+spec:
+  containers:
+  - name: main
+    image: /tutorial_db/data_schema/tutorial_repository/echo:1.2
+  - name: sidecar
+    image: myorg-acct.registry.snowflakecomputing.com/db/sch/repo/proxy@sha256:abc
+  - name: local
+    image: repo/worker
+  endpoints:
+  - name: api
+    port: 8000
+    public: true
+  - name: grpc
+    port: 9000
+    protocol: tcp
+`
+	spec, err := ParseServiceSpec(raw, "MYDB", "PUBLIC")
+	if err != nil {
+		t.Fatalf("ParseServiceSpec: %v", err)
+	}
+	if len(spec.Containers) != 3 || len(spec.Endpoints) != 2 {
+		t.Fatalf("unexpected spec: %+v", spec)
+	}
+	main := spec.Containers[0].Image
+	if main.RepositoryPath() != "tutorial_db.data_schema.tutorial_repository" || main.Name != "echo" || main.Tag != "1.2" {
+		t.Fatalf("unexpected main image: %+v", main)
+	}
+	sidecar := spec.Containers[1].Image
+	if sidecar.Registry != "myorg-acct.registry.snowflakecomputing.com" || sidecar.Digest != "sha256:abc" || sidecar.Tag != "" {
+		t.Fatalf("unexpected sidecar image: %+v", sidecar)
+	}
+	local := spec.Containers[2].Image
+	if local.RepositoryPath() != "MYDB.PUBLIC.repo" || local.Tag != "latest" {
+		t.Fatalf("relative image not resolved: %+v", local)
+	}
+	if ep := spec.Endpoints[0]; ep.Protocol != "HTTP" || !ep.Public || ep.Port != 8000 {
+		t.Fatalf("unexpected endpoint: %+v", ep)
+	}
+	if spec.Endpoints[1].Protocol != "TCP" {
+		t.Fatalf("protocol not normalized: %+v", spec.Endpoints[1])
+	}
+	if _, err := ParseServiceSpec("spec: [", "", ""); err == nil {
+		t.Fatalf("expected error for malformed YAML")
+	}
+}
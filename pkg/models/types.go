@@ -8,32 +8,113 @@ import (
 
 // Service represents an SPCS service record surfaced in the UI.
 type Service struct {
-	Namespace   string    `json:"namespace"`
-	Name        string    `json:"name"`
-	Status      string    `json:"status"`
-	ComputePool string    `json:"computePool"`
-	CreatedAt   time.Time `json:"createdAt"`
-	Age         string    `json:"age"`
+	Namespace   string    `json:"namespace" yaml:"namespace"`
+	Name        string    `json:"name" yaml:"name"`
+	Status      string    `json:"status" yaml:"status"`
+	ComputePool string    `json:"computePool" yaml:"computePool"`
+	CreatedAt   time.Time `json:"createdAt" yaml:"createdAt"`
+	Age         string    `json:"age" yaml:"age"`
+	// Owner is the owning role; the filter matches it in every layout.
+	Owner   string `json:"owner" yaml:"owner"`
+	Comment string `json:"comment" yaml:"comment"`
+	// Shown only in the wide table.
+	DNSName      string `json:"dnsName" yaml:"dnsName"`
+	MinInstances string `json:"minInstances" yaml:"minInstances"`
+	MaxInstances string `json:"maxInstances" yaml:"maxInstances"`
+	SpecDigest   string `json:"specDigest" yaml:"specDigest"`
 }
 
 // ComputePool represents a Snowpark compute pool record.
+//
+// The string node fields hold the raw SHOW COMPUTE POOLS values; the *Count
+// fields are their parsed form, zero when a value is missing or not a number.
 type ComputePool struct {
-	Name           string    `json:"name"`
-	State          string    `json:"state"`
-	MinNodes       string    `json:"minNodes"`
-	MaxNodes       string    `json:"maxNodes"`
-	InstanceFamily string    `json:"instanceFamily"`
-	CreatedAt      time.Time `json:"createdAt"`
-	Age            string    `json:"age"`
+	Name            string    `json:"name" yaml:"name"`
+	State           string    `json:"state" yaml:"state"`
+	MinNodes        string    `json:"minNodes" yaml:"minNodes"`
+	MaxNodes        string    `json:"maxNodes" yaml:"maxNodes"`
+	InstanceFamily  string    `json:"instanceFamily" yaml:"instanceFamily"`
+	ActiveNodes     string    `json:"activeNodes" yaml:"activeNodes"`
+	IdleNodes       string    `json:"idleNodes" yaml:"idleNodes"`
+	MinNodeCount    int       `json:"minNodeCount" yaml:"minNodeCount"`
+	MaxNodeCount    int       `json:"maxNodeCount" yaml:"maxNodeCount"`
+	ActiveNodeCount int       `json:"activeNodeCount" yaml:"activeNodeCount"`
+	IdleNodeCount   int       `json:"idleNodeCount" yaml:"idleNodeCount"`
+	AutoResume      bool      `json:"autoResume" yaml:"autoResume"`
+	CreatedAt       time.Time `json:"createdAt" yaml:"createdAt"`
+	Age             string    `json:"age" yaml:"age"`
+}
+
+// Utilization is the percentage of the pool's maximum size that is running.
+// It reports false when the maximum is unknown.
+func (p ComputePool) Utilization() (int, bool) {
+	if p.MaxNodeCount <= 0 {
+		return 0, false
+	}
+	return p.ActiveNodeCount * 100 / p.MaxNodeCount, true
+}
+
+// ComputePoolDetail is the full state of a compute pool from DESCRIBE
+// COMPUTE POOL, with its counts and timestamps parsed.
+type ComputePoolDetail struct {
+	Name            string    `json:"name"`
+	State           string    `json:"state"`
+	InstanceFamily  string    `json:"instanceFamily"`
+	MinNodes        int       `json:"minNodes"`
+	MaxNodes        int       `json:"maxNodes"`
+	ActiveNodes     int       `json:"activeNodes"`
+	IdleNodes       int       `json:"idleNodes"`
+	TargetNodes     int       `json:"targetNodes"`
+	NumServices     int       `json:"numServices"`
+	NumJobs         int       `json:"numJobs"`
+	AutoSuspendSecs int       `json:"autoSuspendSecs"`
+	AutoResume      bool      `json:"autoResume"`
+	Application     string    `json:"application"`
+	Owner           string    `json:"owner"`
+	Comment         string    `json:"comment"`
+	StatusMessage   string    `json:"statusMessage"`
+	CreatedOn       time.Time `json:"createdOn"`
+	ResumedOn       time.Time `json:"resumedOn"`
+	UpdatedOn       time.Time `json:"updatedOn"`
+}
+
+// PendingNodes is how many nodes the pool is still provisioning to reach
+// its target.
+func (d ComputePoolDetail) PendingNodes() int {
+	return max(d.TargetNodes-d.ActiveNodes, 0)
 }
 
 // ImageRepository represents an SPCS image repository.
 type ImageRepository struct {
-	Name          string    `json:"name"`
-	RepositoryURL string    `json:"repositoryUrl"`
-	Owner         string    `json:"owner"`
-	CreatedAt     time.Time `json:"createdAt"`
-	Age           string    `json:"age"`
+	Name          string    `json:"name" yaml:"name"`
+	RepositoryURL string    `json:"repositoryUrl" yaml:"repositoryUrl"`
+	Owner         string    `json:"owner" yaml:"owner"`
+	CreatedAt     time.Time `json:"createdAt" yaml:"createdAt"`
+	Age           string    `json:"age" yaml:"age"`
+}
+
+// ImageTag is one tag of an image in an SPCS image repository. Untagged
+// images appear once with an empty Tag.
+type ImageTag struct {
+	Image     string    `json:"image" yaml:"image"`
+	Tag       string    `json:"tag" yaml:"tag"`
+	Digest    string    `json:"digest" yaml:"digest"`
+	Path      string    `json:"path" yaml:"path"`
+	CreatedAt time.Time `json:"createdAt" yaml:"createdAt"`
+	Age       string    `json:"age" yaml:"age"`
+}
+
+// Job is an SPCS job service, run to completion by EXECUTE JOB SERVICE.
+// CompletedAt is zero while the job runs; Age counts from completion when
+// known and from creation otherwise.
+type Job struct {
+	Namespace   string    `json:"namespace" yaml:"namespace"`
+	Name        string    `json:"name" yaml:"name"`
+	Status      string    `json:"status" yaml:"status"`
+	ComputePool string    `json:"computePool" yaml:"computePool"`
+	CreatedAt   time.Time `json:"createdAt" yaml:"createdAt"`
+	CompletedAt time.Time `json:"completedAt" yaml:"completedAt"`
+	Age         string    `json:"age" yaml:"age"`
 }
 
 // ServiceInstance represents an SPCS service instance.
@@ -45,14 +126,116 @@ type ServiceInstance struct {
 	Age       string    `json:"age"`
 }
 
+// Endpoint is a network endpoint exposed by a running service.
+type Endpoint struct {
+	Name       string `json:"name"`
+	Port       string `json:"port"`
+	Protocol   string `json:"protocol"`
+	Public     bool   `json:"public"`
+	IngressURL string `json:"ingressUrl"`
+}
+
+// Database represents a Snowflake database.
+type Database struct {
+	Name      string    `json:"name"`
+	Owner     string    `json:"owner"`
+	CreatedAt time.Time `json:"createdAt"`
+	Age       string    `json:"age"`
+}
+
+// Schema represents a schema within a database.
+type Schema struct {
+	Name      string    `json:"name"`
+	Database  string    `json:"database"`
+	Owner     string    `json:"owner"`
+	CreatedAt time.Time `json:"createdAt"`
+	Age       string    `json:"age"`
+}
+
+// ServiceEvent is a status message reported for a service container.
+type ServiceEvent struct {
+	Time      time.Time `json:"time"`
+	Severity  string    `json:"severity"`
+	Status    string    `json:"status"`
+	Message   string    `json:"message"`
+	Container string    `json:"container"`
+	Instance  string    `json:"instance"`
+}
+
+// ContainerStatus is the readiness of one container in a service instance,
+// as reported by SYSTEM$GET_SERVICE_STATUS.
+type ContainerStatus struct {
+	Status        string    `json:"status"`
+	Message       string    `json:"message"`
+	ContainerName string    `json:"containerName"`
+	InstanceID    string    `json:"instanceId"`
+	RestartCount  int       `json:"restartCount"`
+	StartTime     time.Time `json:"startTime"`
+}
+
 const (
-	StatusRunning   = "running"
-	StatusStarting  = "starting"
-	StatusStopped   = "stopped"
-	StatusSuspended = "suspended"
+	SeverityInfo    = "info"
+	SeverityWarning = "warning"
+	SeverityError   = "error"
 )
 
-// FormatAge renders durations in the terse style used by k9s (s, m, h, d, w).
+// Canonical statuses of services, jobs, instances, and compute pools, as
+// returned by NormalizeStatus.
+const (
+	StatusPending       = "pending"
+	StatusStarting      = "starting"
+	StatusRunning       = "running"
+	StatusReady         = "ready"
+	StatusDone          = "done"
+	StatusFailed        = "failed"
+	StatusInternalError = "internal_error"
+	StatusCancelled     = "cancelled"
+	StatusSuspending    = "suspending"
+	StatusSuspended     = "suspended"
+	StatusStopping      = "stopping"
+	StatusStopped       = "stopped"
+	StatusDeleting      = "deleting"
+	StatusDeleted       = "deleted"
+	StatusActive        = "active"
+	StatusIdle          = "idle"
+	StatusResizing      = "resizing"
+)
+
+// statusAliases maps spellings seen in other columns and APIs to the
+// canonical status.
+var statusAliases = map[string]string{
+	"started":      StatusRunning,
+	"completed":    StatusDone,
+	"succeeded":    StatusDone,
+	"canceled":     StatusCancelled,
+	"paused":       StatusSuspended,
+	"init":         StatusPending,
+	"initializing": StatusPending,
+}
+
+// NormalizeStatus canonicalizes a status from any SPCS column or casing:
+// "RUNNING", " Running " and "started" all become "running", and "Internal
+// Error" becomes "internal_error". Unknown values are lowercased as-is.
+func NormalizeStatus(raw string) string {
+	status := strings.ToLower(strings.Join(strings.Fields(strings.ReplaceAll(raw, "-", " ")), "_"))
+	if alias, ok := statusAliases[status]; ok {
+		return alias
+	}
+	return status
+}
+
+// Calendar-ish units for FormatAge; ages are approximate, so a month is 30
+// days and a year 365.
+const (
+	ageDay   = 24 * time.Hour
+	ageWeek  = 7 * ageDay
+	ageMonth = 30 * ageDay
+	ageYear  = 365 * ageDay
+)
+
+// FormatAge renders durations in the terse style used by k9s (s, m, h, d, w,
+// mo, y). Like k9s it keeps the finer unit until the coarser one reads well,
+// so ages stay in months until two years.
 func FormatAge(d time.Duration) string {
 	if d < time.Minute {
 		seconds := int(d.Seconds())
@@ -67,10 +250,16 @@ func FormatAge(d time.Duration) string {
 	if d < 24*time.Hour {
 		return formatUnit(int(d.Hours()), "h")
 	}
-	if d < 7*24*time.Hour {
-		return formatUnit(int(d.Hours()/24), "d")
+	if d < ageWeek {
+		return formatUnit(int(d/ageDay), "d")
+	}
+	if d < ageMonth {
+		return formatUnit(int(d/ageWeek), "w")
+	}
+	if d < 2*ageYear {
+		return formatUnit(int(d/ageMonth), "mo")
 	}
-	return formatUnit(int(d.Hours()/(24*7)), "w")
+	return formatUnit(int(d/ageYear), "y")
 }
 
 func formatUnit(value int, suffix string) string {
@@ -82,10 +271,15 @@ func formatUnit(value int, suffix string) string {
 
 // HumanizeAge converts a creation timestamp into the k9s-style age.
 func HumanizeAge(created time.Time) string {
+	return HumanizeAgeWith(RealClock, created)
+}
+
+// HumanizeAgeWith is HumanizeAge measured against clock.
+func HumanizeAgeWith(clock Clock, created time.Time) string {
 	if created.IsZero() {
 		return ""
 	}
-	return FormatAge(time.Since(created))
+	return FormatAge(clock.Now().Sub(created))
 }
 
 // MatchesFilter reports whether any field contains the filter value (case-insensitive).
@@ -97,5 +291,6 @@ func (s Service) MatchesFilter(filter string) bool {
 	return strings.Contains(strings.ToLower(s.Name), needle) ||
 		strings.Contains(strings.ToLower(s.Namespace), needle) ||
 		strings.Contains(strings.ToLower(s.Status), needle) ||
-		strings.Contains(strings.ToLower(s.ComputePool), needle)
+		strings.Contains(strings.ToLower(s.ComputePool), needle) ||
+		strings.Contains(strings.ToLower(s.Owner), needle)
 }
@@ -15,6 +15,15 @@ func TestFormatAge(t *testing.T) {
 		{1 * time.Hour, "1h"},
 		{3 * 24 * time.Hour, "3d"},
 		{14 * 24 * time.Hour, "2w"},
+		{29 * 24 * time.Hour, "4w"},
+		{30 * 24 * time.Hour, "1mo"},
+		{59 * 24 * time.Hour, "1mo"},
+		{364 * 24 * time.Hour, "12mo"},
+		{365 * 24 * time.Hour, "12mo"},
+		{400 * 24 * time.Hour, "13mo"},
+		{729 * 24 * time.Hour, "24mo"},
+		{730 * 24 * time.Hour, "2y"},
+		{1200 * 24 * time.Hour, "3y"},
 	}
 	for _, c := range cases {
 		if got := FormatAge(c.d); got != c.ex {
@@ -31,12 +40,71 @@ func TestHumanizeAge(t *testing.T) {
 	}
 }
 
+func TestHumanizeAgeWithFakeClock(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(now)
+	created := now.Add(-90 * time.Second)
+	cases := []struct {
+		advance time.Duration
+		ex      string
+	}{
+		{0, "1m"},
+		{30 * time.Second, "2m"},
+		{58 * time.Minute, "1h"},
+		{47 * time.Hour, "2d"},
+		{12 * 24 * time.Hour, "2w"},
+	}
+	for _, c := range cases {
+		clock.Advance(c.advance)
+		if got := HumanizeAgeWith(clock, created); got != c.ex {
+			t.Fatalf("after %v expected %s got %s", c.advance, c.ex, got)
+		}
+	}
+	if got := HumanizeAgeWith(clock, time.Time{}); got != "" {
+		t.Fatalf("expected empty age for zero time got %q", got)
+	}
+}
+
 func TestMatchesFilter(t *testing.T) {
-	svc := Service{Namespace: "PUBLIC", Name: "hello", Status: "running", ComputePool: "x"}
+	svc := Service{Namespace: "PUBLIC", Name: "hello", Status: "running", ComputePool: "x", Owner: "ALICE_ROLE"}
 	if !svc.MatchesFilter("run") {
 		t.Fatalf("should match status")
 	}
+	if !svc.MatchesFilter("alice") {
+		t.Fatalf("should match owner")
+	}
 	if svc.MatchesFilter("nomatch") {
 		t.Fatalf("should not match")
 	}
 }
+
+func TestComputePoolUtilization(t *testing.T) {
+	if pct, ok := (ComputePool{ActiveNodeCount: 3, MaxNodeCount: 4}).Utilization(); !ok || pct != 75 {
+		t.Fatalf("expected 75%% got %d %v", pct, ok)
+	}
+	if _, ok := (ComputePool{ActiveNodeCount: 1}).Utilization(); ok {
+		t.Fatal("unknown max should report no utilization")
+	}
+}
+
+func TestNormalizeStatus(t *testing.T) {
+	cases := map[string]string{
+		"RUNNING":         StatusRunning,
+		" Running ":       StatusRunning,
+		"started":         StatusRunning,
+		"READY":           StatusReady,
+		"Completed":       StatusDone,
+		"INTERNAL_ERROR":  StatusInternalError,
+		"Internal Error":  StatusInternalError,
+		"canceled":        StatusCancelled,
+		"SUSPENDED":       StatusSuspended,
+		"":                "",
+		"SOMETHING_NEW":   "something_new",
+		"pending-restart": "pending_restart",
+	}
+	for raw, want := range cases {
+		if got := NormalizeStatus(raw); got != want {
+			t.Errorf("NormalizeStatus(%q) = %q, want %q", raw, got, want)
+		}
+	}
+}